@@ -198,6 +198,22 @@ func TestQsoTypeToModel_ValidQso_MinimalData(t *testing.T) {
 	assert.Equal(t, int64(14250000), result.Freq)
 	assert.Equal(t, "20250107", result.QsoDate)
 	assert.NotEmpty(t, result.AdditionalData)
+	assert.False(t, result.Gridsquare.Valid)
+	assert.False(t, result.ContestID.Valid)
+}
+
+func TestQsoTypeToModel_GeneratedColumns(t *testing.T) {
+	qso := types.Qso{
+		QsoDetails:       types.QsoDetails{Freq: "14250", ContestId: "CQ-WW-SSB"},
+		ContactedStation: types.ContactedStation{Call: "DL1ABC", Gridsquare: "JO62"},
+		LoggingStation:   types.LoggingStation{StationCallsign: "W1AW"},
+	}
+	result, err := QsoTypeToModel(qso)
+	require.NoError(t, err)
+	assert.Equal(t, "JO62", result.Gridsquare.String)
+	assert.Equal(t, "CQ-WW-SSB", result.ContestID.String)
+	assert.Equal(t, "W1AW", result.StationCallsign.String)
+	assert.False(t, result.Operator.Valid)
 }
 func TestQsoTypeToModel_DateNormalization_WithDashes(t *testing.T) {
 	qso := types.Qso{
@@ -140,9 +140,24 @@ func QsoTypeToModel(qso types.Qso) (models.Qso, error) {
 		RstRcvd:        qso.QsoDetails.RstRcvd,
 		Country:        qso.ContactedStation.Country,
 		AdditionalData: jsonData,
+
+		// Generated from additional_data by the database; never written, but kept in step so the model matches a
+		// freshly read row.
+		Gridsquare:         nullIfEmpty(additionalData.Gridsquare),
+		ContestID:          nullIfEmpty(additionalData.ContestId),
+		StationCallsign:    nullIfEmpty(additionalData.StationCallsign),
+		Operator:           nullIfEmpty(additionalData.Operator),
+		SigInfo:            nullIfEmpty(additionalData.SigInfo),
+		QrzComUploadStatus: nullIfEmpty(additionalData.QrzComUploadStatus),
+		SmQsoUploadStatus:  nullIfEmpty(additionalData.SmQsoUploadStatus),
 	}, nil
 }
 
+// nullIfEmpty mirrors json_extract on an omitempty field: an empty string is absent, i.e. NULL.
+func nullIfEmpty(s string) null.String {
+	return null.NewString(s, s != "")
+}
+
 func ContactedStationTypeToModel(station types.ContactedStation) (models.ContactedStation, error) {
 	additionalData := types.ContactedStationAdditionalData{
 		Address:      station.Address,
@@ -0,0 +1,220 @@
+package sqlite
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/goccy/go-json"
+)
+
+const (
+	adifVersion   = "3.1.5"
+	adifProgramID = "StationManager"
+
+	// adifAppPrefix prefixes application-defined fields, i.e. additional_data keys with no ADIF equivalent.
+	adifAppPrefix = "APP_" + adifProgramID + "_"
+)
+
+// ExportOptions selects the QSOs written by ExportLogbookADIF. Zero-valued fields are ignored.
+type ExportOptions struct {
+	// From and To bound the QSO start (qso_date + time_on, UTC). Both ends are inclusive, to the minute.
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	Bands []string `json:"bands"`
+	Modes []string `json:"modes"`
+
+	IncludeDeleted bool `json:"include_deleted"`
+}
+
+// ExportReport says what an export wrote.
+type ExportReport struct {
+	Qsos    int64   `json:"qsos"`    // QSOs written
+	Skipped []int64 `json:"skipped"` // IDs of QSOs that could not be written and were left out
+}
+
+// adifField maps a types.QsoAdditionalData field to its ADIF tag.
+type adifField struct {
+	tag   string
+	index int
+}
+
+// adifAdditionalFields lists every types.QsoAdditionalData field. The JSON keys are the ADIF field names in lower
+// case; the Station Manager upload fields have no ADIF equivalent and are written as application-defined fields.
+var adifAdditionalFields = func() []adifField {
+	t := reflect.TypeOf(types.QsoAdditionalData{})
+	fields := make([]adifField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		key, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if key == emptyString || key == "-" {
+			continue
+		}
+		tag := strings.ToUpper(key)
+		if strings.HasPrefix(key, "sm_") {
+			tag = adifAppPrefix + tag
+		}
+		fields = append(fields, adifField{tag: tag, index: i})
+	}
+	return fields
+}()
+
+// ExportLogbookADIF writes the logbook's QSOs to w as an ADIF (.adi) file, oldest first, and reports how many were
+// written and which could not be. Rows are read and written one at a time, so memory use does not grow with the
+// logbook. The connection is held until the export finishes; no timeout is applied, so cancel ctx to stop it.
+func (s *Service) ExportLogbookADIF(ctx context.Context, logbookID int64, w io.Writer, opts ExportOptions) (ExportReport, error) {
+	const op errors.Op = "sqlite.Service.ExportLogbookADIF"
+	if err := checkService(op, s); err != nil {
+		return ExportReport{}, err
+	}
+
+	if w == nil {
+		return ExportReport{}, errors.New(op).Msg("Writer cannot be nil.")
+	}
+
+	filter := QsoFilter{LogbookID: logbookID, Bands: opts.Bands, Modes: opts.Modes, From: opts.From, To: opts.To}
+	mods, err := filter.whereMods()
+	if err != nil {
+		return ExportReport{}, errors.New(op).Err(err)
+	}
+	if opts.IncludeDeleted {
+		mods = append(mods, qm.WithDeleted())
+	}
+	mods = append(mods, qm.OrderBy(qsoChronological))
+
+	h, err := s.executor(ctx, op)
+	if err != nil {
+		return ExportReport{}, err
+	}
+
+	if _, err = fetchLogbookByID(ctx, h, op, logbookID); err != nil {
+		return ExportReport{}, err
+	}
+
+	aw := &adifWriter{w: bufio.NewWriter(w)}
+	aw.header(time.Now())
+
+	var report ExportReport
+	for model, er := range iterateQsoModels(ctx, h, mods) {
+		if er != nil {
+			err = er
+			break
+		}
+		if er = aw.record(model); er != nil {
+			s.LoggerService.WarnWith().Int64("qso.id", model.ID).Err(er).Msg("Skipping QSO in ADIF export.")
+			report.Skipped = append(report.Skipped, model.ID)
+			continue
+		}
+		if aw.err != nil {
+			break
+		}
+		report.Qsos++
+	}
+	if err == nil {
+		err = aw.flush()
+	}
+	if err != nil {
+		return report, errors.New(op).Err(err).Msg("Failed to export QSOs.")
+	}
+
+	return report, nil
+}
+
+// adifWriter writes ADI output, remembering the first write error so callers can check once per record.
+type adifWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (a *adifWriter) write(s string) {
+	if a.err == nil {
+		_, a.err = a.w.WriteString(s)
+	}
+}
+
+func (a *adifWriter) flush() error {
+	if a.err == nil {
+		a.err = a.w.Flush()
+	}
+	return a.err
+}
+
+func (a *adifWriter) header(now time.Time) {
+	a.write("ADIF export generated by Station Manager\n")
+	a.field("ADIF_VER", adifVersion)
+	a.write("\n")
+	a.field("PROGRAMID", adifProgramID)
+	a.write("\n")
+	a.field("CREATED_TIMESTAMP", now.UTC().Format("20060102 150405"))
+	a.write("\n<EOH>\n")
+}
+
+// record writes a single QSO. It returns an error, and writes nothing, if the QSO's additional_data cannot be
+// decoded.
+func (a *adifWriter) record(model *models.Qso) error {
+	var data types.QsoAdditionalData
+	if len(model.AdditionalData) > 0 {
+		if err := json.Unmarshal(model.AdditionalData, &data); err != nil {
+			return err
+		}
+	}
+
+	a.field("CALL", model.Call)
+	a.field("QSO_DATE", model.QsoDate)
+	a.field("TIME_ON", model.TimeOn)
+	a.field("TIME_OFF", model.TimeOff)
+	a.field("BAND", model.Band)
+	a.field("MODE", model.Mode)
+	a.field("FREQ", freqKHzToMHz(model.Freq))
+	a.field("RST_SENT", model.RstSent)
+	a.field("RST_RCVD", model.RstRcvd)
+	a.field("COUNTRY", model.Country)
+
+	v := reflect.ValueOf(data)
+	for _, f := range adifAdditionalFields {
+		value := v.Field(f.index).String()
+		if f.tag == "FREQ_RX" {
+			value = freqRxToMHz(value)
+		}
+		a.field(f.tag, value)
+	}
+
+	a.write("<EOR>\n")
+	return nil
+}
+
+// field writes a data specifier and its value; empty values are left out. The length is the value's size in
+// bytes, so readers that count bytes stay aligned even if a value is not plain ASCII.
+func (a *adifWriter) field(tag, value string) {
+	if value == emptyString {
+		return
+	}
+	a.write("<" + tag + ":" + strconv.Itoa(len(value)) + ">" + value + " ")
+}
+
+// freqKHzToMHz converts the qso.freq column (kHz) to an ADIF frequency (MHz).
+func freqKHzToMHz(khz int64) string {
+	if khz <= 0 {
+		return emptyString
+	}
+	return fmt.Sprintf("%d.%03d", khz/1000, khz%1000)
+}
+
+// freqRxToMHz converts freq_rx to MHz when it is held in kHz like the freq column; anything else is assumed to be
+// in MHz already.
+func freqRxToMHz(value string) string {
+	khz, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return value
+	}
+	return freqKHzToMHz(khz)
+}
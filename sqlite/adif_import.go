@@ -0,0 +1,498 @@
+package sqlite
+
+import (
+	"context"
+	stderr "errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Station-Manager/database/sqlite/adapters"
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
+	"github.com/goccy/go-json"
+)
+
+const (
+	defaultImportBatchSize     = 500
+	defaultImportProgressEvery = 100
+
+	importChangeReason = "ADIF import"
+)
+
+// ImportOptions controls ImportLogbookADIF.
+type ImportOptions struct {
+	// SessionID is the session the imported QSOs belong to. If zero, a new session is created for the import.
+	SessionID int64 `json:"session_id"`
+
+	// Duplicates says what to do with a record that matches an active QSO in the logbook with the same call, band
+	// and mode starting within DuplicateWindow (zero: the same minute). Defaults to ImportSkipDuplicates.
+	Duplicates      ImportDuplicatePolicy `json:"duplicates"`
+	DuplicateWindow time.Duration         `json:"duplicate_window"`
+
+	// BatchSize is the number of records written per transaction.
+	BatchSize int `json:"batch_size"`
+
+	// Progress, if set, is called every ProgressEvery records and once more when the import ends.
+	Progress      func(ImportProgress) `json:"-"`
+	ProgressEvery int                  `json:"progress_every"`
+}
+
+// ImportProgress reports how far an import has got.
+type ImportProgress struct {
+	Records   int64 `json:"records"`    // Records read so far
+	Inserted  int64 `json:"inserted"`   // QSOs inserted
+	Updated   int64 `json:"updated"`    // Existing QSOs updated
+	Skipped   int64 `json:"skipped"`    // Duplicates skipped
+	Rejected  int64 `json:"rejected"`   // Records that could not be imported
+	BytesRead int64 `json:"bytes_read"` // Position in the input
+}
+
+// ImportRejection is a record that could not be imported.
+type ImportRejection struct {
+	Record int64  `json:"record"` // 1-based position of the record in the file
+	Offset int64  `json:"offset"` // Byte offset of the record in the file
+	Call   string `json:"call"`
+	Reason string `json:"reason"`
+}
+
+// ImportReport is the outcome of an import. Counts only include batches that were committed.
+type ImportReport struct {
+	SessionID int64             `json:"session_id"`
+	Records   int64             `json:"records"`
+	Inserted  int64             `json:"inserted"`
+	Updated   int64             `json:"updated"`
+	Skipped   int64             `json:"skipped"`
+	Rejected  []ImportRejection `json:"rejected"`
+}
+
+// ImportLogbookADIF reads an ADI or ADX file and adds its QSOs to the logbook. Records are written in batches of
+// opts.BatchSize, each in its own transaction. If ctx is cancelled, or the file cannot be read any further, the
+// batch being written is rolled back and the report covers the batches committed before it.
+func (s *Service) ImportLogbookADIF(ctx context.Context, logbookID int64, r io.Reader, opts ImportOptions) (ImportReport, error) {
+	const op errors.Op = "sqlite.Service.ImportLogbookADIF"
+	if err := checkService(op, s); err != nil {
+		return ImportReport{}, err
+	}
+
+	if r == nil {
+		return ImportReport{}, errors.New(op).Msg("Reader cannot be nil.")
+	}
+
+	switch opts.Duplicates {
+	case emptyString:
+		opts.Duplicates = ImportSkipDuplicates
+	case ImportSkipDuplicates, ImportUpdateDuplicates, ImportInsertDuplicates:
+	default:
+		return ImportReport{}, errors.New(op).Msgf("Unknown import duplicate policy: %q", opts.Duplicates)
+	}
+	if opts.DuplicateWindow < 0 {
+		return ImportReport{}, errors.New(op).Msg("Duplicate window cannot be negative.")
+	}
+	if opts.BatchSize < 1 {
+		opts.BatchSize = defaultImportBatchSize
+	}
+	if opts.ProgressEvery < 1 {
+		opts.ProgressEvery = defaultImportProgressEvery
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := s.FetchLogbookByIDWithContext(ctx, logbookID); err != nil {
+		return ImportReport{}, err
+	}
+
+	report := ImportReport{SessionID: opts.SessionID, Rejected: []ImportRejection{}}
+	if report.SessionID == 0 {
+		id, err := s.GenerateSessionWithContext(ctx)
+		if err != nil {
+			return report, errors.New(op).Err(err)
+		}
+		report.SessionID = id
+	}
+
+	imp := &adifImport{
+		logbookID: logbookID,
+		sessionID: report.SessionID,
+		opts:      opts,
+		reader:    newAdifRecordReader(r),
+	}
+
+	for {
+		batch, readErr := imp.readBatch(ctx)
+
+		if len(batch) > 0 {
+			err := s.WithTx(WithChangeReason(ctx, importChangeReason), func(tx *Tx) error {
+				return imp.writeBatch(tx, batch)
+			})
+			if err != nil {
+				imp.rollback()
+				imp.progress()
+				return imp.report(report), errors.New(op).Err(err).Msg("ADIF import stopped.")
+			}
+			imp.commit()
+		}
+
+		if readErr != nil {
+			if stderr.Is(readErr, io.EOF) {
+				break
+			}
+			imp.progress()
+			return imp.report(report), errors.New(op).Err(readErr).Msg("ADIF import stopped.")
+		}
+	}
+
+	imp.progress()
+	return imp.report(report), nil
+}
+
+// adifImport tracks an import between batches. The pending counters belong to the batch being written and are
+// only added to the totals once it commits.
+type adifImport struct {
+	logbookID int64
+	sessionID int64
+	opts      ImportOptions
+	reader    adifRecordReader
+
+	records                    int64
+	inserted, updated, skipped int64
+	rejected                   []ImportRejection
+
+	pending struct {
+		inserted, updated, skipped int64
+		rejected                   []ImportRejection
+	}
+}
+
+// readBatch reads up to BatchSize records. Records rejected while converting them are still returned so they are
+// reported in order.
+func (i *adifImport) readBatch(ctx context.Context) ([]adifRecord, error) {
+	batch := make([]adifRecord, 0, i.opts.BatchSize)
+	for len(batch) < i.opts.BatchSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		rec, err := i.reader.next()
+		if err != nil {
+			return batch, err
+		}
+		batch = append(batch, rec)
+	}
+	return batch, nil
+}
+
+func (i *adifImport) writeBatch(tx *Tx, batch []adifRecord) error {
+	for _, rec := range batch {
+		if err := tx.Context().Err(); err != nil {
+			return err
+		}
+
+		i.records++
+		call := strings.TrimSpace(rec.fields["CALL"])
+
+		err := rec.err
+		if err == nil {
+			err = tx.WithTx(func(tx *Tx) error {
+				return i.writeRecord(tx, rec)
+			})
+		}
+		if err != nil {
+			if ctxErr := tx.Context().Err(); ctxErr != nil {
+				return ctxErr
+			}
+			i.pending.rejected = append(i.pending.rejected, ImportRejection{
+				Record: i.records,
+				Offset: rec.offset,
+				Call:   call,
+				Reason: importRejectionReason(err),
+			})
+		}
+
+		if i.records%int64(i.opts.ProgressEvery) == 0 {
+			i.progress()
+		}
+	}
+
+	return nil
+}
+
+// writeRecord converts, de-duplicates and stores one record.
+func (i *adifImport) writeRecord(tx *Tx, rec adifRecord) error {
+	qso, err := adifRecordToQso(rec.fields)
+	if err != nil {
+		return err
+	}
+	qso.LogbookID = i.logbookID
+	qso.SessionID = i.sessionID
+
+	model, err := adapters.QsoTypeToModel(qso)
+	if err != nil {
+		return err
+	}
+
+	if i.opts.Duplicates != ImportInsertDuplicates {
+		existingID, er := findDuplicateQso(tx.ctx, tx.tx, &model, i.opts.DuplicateWindow)
+		if er != nil {
+			return er
+		}
+		if existingID > 0 {
+			if i.opts.Duplicates == ImportSkipDuplicates {
+				i.pending.skipped++
+				return nil
+			}
+			if er = tx.mergeImportedQso(existingID, &model); er != nil {
+				return er
+			}
+			i.pending.updated++
+			return nil
+		}
+	}
+
+	if err = tx.insertQsoModel(&model); err != nil {
+		return err
+	}
+	i.pending.inserted++
+	return nil
+}
+
+func (i *adifImport) commit() {
+	i.inserted += i.pending.inserted
+	i.updated += i.pending.updated
+	i.skipped += i.pending.skipped
+	i.rejected = append(i.rejected, i.pending.rejected...)
+	i.rollback()
+}
+
+func (i *adifImport) rollback() {
+	i.pending.inserted, i.pending.updated, i.pending.skipped = 0, 0, 0
+	i.pending.rejected = nil
+}
+
+func (i *adifImport) progress() {
+	if i.opts.Progress == nil {
+		return
+	}
+	i.opts.Progress(ImportProgress{
+		Records:   i.records,
+		Inserted:  i.inserted + i.pending.inserted,
+		Updated:   i.updated + i.pending.updated,
+		Skipped:   i.skipped + i.pending.skipped,
+		Rejected:  int64(len(i.rejected) + len(i.pending.rejected)),
+		BytesRead: i.reader.offset(),
+	})
+}
+
+func (i *adifImport) report(r ImportReport) ImportReport {
+	r.Records = i.inserted + i.updated + i.skipped + int64(len(i.rejected))
+	r.Inserted = i.inserted
+	r.Updated = i.updated
+	r.Skipped = i.skipped
+	r.Rejected = append(r.Rejected, i.rejected...)
+	return r
+}
+
+// mergeImportedQso updates an existing QSO with an imported one. Core columns and additional_data keys present in
+// the import replace the stored values; everything else is kept.
+func (t *Tx) mergeImportedQso(id int64, imported *models.Qso) error {
+	existing, err := models.FindQso(t.ctx, t.tx, id)
+	if err != nil {
+		return err
+	}
+
+	existing.Call = imported.Call
+	existing.Band = imported.Band
+	existing.Mode = imported.Mode
+	existing.QsoDate = imported.QsoDate
+	existing.TimeOn = imported.TimeOn
+	existing.TimeOff = imported.TimeOff
+	if imported.Freq > 0 {
+		existing.Freq = imported.Freq
+	}
+	if imported.RstSent != emptyString {
+		existing.RstSent = imported.RstSent
+	}
+	if imported.RstRcvd != emptyString {
+		existing.RstRcvd = imported.RstRcvd
+	}
+	if imported.Country != emptyString {
+		existing.Country = imported.Country
+	}
+
+	data, err := additionalDataMap(existing.AdditionalData)
+	if err != nil {
+		return err
+	}
+	overlay, err := additionalDataMap(imported.AdditionalData)
+	if err != nil {
+		return err
+	}
+	for k, v := range overlay {
+		data[k] = v
+	}
+	if existing.AdditionalData, err = json.Marshal(data); err != nil {
+		return err
+	}
+
+	return t.updateQsoModel(existing, changeReason(t.ctx))
+}
+
+// importRejectionReason reports the root cause, which is more useful than the operation chain wrapped around it.
+func importRejectionReason(err error) string {
+	if root := errors.Root(err); root != nil && root.Error() != emptyString {
+		return root.Error()
+	}
+	return err.Error()
+}
+
+/**********************************************************************************************************************
+ * ADIF record to types.Qso
+ **********************************************************************************************************************/
+
+// adifQsoFields maps upper-case ADIF field names to the string fields of types.Qso that hold them, using the JSON
+// names, which are the ADIF names in lower case. Station Manager's own upload fields are read from the
+// application-defined fields written by ExportLogbookADIF.
+var adifQsoFields = func() map[string][]int {
+	fields := map[string][]int{}
+
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			path := append(append([]int{}, index...), i)
+			if f.Anonymous && f.Type.Kind() == reflect.Struct {
+				walk(f.Type, path)
+				continue
+			}
+			if f.Type.Kind() != reflect.String || f.Tag.Get("adapter") == "ignore" {
+				continue
+			}
+			key, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if key == emptyString || key == "-" {
+				continue
+			}
+			name := strings.ToUpper(key)
+			if strings.HasPrefix(key, "sm_") {
+				name = strings.ToUpper(adifAppPrefix) + name
+			}
+			fields[name] = path
+		}
+	}
+	walk(reflect.TypeOf(types.Qso{}), nil)
+
+	return fields
+}()
+
+// adifRecordToQso converts an ADIF record. FREQ and FREQ_RX are converted from MHz to kHz, times are cut to HHMM,
+// a missing TIME_OFF is taken from TIME_ON and a missing BAND is derived from FREQ.
+func adifRecordToQso(fields map[string]string) (types.Qso, error) {
+	var qso types.Qso
+	v := reflect.ValueOf(&qso).Elem()
+
+	for name, value := range fields {
+		path, ok := adifQsoFields[strings.ToUpper(name)]
+		if !ok {
+			continue
+		}
+		v.FieldByIndex(path).SetString(strings.TrimSpace(value))
+	}
+
+	if qso.ContactedStation.Call == emptyString {
+		return qso, stderr.New("CALL is missing")
+	}
+	if qso.QsoDetails.QsoDate == emptyString {
+		return qso, stderr.New("QSO_DATE is missing")
+	}
+	if qso.QsoDetails.TimeOn == emptyString {
+		return qso, stderr.New("TIME_ON is missing")
+	}
+	if qso.QsoDetails.Mode == emptyString {
+		return qso, stderr.New("MODE is missing")
+	}
+
+	qso.ContactedStation.Call = strings.ToUpper(qso.ContactedStation.Call)
+	if _, err := time.Parse(qslDateLayout, qso.QsoDetails.QsoDate); err != nil {
+		return qso, fmt.Errorf("QSO_DATE %q is not a valid date", qso.QsoDetails.QsoDate)
+	}
+	qso.QsoDetails.TimeOn = adifTimeToHHMM(qso.QsoDetails.TimeOn)
+	if _, err := time.Parse("1504", qso.QsoDetails.TimeOn); err != nil {
+		return qso, fmt.Errorf("TIME_ON %q is not a valid time", qso.QsoDetails.TimeOn)
+	}
+	if qso.QsoDetails.TimeOff == emptyString {
+		qso.QsoDetails.TimeOff = qso.QsoDetails.TimeOn
+	}
+	qso.QsoDetails.TimeOff = adifTimeToHHMM(qso.QsoDetails.TimeOff)
+	if _, err := time.Parse("1504", qso.QsoDetails.TimeOff); err != nil {
+		return qso, fmt.Errorf("TIME_OFF %q is not a valid time", qso.QsoDetails.TimeOff)
+	}
+
+	khz, err := freqMHzToKHz(qso.QsoDetails.Freq)
+	if err != nil {
+		return qso, fmt.Errorf("FREQ %q is not a frequency in MHz", qso.QsoDetails.Freq)
+	}
+	qso.QsoDetails.Freq = strconv.FormatInt(khz, 10)
+
+	if qso.QsoDetails.FreqRx != emptyString {
+		rx, er := freqMHzToKHz(qso.QsoDetails.FreqRx)
+		if er != nil {
+			return qso, fmt.Errorf("FREQ_RX %q is not a frequency in MHz", qso.QsoDetails.FreqRx)
+		}
+		qso.QsoDetails.FreqRx = strconv.FormatInt(rx, 10)
+	}
+
+	if qso.QsoDetails.Band == emptyString {
+		qso.QsoDetails.Band = bandForFreqKHz(khz)
+		if qso.QsoDetails.Band == emptyString {
+			return qso, stderr.New("BAND is missing and cannot be derived from FREQ")
+		}
+	}
+
+	return qso, nil
+}
+
+// adifTimeToHHMM drops the seconds from an HHMMSS time; the qso table stores HHMM.
+func adifTimeToHHMM(t string) string {
+	if len(t) == 6 {
+		return t[:4]
+	}
+	return t
+}
+
+// freqMHzToKHz converts an ADIF frequency (MHz) to the kHz used by the freq column. An empty value is 0.
+func freqMHzToKHz(mhz string) (int64, error) {
+	if mhz == emptyString {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(mhz, 64)
+	if err != nil || f < 0 {
+		return 0, stderr.New("invalid frequency")
+	}
+	return int64(math.Round(f * 1000)), nil
+}
+
+// adifBands holds the ADIF band edges in kHz.
+var adifBands = []struct {
+	name         string
+	lower, upper int64
+}{
+	{"2190m", 135, 138}, {"630m", 472, 479}, {"560m", 501, 504}, {"160m", 1800, 2000}, {"80m", 3500, 4000},
+	{"60m", 5060, 5450}, {"40m", 7000, 7300}, {"30m", 10100, 10150}, {"20m", 14000, 14350}, {"17m", 18068, 18168},
+	{"15m", 21000, 21450}, {"12m", 24890, 24990}, {"10m", 28000, 29700}, {"8m", 40000, 45000}, {"6m", 50000, 54000},
+	{"4m", 70000, 71000}, {"2m", 144000, 148000}, {"1.25m", 222000, 225000}, {"70cm", 420000, 450000},
+	{"33cm", 902000, 928000}, {"23cm", 1240000, 1300000}, {"13cm", 2300000, 2450000},
+}
+
+// bandForFreqKHz returns the ADIF band containing the frequency, or an empty string.
+func bandForFreqKHz(khz int64) string {
+	for _, b := range adifBands {
+		if khz >= b.lower && khz <= b.upper {
+			return b.name
+		}
+	}
+	return emptyString
+}
@@ -0,0 +1,192 @@
+package sqlite
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdiReader(t *testing.T) {
+	const input = "Header <text>\n<ADIF_VER:5>3.1.4<EOH>\n" +
+		"<CALL:4>DL1A<MODE:3>SSB<EOR>\n" +
+		"<CALL:x>DL1B<EOR>\n" +
+		"<call:4:S>DL1C<eor>"
+
+	r := newAdifRecordReader(strings.NewReader(input))
+
+	rec, err := r.next()
+	require.NoError(t, err)
+	assert.NoError(t, rec.err)
+	assert.Equal(t, int64(strings.Index(input, "<CALL:4>DL1A")), rec.offset)
+	assert.Equal(t, map[string]string{"CALL": "DL1A", "MODE": "SSB"}, rec.fields)
+
+	rec, err = r.next()
+	require.NoError(t, err)
+	assert.Error(t, rec.err)
+	assert.Equal(t, int64(strings.Index(input, "<CALL:x>")), rec.offset)
+
+	rec, err = r.next()
+	require.NoError(t, err)
+	assert.NoError(t, rec.err)
+	assert.Equal(t, "DL1C", rec.fields["CALL"])
+
+	_, err = r.next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestAdxReader(t *testing.T) {
+	const input = `<?xml version="1.0"?><ADX><HEADER><ADIF_VER>3.1.4</ADIF_VER></HEADER><RECORDS>` +
+		`<RECORD><CALL>JA1X</CALL><APP PROGRAMID="StationManager" FIELDNAME="sm_qso_upload_status">Y</APP></RECORD>` +
+		`</RECORDS></ADX>`
+
+	r := newAdifRecordReader(strings.NewReader(input))
+
+	rec, err := r.next()
+	require.NoError(t, err)
+	assert.Equal(t, int64(strings.Index(input, "<RECORD>")), rec.offset)
+	assert.Equal(t, "JA1X", rec.fields["CALL"])
+	assert.Equal(t, "Y", rec.fields["APP_STATIONMANAGER_SM_QSO_UPLOAD_STATUS"])
+
+	_, err = r.next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestAdifRecordToQso(t *testing.T) {
+	qso, err := adifRecordToQso(map[string]string{
+		"CALL": "dl1abc", "QSO_DATE": "20250107", "TIME_ON": "143015", "MODE": "FT8", "FREQ": "14.074",
+		"GRIDSQUARE": "JO62", "APP_STATIONMANAGER_SM_QSO_UPLOAD_STATUS": "Y",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "DL1ABC", qso.ContactedStation.Call)
+	assert.Equal(t, "1430", qso.QsoDetails.TimeOn)
+	assert.Equal(t, "1430", qso.QsoDetails.TimeOff)
+	assert.Equal(t, "14074", qso.QsoDetails.Freq)
+	assert.Equal(t, "20m", qso.QsoDetails.Band)
+	assert.Equal(t, "JO62", qso.ContactedStation.Gridsquare)
+	assert.Equal(t, "Y", qso.SmQsoUploadStatus)
+
+	_, err = adifRecordToQso(map[string]string{"CALL": "DL1ABC", "QSO_DATE": "20250107", "TIME_ON": "1430", "MODE": "CW"})
+	assert.EqualError(t, err, "BAND is missing and cannot be derived from FREQ")
+}
+
+func TestImportLogbookADIFDuplicates(t *testing.T) {
+	const input = "<EOH>\n" +
+		"<CALL:6>DL1ABC<QSO_DATE:8>20250107<TIME_ON:6>143045<BAND:3>20m<MODE:3>SSB<RST_SENT:2>57<NAME:6>Johann<EOR>\n" +
+		"<CALL:6>DL9XYZ<QSO_DATE:8>20250107<TIME_ON:4>1500<FREQ:6>14.250<MODE:3>SSB<EOR>\n"
+
+	for _, tc := range []struct {
+		test                       string
+		policy                     ImportDuplicatePolicy
+		inserted, updated, skipped int64
+		name, rst                  string
+	}{
+		{test: "default", policy: emptyString, inserted: 1, skipped: 1, name: "Hans", rst: "59"},
+		{test: string(ImportSkipDuplicates), policy: ImportSkipDuplicates, inserted: 1, skipped: 1, name: "Hans", rst: "59"},
+		{test: string(ImportUpdateDuplicates), policy: ImportUpdateDuplicates, inserted: 1, updated: 1, name: "Johann", rst: "57"},
+		{test: string(ImportInsertDuplicates), policy: ImportInsertDuplicates, inserted: 2, name: "Hans", rst: "59"},
+	} {
+		t.Run(tc.test, func(t *testing.T) {
+			s := newTestService(t)
+			lb, sess := newTestLogbook(t, s)
+			qso := testQso(lb, sess, "DL1ABC", "20250107", "1430")
+			qso.ContactedStation.Name = "Hans"
+			qso.QsoDetails.Comment = "portable"
+			id, err := s.InsertQso(qso)
+			require.NoError(t, err)
+
+			report, err := s.ImportLogbookADIF(context.Background(), lb, strings.NewReader(input),
+				ImportOptions{SessionID: sess, Duplicates: tc.policy})
+			require.NoError(t, err)
+			assert.Equal(t, ImportReport{SessionID: sess, Records: 2, Inserted: tc.inserted, Updated: tc.updated,
+				Skipped: tc.skipped, Rejected: []ImportRejection{}}, report)
+
+			stored, err := s.FetchQsoById(id)
+			require.NoError(t, err)
+			assert.Equal(t, tc.name, stored.ContactedStation.Name)
+			assert.Equal(t, tc.rst, stored.QsoDetails.RstSent)
+			assert.Equal(t, "portable", stored.QsoDetails.Comment, "keys missing from the import are kept")
+			assert.Equal(t, "Germany", stored.ContactedStation.Country)
+
+			var count int64
+			require.NoError(t, s.handle.QueryRow(`SELECT count(*) FROM qso WHERE logbook_id = ?`, lb).Scan(&count))
+			assert.Equal(t, 1+tc.inserted, count)
+		})
+	}
+}
+
+func TestImportLogbookADIFRejections(t *testing.T) {
+	const input = "<EOH>\n" +
+		"<CALL:6>DL1ABC<QSO_DATE:8>20250107<TIME_ON:4>1430<BAND:3>20m<MODE:3>SSB<EOR>\n" +
+		"<CALL:6>DL2ABC<QSO_DATE:8>20250107<TIME_ON:4>1431<BAND:3>20m<EOR>\n" +
+		"<CALL:x>DL3ABC<EOR>\n" +
+		"<CALL:6>DL4ABC<QSO_DATE:8>20251307<TIME_ON:4>1433<BAND:3>20m<MODE:3>SSB<EOR>\n"
+
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+
+	var progress []ImportProgress
+	report, err := s.ImportLogbookADIF(context.Background(), lb, strings.NewReader(input), ImportOptions{
+		SessionID:     sess,
+		BatchSize:     3,
+		ProgressEvery: 2,
+		Progress:      func(p ImportProgress) { progress = append(progress, p) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), report.Records)
+	assert.Equal(t, int64(1), report.Inserted)
+	assert.Equal(t, []ImportRejection{
+		{Record: 2, Offset: int64(strings.Index(input, "<CALL:6>DL2ABC")), Call: "DL2ABC", Reason: "MODE is missing"},
+		{Record: 3, Offset: int64(strings.Index(input, "<CALL:x>")), Reason: report.Rejected[1].Reason},
+		{Record: 4, Offset: int64(strings.Index(input, "<CALL:6>DL4ABC")), Call: "DL4ABC",
+			Reason: `QSO_DATE "20251307" is not a valid date`},
+	}, report.Rejected)
+	assert.NotEmpty(t, report.Rejected[1].Reason)
+
+	// Every second record, then once at the end.
+	require.Len(t, progress, 3)
+	assert.Equal(t, ImportProgress{Records: 2, Inserted: 1, Rejected: 1, BytesRead: progress[0].BytesRead}, progress[0])
+	assert.Equal(t, ImportProgress{Records: 4, Inserted: 1, Rejected: 3, BytesRead: int64(len(input))}, progress[2])
+	assert.Equal(t, progress[1].Records, progress[2].Records)
+	assert.Positive(t, progress[0].BytesRead)
+	assert.Less(t, progress[0].BytesRead, int64(len(input)))
+}
+
+func TestImportLogbookADIFCancel(t *testing.T) {
+	var input strings.Builder
+	input.WriteString("<EOH>\n")
+	for i := 0; i < 6; i++ {
+		input.WriteString("<CALL:6>DL1ABC<QSO_DATE:8>20250107<TIME_ON:4>14" + strconv.Itoa(10+i) +
+			"<BAND:3>20m<MODE:3>SSB<EOR>\n")
+	}
+
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+
+	// Cancel in the middle of the second batch: the first batch stays, the second is rolled back.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var last ImportProgress
+	report, err := s.ImportLogbookADIF(ctx, lb, strings.NewReader(input.String()), ImportOptions{
+		SessionID:     sess,
+		BatchSize:     2,
+		ProgressEvery: 1,
+		Progress: func(p ImportProgress) {
+			last = p
+			if p.Records == 3 {
+				cancel()
+			}
+		},
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, ImportReport{SessionID: sess, Records: 2, Inserted: 2, Rejected: []ImportRejection{}}, report)
+	assert.Equal(t, int64(2), last.Inserted, "the final progress leaves out the rolled back batch")
+
+	var count int64
+	require.NoError(t, s.handle.QueryRow(`SELECT count(*) FROM qso WHERE logbook_id = ?`, lb).Scan(&count))
+	assert.Equal(t, int64(2), count)
+}
@@ -0,0 +1,247 @@
+package sqlite
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	stderr "errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxAdifSpecifierLen bounds a data specifier (<NAME:LENGTH:TYPE>) so a stray '<' cannot swallow the file.
+const maxAdifSpecifierLen = 128
+
+// adifRecord is one QSO read from an ADIF file. Field names are upper case. If the record is malformed err says
+// why; fields may then be incomplete.
+type adifRecord struct {
+	offset int64 // Byte offset of the start of the record
+	fields map[string]string
+	err    error
+}
+
+// adifRecordReader returns records until io.EOF. Any other error means the rest of the file cannot be read.
+type adifRecordReader interface {
+	next() (adifRecord, error)
+	offset() int64
+}
+
+// newAdifRecordReader detects the format from the start of the file: ADX is XML, anything else is read as ADI.
+func newAdifRecordReader(r io.Reader) adifRecordReader {
+	br := bufio.NewReader(r)
+	start, _ := br.Peek(512)
+	start = bytes.TrimLeft(bytes.TrimPrefix(start, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if bytes.HasPrefix(start, []byte("<?xml")) || bytes.HasPrefix(bytes.ToUpper(start), []byte("<ADX")) {
+		return newAdxReader(br)
+	}
+	return &adiReader{r: br}
+}
+
+/**********************************************************************************************************************
+ * ADI
+ **********************************************************************************************************************/
+
+type adiReader struct {
+	r   *bufio.Reader
+	pos int64
+}
+
+func (a *adiReader) offset() int64 {
+	return a.pos
+}
+
+func (a *adiReader) next() (adifRecord, error) {
+	rec := adifRecord{offset: -1, fields: map[string]string{}}
+
+	for {
+		b, err := a.r.ReadByte()
+		if err != nil {
+			if stderr.Is(err, io.EOF) && rec.offset >= 0 {
+				if rec.err == nil {
+					rec.err = stderr.New("record is not terminated by <EOR>")
+				}
+				return rec, nil
+			}
+			return adifRecord{}, err
+		}
+		a.pos++
+		if b != '<' {
+			continue // Text between fields is ignored
+		}
+
+		start := a.pos - 1
+		name, length, err := a.specifier()
+		if err != nil {
+			if stderr.Is(err, io.EOF) {
+				continue
+			}
+			if rec.offset < 0 {
+				rec.offset = start
+			}
+			if rec.err == nil {
+				rec.err = err
+			}
+			continue
+		}
+
+		switch name {
+		case "EOH":
+			// Everything so far was the header.
+			rec = adifRecord{offset: -1, fields: map[string]string{}}
+			continue
+		case "EOR":
+			if rec.offset < 0 {
+				continue // Empty record
+			}
+			return rec, nil
+		}
+
+		if rec.offset < 0 {
+			rec.offset = start
+		}
+		if length < 0 {
+			if rec.err == nil {
+				rec.err = fmt.Errorf("field %s has no length", name)
+			}
+			continue
+		}
+
+		data := make([]byte, length)
+		n, err := io.ReadFull(a.r, data)
+		a.pos += int64(n)
+		if err != nil {
+			if rec.err == nil {
+				rec.err = fmt.Errorf("field %s is cut short by the end of the file", name)
+			}
+			continue
+		}
+		rec.fields[name] = string(data)
+	}
+}
+
+// specifier reads the rest of a data specifier after its '<' and returns the upper-cased field name and the data
+// length, which is -1 if the specifier has none.
+func (a *adiReader) specifier() (string, int, error) {
+	var spec []byte
+	for {
+		b, err := a.r.ReadByte()
+		if err != nil {
+			return emptyString, 0, err
+		}
+		a.pos++
+		if b == '>' {
+			break
+		}
+		if b == '<' || len(spec) >= maxAdifSpecifierLen {
+			_ = a.r.UnreadByte()
+			a.pos--
+			return emptyString, 0, fmt.Errorf("malformed data specifier <%s", spec)
+		}
+		spec = append(spec, b)
+	}
+
+	parts := strings.Split(string(spec), ":")
+	name := strings.ToUpper(strings.TrimSpace(parts[0]))
+	if name == emptyString {
+		return emptyString, 0, stderr.New("data specifier has no field name")
+	}
+	if len(parts) == 1 {
+		return name, -1, nil
+	}
+
+	length, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil || length < 0 {
+		return emptyString, 0, fmt.Errorf("field %s has an invalid length %q", name, parts[1])
+	}
+
+	return name, length, nil
+}
+
+/**********************************************************************************************************************
+ * ADX
+ **********************************************************************************************************************/
+
+type adxReader struct {
+	dec *xml.Decoder
+}
+
+func newAdxReader(r io.Reader) *adxReader {
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+	return &adxReader{dec: dec}
+}
+
+func (a *adxReader) offset() int64 {
+	return a.dec.InputOffset()
+}
+
+func (a *adxReader) next() (adifRecord, error) {
+	for {
+		offset := a.dec.InputOffset()
+		tok, err := a.dec.Token()
+		if err != nil {
+			return adifRecord{}, err
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch strings.ToUpper(se.Name.Local) {
+		case "HEADER":
+			if err = a.dec.Skip(); err != nil {
+				return adifRecord{}, err
+			}
+		case "RECORD":
+			return a.record(offset)
+		}
+	}
+}
+
+// record reads the fields of a RECORD element up to its end tag.
+func (a *adxReader) record(offset int64) (adifRecord, error) {
+	rec := adifRecord{offset: offset, fields: map[string]string{}}
+
+	for {
+		tok, err := a.dec.Token()
+		if err != nil {
+			return adifRecord{}, err
+		}
+		switch t := tok.(type) {
+		case xml.EndElement:
+			return rec, nil
+		case xml.StartElement:
+			var value struct {
+				Text string `xml:",chardata"`
+			}
+			if err = a.dec.DecodeElement(&value, &t); err != nil {
+				return adifRecord{}, err
+			}
+			if name := adxFieldName(t); name != emptyString {
+				rec.fields[name] = value.Text
+			}
+		}
+	}
+}
+
+// adxFieldName maps an ADX element to the ADI field name. APP elements become APP_<PROGRAMID>_<FIELDNAME>.
+func adxFieldName(se xml.StartElement) string {
+	name := strings.ToUpper(se.Name.Local)
+	attr := func(key string) string {
+		for _, a := range se.Attr {
+			if strings.EqualFold(a.Name.Local, key) {
+				return strings.ToUpper(a.Value)
+			}
+		}
+		return emptyString
+	}
+
+	switch name {
+	case "APP":
+		return "APP_" + attr("PROGRAMID") + "_" + attr("FIELDNAME")
+	case "USERDEF":
+		return attr("FIELDNAME")
+	}
+	return name
+}
@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdifWriterRecord(t *testing.T) {
+	var buf bytes.Buffer
+	aw := &adifWriter{w: bufio.NewWriter(&buf)}
+
+	model := &models.Qso{
+		Call:           "DL1ABC",
+		QsoDate:        "20250107",
+		TimeOn:         "1430",
+		Band:           "20m",
+		Mode:           "SSB",
+		Freq:           14250,
+		Country:        "Germany",
+		AdditionalData: []byte(`{"name":"Jürgen","freq_rx":"14255","sm_qso_upload_status":"Y"}`),
+	}
+	require.NoError(t, aw.record(model))
+	require.NoError(t, aw.flush())
+
+	out := buf.String()
+	assert.Contains(t, out, "<CALL:6>DL1ABC ")
+	assert.Contains(t, out, "<FREQ:6>14.250 ")
+	assert.Contains(t, out, "<FREQ_RX:6>14.255 ")
+	assert.Contains(t, out, "<NAME:7>Jürgen ")
+	assert.Contains(t, out, "<APP_StationManager_SM_QSO_UPLOAD_STATUS:1>Y ")
+	assert.NotContains(t, out, "TIME_OFF")
+	assert.Contains(t, out, "<EOR>\n")
+}
+
+func TestAdifWriterRecordBadData(t *testing.T) {
+	var buf bytes.Buffer
+	aw := &adifWriter{w: bufio.NewWriter(&buf)}
+
+	assert.Error(t, aw.record(&models.Qso{Call: "DL1ABC", AdditionalData: []byte(`{`)}))
+	require.NoError(t, aw.flush())
+	assert.Empty(t, buf.String())
+}
+
+func TestFreqKHzToMHz(t *testing.T) {
+	assert.Equal(t, "14.074", freqKHzToMHz(14074))
+	assert.Equal(t, "144.300", freqKHzToMHz(144300))
+	assert.Equal(t, "0.475", freqKHzToMHz(475))
+	assert.Equal(t, "", freqKHzToMHz(0))
+	assert.Equal(t, "7.1", freqRxToMHz("7.1"))
+}
+
+func TestExportLogbookADIFReportsSkipped(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+
+	_, err := s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1430"))
+	require.NoError(t, err)
+	bad, err := s.InsertQso(testQso(lb, sess, "DL2ABC", "20250107", "1431"))
+	require.NoError(t, err)
+	_, err = s.handle.Exec(`UPDATE qso SET additional_data = '{"name":5}' WHERE id = ?`, bad)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	report, err := s.ExportLogbookADIF(context.Background(), lb, &buf, ExportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, ExportReport{Qsos: 1, Skipped: []int64{bad}}, report)
+	assert.Contains(t, buf.String(), "<ADIF_VER:5>3.1.5 ", "MY_MORSE_KEY_* fields need 3.1.5")
+	assert.Contains(t, buf.String(), "<CALL:6>DL1ABC ")
+	assert.NotContains(t, buf.String(), "DL2ABC")
+}
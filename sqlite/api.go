@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"time"
 
 	"github.com/Station-Manager/enums/upload"
 	"github.com/Station-Manager/enums/upload/action"
@@ -17,6 +18,14 @@ func (s *Service) InsertQso(qso types.Qso) (int64, error) {
 	return s.InsertQsoWithContext(context.Background(), qso)
 }
 
+func (s *Service) InsertQsoChecked(qso types.Qso) (InsertQsoResult, error) {
+	return s.InsertQsoCheckedWithContext(context.Background(), qso)
+}
+
+func (s *Service) InsertQsoBatch(qsos []types.Qso, opts BatchOptions) ([]BatchItemResult, error) {
+	return s.InsertQsoBatchWithContext(context.Background(), qsos, opts)
+}
+
 func (s *Service) UpdateQso(qso types.Qso) error {
 	return s.UpdateQsoWithContext(context.Background(), qso)
 }
@@ -49,6 +58,90 @@ func (s *Service) FetchQsoSlicePaging(logbookId, pageNum, pageSize int64, orderi
 	return s.FetchQsoSlicePagingWithContext(context.Background(), logbookId, pageNum, pageSize, ordering)
 }
 
+func (s *Service) FetchQsoSliceByCursor(logbookId int64, cursor string, pageSize int64, ordering Ordering) (QsoCursorPage, error) {
+	return s.FetchQsoSliceByCursorWithContext(context.Background(), logbookId, cursor, pageSize, ordering)
+}
+
+func (s *Service) SearchQsos(filter QsoFilter, page Page) (QsoSearchResult, error) {
+	return s.SearchQsosWithContext(context.Background(), filter, page)
+}
+
+func (s *Service) DeleteQso(id int64) error {
+	return s.DeleteQsoWithContext(context.Background(), id)
+}
+
+func (s *Service) RestoreQso(id int64) error {
+	return s.RestoreQsoWithContext(context.Background(), id)
+}
+
+func (s *Service) FetchDeletedQsosByLogbookId(id int64) (types.QsoSlice, error) {
+	return s.FetchDeletedQsosByLogbookIdWithContext(context.Background(), id)
+}
+
+func (s *Service) PurgeDeletedQsosOlderThan(age time.Duration) (int64, error) {
+	return s.PurgeDeletedQsosOlderThanWithContext(context.Background(), age)
+}
+
+func (s *Service) FetchQsoRevisions(qsoID int64) ([]QsoRevision, error) {
+	return s.FetchQsoRevisionsWithContext(context.Background(), qsoID)
+}
+
+func (s *Service) DiffQsoRevisions(fromRevisionID, toRevisionID int64) ([]QsoFieldChange, error) {
+	return s.DiffQsoRevisionsWithContext(context.Background(), fromRevisionID, toRevisionID)
+}
+
+func (s *Service) RevertQsoToRevision(revisionID int64) error {
+	return s.RevertQsoToRevisionWithContext(context.Background(), revisionID)
+}
+
+func (s *Service) SearchQsoText(logbookID int64, query string) ([]QsoTextMatch, error) {
+	return s.SearchQsoTextWithContext(context.Background(), logbookID, query)
+}
+
+/**********************************************************************************************************************
+ * QSL Methods
+ **********************************************************************************************************************/
+
+func (s *Service) SetQslStatus(qsl QslStatus) error {
+	return s.SetQslStatusWithContext(context.Background(), qsl)
+}
+
+func (s *Service) MarkQslSent(channel QslChannel, qsoIDs []int64, date string) error {
+	return s.MarkQslSentWithContext(context.Background(), channel, qsoIDs, date)
+}
+
+func (s *Service) MarkQslReceived(channel QslChannel, qsoIDs []int64, date string) error {
+	return s.MarkQslReceivedWithContext(context.Background(), channel, qsoIDs, date)
+}
+
+func (s *Service) FetchQslStatusByQsoId(qsoID int64) ([]QslStatus, error) {
+	return s.FetchQslStatusByQsoIdWithContext(context.Background(), qsoID)
+}
+
+func (s *Service) FetchQslRequestedNotSent(logbookID int64, channel QslChannel) ([]QsoQsl, error) {
+	return s.FetchQslRequestedNotSentWithContext(context.Background(), logbookID, channel)
+}
+
+func (s *Service) FetchQslSentNotConfirmed(logbookID int64, channel QslChannel) ([]QsoQsl, error) {
+	return s.FetchQslSentNotConfirmedWithContext(context.Background(), logbookID, channel)
+}
+
+/**********************************************************************************************************************
+ * Forwarding Rule Methods
+ **********************************************************************************************************************/
+
+func (s *Service) SetForwardingRule(rule ForwardingRule) (int64, error) {
+	return s.SetForwardingRuleWithContext(context.Background(), rule)
+}
+
+func (s *Service) DeleteForwardingRule(logbookID int64, service upload.OnlineService) error {
+	return s.DeleteForwardingRuleWithContext(context.Background(), logbookID, service)
+}
+
+func (s *Service) FetchForwardingRules(logbookID int64) ([]ForwardingRule, error) {
+	return s.FetchForwardingRulesWithContext(context.Background(), logbookID)
+}
+
 /**********************************************************************************************************************
  * ContactedStation Methods
  **********************************************************************************************************************/
@@ -65,6 +158,18 @@ func (s *Service) UpdateContactedStation(station types.ContactedStation) error {
 	return s.UpdateContactedStationWithContext(context.Background(), station)
 }
 
+func (s *Service) ListContactedStations(filter ContactedStationFilter, pageNum, pageSize int64) (ContactedStationList, error) {
+	return s.ListContactedStationsWithContext(context.Background(), filter, pageNum, pageSize)
+}
+
+func (s *Service) DeleteContactedStation(id int64) error {
+	return s.DeleteContactedStationWithContext(context.Background(), id)
+}
+
+func (s *Service) RestoreContactedStation(id int64) error {
+	return s.RestoreContactedStationWithContext(context.Background(), id)
+}
+
 /**********************************************************************************************************************
  * Country Methods
  **********************************************************************************************************************/
@@ -133,6 +238,10 @@ func (s *Service) IsContestDuplicateByLogbookID(id int64, callsign, band string)
 	return s.IsContestDuplicateByLogbookIDWithContext(context.Background(), id, callsign, band)
 }
 
+func (s *Service) IsContestDuplicateInContest(id int64, contestID, callsign, band string) (bool, error) {
+	return s.IsContestDuplicateInContestWithContext(context.Background(), id, contestID, callsign, band)
+}
+
 /**********************************************************************************************************************
  * Upload Methods
  **********************************************************************************************************************/
@@ -141,6 +250,50 @@ func (s *Service) FetchPendingUploads() ([]types.QsoUpload, error) {
 	return s.FetchPendingUploadsWithContext(context.Background())
 }
 
+func (s *Service) FetchPendingUploadsForService(service upload.OnlineService, limit int) ([]types.QsoUpload, error) {
+	return s.FetchPendingUploadsForServiceWithContext(context.Background(), service, limit)
+}
+
 func (s *Service) UpdateQsoUploadStatus(id int64, status status.Status, action action.Action, attempts int64, lastError string) error {
 	return s.UpdateQsoUploadStatusWithContext(context.Background(), id, status, action, attempts, lastError)
 }
+
+func (s *Service) ReleaseUploadLease(ids []int64) (int64, error) {
+	return s.ReleaseUploadLeaseWithContext(context.Background(), ids)
+}
+
+func (s *Service) FetchDeadUploads(service upload.OnlineService) ([]types.QsoUpload, error) {
+	return s.FetchDeadUploadsWithContext(context.Background(), service)
+}
+
+func (s *Service) RequeueDeadUploads(ids []int64) (int64, error) {
+	return s.RequeueDeadUploadsWithContext(context.Background(), ids)
+}
+
+func (s *Service) RetryUploadsNow(ids []int64) (int64, error) {
+	return s.RetryUploadsNowWithContext(context.Background(), ids)
+}
+
+func (s *Service) CancelUploads(ids []int64) (int64, error) {
+	return s.CancelUploadsWithContext(context.Background(), ids)
+}
+
+func (s *Service) RequeueFailedUploads(service upload.OnlineService) (int64, error) {
+	return s.RequeueFailedUploadsWithContext(context.Background(), service)
+}
+
+func (s *Service) PurgeUploadedOlderThan(age time.Duration) (int64, error) {
+	return s.PurgeUploadedOlderThanWithContext(context.Background(), age)
+}
+
+func (s *Service) RecordUploadAttempt(id int64, attempts int64, attempt UploadAttempt) error {
+	return s.RecordUploadAttemptWithContext(context.Background(), id, attempts, attempt)
+}
+
+func (s *Service) FetchUploadAttemptsByQsoId(qsoID int64) ([]UploadAttempt, error) {
+	return s.FetchUploadAttemptsByQsoIdWithContext(context.Background(), qsoID)
+}
+
+func (s *Service) FetchUploadAttemptsByService(service upload.OnlineService, limit int) ([]UploadAttempt, error) {
+	return s.FetchUploadAttemptsByServiceWithContext(context.Background(), service, limit)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	stderr "errors"
+	"slices"
 	"strings"
 	"time"
 
@@ -24,30 +25,56 @@ import (
  * QSO Methods
  **********************************************************************************************************************/
 
+// InsertQsoWithContext inserts the QSO in a transaction of its own and returns its ID; see Tx.InsertQsoChecked.
 func (s *Service) InsertQsoWithContext(ctx context.Context, qso types.Qso) (int64, error) {
-	const op errors.Op = "sqlite.Service.InsertQsoWithContext"
+	result, err := s.InsertQsoCheckedWithContext(ctx, qso)
+	return result.ID, err
+}
+
+// InsertQsoCheckedWithContext inserts the QSO in a transaction of its own; see Tx.InsertQsoChecked.
+func (s *Service) InsertQsoCheckedWithContext(ctx context.Context, qso types.Qso) (InsertQsoResult, error) {
+	const op errors.Op = "sqlite.Service.InsertQsoCheckedWithContext"
 	if err := checkService(op, s); err != nil {
-		return 0, err
+		return InsertQsoResult{}, err
 	}
 
-	h, err := s.getOpenHandle(op)
+	var result InsertQsoResult
+	err := s.WithTx(ctx, func(tx *Tx) error {
+		var err error
+		result, err = tx.InsertQsoChecked(qso)
+		return err
+	})
 	if err != nil {
-		return 0, err
+		return InsertQsoResult{}, err
 	}
 
-	ctx, cancel := s.ensureCtxTimeout(ctx)
-	defer cancel()
+	return result, nil
+}
 
-	model, err := adapters.QsoTypeToModel(qso)
-	if err != nil {
-		return 0, errors.New(op).Err(err)
+// InsertQsoBatchWithContext inserts the QSOs in a single transaction using one prepared statement. The returned
+// slice holds a result for every QSO that was attempted, in input order. With BatchStopOnFirstError the batch is
+// rolled back on the first failure, no IDs are returned and the error identifies the failing index.
+func (s *Service) InsertQsoBatchWithContext(ctx context.Context, qsos []types.Qso, opts BatchOptions) ([]BatchItemResult, error) {
+	const op errors.Op = "sqlite.Service.InsertQsoBatchWithContext"
+	if err := checkService(op, s); err != nil {
+		return nil, err
 	}
 
-	if err = model.Insert(ctx, h, boil.Infer()); err != nil {
-		return 0, errors.New(op).Err(err)
+	var results []BatchItemResult
+	err := s.WithTx(ctx, func(tx *Tx) error {
+		var err error
+		results, err = tx.InsertQsoBatch(qsos, opts)
+		return err
+	})
+	if err != nil {
+		// Nothing was committed, so none of the IDs exist.
+		for i := range results {
+			results[i].ID = 0
+		}
+		return results, err
 	}
 
-	return model.ID, nil
+	return results, nil
 }
 
 func (s *Service) FetchQsoSliceBySessionIDWithContext(ctx context.Context, id int64) (types.QsoSlice, error) {
@@ -60,7 +87,7 @@ func (s *Service) FetchQsoSliceBySessionIDWithContext(ctx context.Context, id in
 		return nil, errors.New(op).Msg(errMsgInvalidId)
 	}
 
-	h, err := s.getOpenHandle(op)
+	h, err := s.executor(ctx, op)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +127,7 @@ func (s *Service) FetchQsoSliceByCallsignWithContext(ctx context.Context, callsi
 		return nil, errors.New(op).Msg(errMsgEmptyCallsign)
 	}
 
-	h, err := s.getOpenHandle(op)
+	h, err := s.executor(ctx, op)
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +187,7 @@ func (s *Service) FetchQsoSliceByLogbookIdWithContext(ctx context.Context, id in
 		return nil, errors.New(op).Msg(errMsgInvalidId)
 	}
 
-	h, err := s.getOpenHandle(op)
+	h, err := s.executor(ctx, op)
 	if err != nil {
 		return nil, err
 	}
@@ -200,7 +227,7 @@ func (s *Service) FetchQsoCountByLogbookIdWithContext(ctx context.Context, id in
 		return 0, errors.New(op).Msg(errMsgInvalidId)
 	}
 
-	h, err := s.getOpenHandle(op)
+	h, err := s.executor(ctx, op)
 	if err != nil {
 		return 0, err
 	}
@@ -222,30 +249,9 @@ func (s *Service) UpdateQsoWithContext(ctx context.Context, qso types.Qso) error
 		return err
 	}
 
-	if qso.ID < 1 {
-		return errors.New(op).Msgf("QSO ID is invalid: %d", qso.ID)
-	}
-
-	h, err := s.getOpenHandle(op)
-	if err != nil {
-		return err
-	}
-
-	ctx, cancel := s.ensureCtxTimeout(ctx)
-	defer cancel()
-
-	model, err := adapters.QsoTypeToModel(qso)
-	if err != nil {
-		return errors.New(op).Err(err)
-	}
-
-	model.ModifiedAt = null.TimeFrom(time.Now())
-
-	if _, err = model.Update(ctx, h, boil.Infer()); err != nil {
-		return errors.New(op).Err(err)
-	}
-
-	return nil
+	return s.WithTx(ctx, func(tx *Tx) error {
+		return tx.UpdateQso(qso)
+	})
 }
 
 func (s *Service) FetchQsoSliceNotForwardedWithContext(ctx context.Context) (types.QsoSlice, error) {
@@ -254,7 +260,7 @@ func (s *Service) FetchQsoSliceNotForwardedWithContext(ctx context.Context) (typ
 		return nil, err
 	}
 
-	h, err := s.getOpenHandle(op)
+	h, err := s.executor(ctx, op)
 	if err != nil {
 		return nil, err
 	}
@@ -263,9 +269,9 @@ func (s *Service) FetchQsoSliceNotForwardedWithContext(ctx context.Context) (typ
 	defer cancel()
 
 	modelSlice, err := models.Qsos(
-		qm.Where(
-			"json_extract(\"qso\".\"additional_data\", '$.QrzComUploadStatus') IS NULL "+
-				"OR json_extract(\"qso\".\"additional_data\", '$.SmQsoUploadStatus') IS NULL",
+		qm.Expr(
+			models.QsoWhere.QrzComUploadStatus.IsNull(),
+			qm.Or2(models.QsoWhere.SmQsoUploadStatus.IsNull()),
 		),
 	).All(ctx, h)
 
@@ -296,30 +302,9 @@ func (s *Service) InsertQsoUploadWithContext(ctx context.Context, qsoId int64, a
 		return err
 	}
 
-	if qsoId < 1 {
-		return errors.New(op).Msgf("QSO ID is invalid: %d", qsoId)
-	}
-
-	h, err := s.getOpenHandle(op)
-	if err != nil {
-		return err
-	}
-
-	ctx, cancel := s.ensureCtxTimeout(ctx)
-	defer cancel()
-
-	model := models.QsoUpload{
-		QsoID:   qsoId,
-		Service: service.String(),
-		Action:  action.String(),
-		Status:  status.Pending.String(),
-	}
-
-	if err = model.Insert(ctx, h, boil.Infer()); err != nil {
-		return errors.New(op).Err(err).Msg("Inserting new QSO upload failed.")
-	}
-
-	return nil
+	return s.WithTx(ctx, func(tx *Tx) error {
+		return tx.InsertQsoUpload(qsoId, action, service)
+	})
 }
 
 func (s *Service) FetchQsoByIdWithContext(ctx context.Context, id int64) (types.Qso, error) {
@@ -332,7 +317,7 @@ func (s *Service) FetchQsoByIdWithContext(ctx context.Context, id int64) (types.
 		return types.Qso{}, errors.New(op).Msg(errMsgInvalidId)
 	}
 
-	h, err := s.getOpenHandle(op)
+	h, err := s.executor(ctx, op)
 	if err != nil {
 		return types.Qso{}, err
 	}
@@ -340,20 +325,7 @@ func (s *Service) FetchQsoByIdWithContext(ctx context.Context, id int64) (types.
 	ctx, cancel := s.ensureCtxTimeout(ctx)
 	defer cancel()
 
-	model, err := models.FindQso(ctx, h, id)
-	if err != nil {
-		if stderr.Is(err, sql.ErrNoRows) {
-			return types.Qso{}, errors.ErrNotFound
-		}
-		return types.Qso{}, errors.New(op).Err(err)
-	}
-
-	qso, err := adapters.QsoModelToType(model)
-	if err != nil {
-		return types.Qso{}, errors.New(op).Err(err)
-	}
-
-	return qso, nil
+	return fetchQsoByID(ctx, h, op, id)
 }
 
 func (s *Service) FetchQsoSlicePagingWithContext(ctx context.Context, logbookId, pageNum, pageSize int64, ordering Ordering) (types.QsoSlice, error) {
@@ -372,7 +344,7 @@ func (s *Service) FetchQsoSlicePagingWithContext(ctx context.Context, logbookId,
 		return nil, errors.New(op).Msg("Invalid page size. Must be greater than 0.")
 	}
 
-	h, err := s.getOpenHandle(op)
+	h, err := s.executor(ctx, op)
 	if err != nil {
 		return nil, err
 	}
@@ -410,305 +382,897 @@ func (s *Service) FetchQsoSlicePagingWithContext(ctx context.Context, logbookId,
 	return typeSlice, nil
 }
 
-/**********************************************************************************************************************
- * ContactedStation Methods
- **********************************************************************************************************************/
-
-func (s *Service) FetchContactedStationByCallsignWithContext(ctx context.Context, callsign string) (types.ContactedStation, error) {
-	const op errors.Op = "sqlite.Service.FetchContactedStationByCallsignWithContext"
+// FetchQsoSliceByCursorWithContext returns a page of QSOs for the logbook ordered by (qso_date, time_on, id). An
+// empty cursor returns the first page. Unlike FetchQsoSlicePagingWithContext, pages stay consistent while QSOs are
+// being inserted, and the cost of a page does not grow with its position in the log.
+func (s *Service) FetchQsoSliceByCursorWithContext(ctx context.Context, logbookId int64, cursor string, pageSize int64, ordering Ordering) (QsoCursorPage, error) {
+	const op errors.Op = "sqlite.Service.FetchQsoSliceByCursorWithContext"
 	if err := checkService(op, s); err != nil {
-		return types.ContactedStation{}, err
+		return QsoCursorPage{}, err
 	}
 
-	callsign = strings.TrimSpace(callsign)
-	if callsign == "" {
-		return types.ContactedStation{}, errors.New(op).Msg(errMsgEmptyCallsign)
+	if logbookId < 1 {
+		return QsoCursorPage{}, errors.New(op).Msg(errMsgInvalidId)
+	}
+	if pageSize < 1 {
+		return QsoCursorPage{}, errors.New(op).Msg("Invalid page size. Must be greater than 0.")
+	}
+	if ordering != Ascending && ordering != Descending {
+		return QsoCursorPage{}, errors.New(op).Msgf("Unknown sort order: %q", ordering)
+	}
+
+	var pos *qsoCursor
+	if cursor != emptyString {
+		c, err := decodeQsoCursor(cursor)
+		if err != nil {
+			return QsoCursorPage{}, errors.New(op).Err(err)
+		}
+		pos = &c
 	}
 
-	h, err := s.getOpenHandle(op)
+	h, err := s.executor(ctx, op)
 	if err != nil {
-		return types.ContactedStation{}, err
+		return QsoCursorPage{}, err
 	}
 
 	ctx, cancel := s.ensureCtxTimeout(ctx)
 	defer cancel()
 
-	model, err := models.ContactedStations(models.ContactedStationWhere.Call.EQ(callsign)).One(ctx, h)
-	if err != nil {
-		if stderr.Is(err, sql.ErrNoRows) {
-			return types.ContactedStation{}, errors.ErrNotFound
+	// Paging backwards walks the index in the opposite direction and reverses the rows afterwards.
+	backwards := pos != nil && pos.Before
+	scanOrder := ordering
+	if backwards {
+		scanOrder = Ascending
+		if ordering == Ascending {
+			scanOrder = Descending
 		}
-		return types.ContactedStation{}, errors.New(op).Err(err)
+	}
+	cmp := ">"
+	if scanOrder == Descending {
+		cmp = "<"
 	}
 
-	contactedStation, err := adapters.ContactedStationModelToType(model)
-	if err != nil {
-		return types.ContactedStation{}, errors.New(op).Err(err)
+	// INDEXED BY pins the plan to the date/time index; otherwise the planner tends to pick idx_qso_logbook_id
+	// and sort the whole logbook. The 'deleted_at IS NULL' term is required for the partial index to apply.
+	query := `SELECT "qso".* FROM "qso" INDEXED BY idx_qso_active_date_time
+		WHERE "qso"."deleted_at" IS NULL AND "qso"."logbook_id" = ?`
+	args := []interface{}{logbookId}
+	if pos != nil {
+		query += ` AND ("qso"."qso_date", "qso"."time_on", "qso"."id") ` + cmp + ` (?, ?, ?)`
+		args = append(args, pos.QsoDate, pos.TimeOn, pos.ID)
 	}
+	dir := scanOrder.String()
+	query += ` ORDER BY "qso"."qso_date" ` + dir + `, "qso"."time_on" ` + dir + `, "qso"."id" ` + dir + ` LIMIT ?`
+	// Fetch one extra row to find out whether there is anything beyond this page.
+	args = append(args, pageSize+1)
 
-	return contactedStation, nil
-}
+	var slice models.QsoSlice
+	if err = queries.Raw(query, args...).Bind(ctx, h, &slice); err != nil && !stderr.Is(err, sql.ErrNoRows) {
+		return QsoCursorPage{}, errors.New(op).Err(err).Msg("Failed to fetch QSO page.")
+	}
 
-func (s *Service) InsertContactedStationWithContext(ctx context.Context, station types.ContactedStation) (int64, error) {
-	const op errors.Op = "sqlite.Service.InsertContactedStationWithContext"
-	if err := checkService(op, s); err != nil {
-		return 0, err
+	hasMore := int64(len(slice)) > pageSize
+	if hasMore {
+		slice = slice[:pageSize]
+	}
+	if backwards {
+		slices.Reverse(slice)
 	}
 
-	h, err := s.getOpenHandle(op)
-	if err != nil {
-		return 0, err
+	page := QsoCursorPage{Qsos: make(types.QsoSlice, 0, len(slice))}
+	for _, qso := range slice {
+		typeQso, er := adapters.QsoModelToType(qso)
+		if er != nil {
+			s.LoggerService.WarnWith().Int64("qso.id", qso.ID).Err(er).Msg("Failed to adapt QSO for cursor page.")
+			continue
+		}
+		page.Qsos = append(page.Qsos, typeQso)
 	}
 
-	ctx, cancel := s.ensureCtxTimeout(ctx)
-	defer cancel()
+	if len(slice) == 0 {
+		return page, nil
+	}
 
-	model, err := adapters.ContactedStationTypeToModel(station)
-	if err != nil {
-		return 0, errors.New(op).Err(err)
+	first, last := slice[0], slice[len(slice)-1]
+	// Moving forwards, rows before this page exist whenever we started from a cursor; moving backwards, rows
+	// after this page always exist (the cursor row itself).
+	if (backwards && hasMore) || (!backwards && pos != nil) {
+		page.PrevCursor = qsoCursor{QsoDate: first.QsoDate, TimeOn: first.TimeOn, ID: first.ID, Before: true}.encode()
 	}
-	if err = model.Insert(ctx, h, boil.Infer()); err != nil {
-		return 0, errors.New(op).Err(err).Msg("Inserting new contacted station failed.")
+	if (!backwards && hasMore) || backwards {
+		page.NextCursor = qsoCursor{QsoDate: last.QsoDate, TimeOn: last.TimeOn, ID: last.ID}.encode()
 	}
 
-	return model.ID, nil
+	return page, nil
 }
 
-func (s *Service) UpdateContactedStationWithContext(ctx context.Context, station types.ContactedStation) error {
-	const op errors.Op = "sqlite.Service.UpdateContactedStationWithContext"
+func (s *Service) SearchQsosWithContext(ctx context.Context, filter QsoFilter, page Page) (QsoSearchResult, error) {
+	const op errors.Op = "sqlite.Service.SearchQsosWithContext"
 	if err := checkService(op, s); err != nil {
-		return err
+		return QsoSearchResult{}, err
 	}
 
-	h, err := s.getOpenHandle(op)
-	if err != nil {
-		return err
+	if page.Number < 1 {
+		return QsoSearchResult{}, errors.New(op).Msg("Invalid page number. Must be greater than 0.")
+	}
+	if page.Size < 1 {
+		return QsoSearchResult{}, errors.New(op).Msg("Invalid page size. Must be greater than 0.")
 	}
 
-	ctx, cancel := s.ensureCtxTimeout(ctx)
-	defer cancel()
-
-	model, err := adapters.ContactedStationTypeToModel(station)
+	where, err := filter.whereMods()
 	if err != nil {
-		return errors.New(op).Err(err)
+		return QsoSearchResult{}, errors.New(op).Err(err)
 	}
 
-	model.ModifiedAt = null.TimeFrom(time.Now())
-
-	_, err = model.Update(ctx, h, boil.Infer())
+	orderBy, err := qsoOrderBy(page.Sort)
 	if err != nil {
-		return errors.New(op).Err(err).Msg("Updating contacted station failed.")
+		return QsoSearchResult{}, errors.New(op).Err(err)
 	}
 
-	return nil
-}
+	h, err := s.executor(ctx, op)
+	if err != nil {
+		return QsoSearchResult{}, err
+	}
 
-/**********************************************************************************************************************
- * Country Methods
- **********************************************************************************************************************/
+	ctx, cancel := s.ensureCtxTimeout(ctx)
+	defer cancel()
 
-func (s *Service) FetchCountryByCallsignWithContext(ctx context.Context, callsign string) (types.Country, error) {
-	const op errors.Op = "sqlite.Service.FetchCountryByCallsignWithContext"
-	if err := checkService(op, s); err != nil {
-		return types.Country{}, err
+	total, err := models.Qsos(where...).Count(ctx, h)
+	if err != nil {
+		return QsoSearchResult{}, errors.New(op).Err(err).Msg("Failed to count QSOs matching filter.")
 	}
 
-	callsign = strings.TrimSpace(callsign)
-	if callsign == "" {
-		return types.Country{}, errors.New(op).Msg(errMsgEmptyCallsign)
+	result := QsoSearchResult{Qsos: make(types.QsoSlice, 0), Total: total}
+	offset := (page.Number - 1) * page.Size
+	if total == 0 || offset >= total {
+		return result, nil
 	}
 
-	h, err := s.getOpenHandle(op)
+	mods := append(where,
+		qm.OrderBy(orderBy),
+		qm.Limit(int(page.Size)),
+		qm.Offset(int(offset)),
+	)
+
+	slice, err := models.Qsos(mods...).All(ctx, h)
 	if err != nil {
-		return types.Country{}, err
+		return QsoSearchResult{}, errors.New(op).Err(err).Msg("Failed to search QSOs.")
 	}
 
-	ctx, cancel := s.ensureCtxTimeout(ctx)
-	defer cancel()
-
-	mods := []qm.QueryMod{
-		qm.Where("? LIKE "+models.TableNames.Country+".prefix || '%'", callsign),
-		qm.OrderBy("LENGTH(" + models.TableNames.Country + ".prefix) DESC"),
-		qm.Limit(1),
+	result.Qsos = make(types.QsoSlice, 0, len(slice))
+	for _, qso := range slice {
+		typeQso, er := adapters.QsoModelToType(qso)
+		if er != nil {
+			s.LoggerService.WarnWith().Int64("qso.id", qso.ID).Err(er).Msg("Failed to adapt QSO for search.")
+			continue
+		}
+		result.Qsos = append(result.Qsos, typeQso)
 	}
 
-	model, err := models.Countries(mods...).One(ctx, h)
-	if err != nil {
-		if stderr.Is(err, sql.ErrNoRows) {
-			return types.Country{}, errors.ErrNotFound
-		}
-		return types.Country{}, errors.New(op).Err(err)
+	return result, nil
+}
+
+func (s *Service) DeleteQsoWithContext(ctx context.Context, id int64) error {
+	const op errors.Op = "sqlite.Service.DeleteQsoWithContext"
+	if err := checkService(op, s); err != nil {
+		return err
 	}
 
-	country, err := adapters.CountryModelToType(model)
-	if err != nil {
-		return types.Country{}, errors.New(op).Err(err)
+	return s.WithTx(ctx, func(tx *Tx) error {
+		return tx.DeleteQso(id)
+	})
+}
+
+func (s *Service) RestoreQsoWithContext(ctx context.Context, id int64) error {
+	const op errors.Op = "sqlite.Service.RestoreQsoWithContext"
+	if err := checkService(op, s); err != nil {
+		return err
 	}
 
-	return country, nil
+	return s.WithTx(ctx, func(tx *Tx) error {
+		return tx.RestoreQso(id)
+	})
 }
 
-func (s *Service) FetchCountryByNameWithContext(ctx context.Context, name string) (types.Country, error) {
-	const op errors.Op = "sqlite.Service.FetchCountryByNameWithContext"
+func (s *Service) FetchDeletedQsosByLogbookIdWithContext(ctx context.Context, id int64) (types.QsoSlice, error) {
+	const op errors.Op = "sqlite.Service.FetchDeletedQsosByLogbookIdWithContext"
 	if err := checkService(op, s); err != nil {
-		return types.Country{}, err
+		return nil, err
 	}
 
-	name = strings.TrimSpace(name)
-	if name == "" {
-		return types.Country{}, errors.New(op).Msg("Country name cannot be empty")
+	if id < 1 {
+		return nil, errors.New(op).Msg(errMsgInvalidId)
 	}
 
-	h, err := s.getOpenHandle(op)
+	h, err := s.executor(ctx, op)
 	if err != nil {
-		return types.Country{}, err
+		return nil, err
 	}
 
 	ctx, cancel := s.ensureCtxTimeout(ctx)
 	defer cancel()
 
-	model, err := models.Countries(models.CountryWhere.Name.EQ(name)).One(ctx, h)
+	slice, err := models.Qsos(
+		qm.WithDeleted(),
+		models.QsoWhere.LogbookID.EQ(id),
+		models.QsoWhere.DeletedAt.IsNotNull(),
+		qm.OrderBy(models.QsoColumns.DeletedAt+" DESC"),
+	).All(ctx, h)
 	if err != nil {
-		if stderr.Is(err, sql.ErrNoRows) {
-			return types.Country{}, errors.ErrNotFound
-		}
-		return types.Country{}, errors.New(op).Err(err)
+		return nil, errors.New(op).Err(err).Msg("Failed to fetch deleted QSO slice.")
 	}
 
-	country, err := adapters.CountryModelToType(model)
-	if err != nil {
-		return types.Country{}, errors.New(op).Err(err)
+	typeSlice := make([]types.Qso, 0, len(slice))
+	for _, qso := range slice {
+		typeQso, er := adapters.QsoModelToType(qso)
+		if er != nil {
+			s.LoggerService.WarnWith().Int64("qso.id", qso.ID).Err(er).Msg("Failed to adapt deleted QSO.")
+			continue
+		}
+		typeSlice = append(typeSlice, typeQso)
 	}
 
-	return country, nil
+	return typeSlice, nil
 }
 
-func (s *Service) InsertCountryWithContext(ctx context.Context, country types.Country) (int64, error) {
-	const op errors.Op = "sqlite.Service.InsertCountryWithContext"
+// PurgeDeletedQsosOlderThanWithContext permanently removes QSOs that were soft deleted more than 'age' ago. QSOs
+// that still have outstanding uploads (e.g. a queued delete for a remote service) are kept until the queue drains.
+func (s *Service) PurgeDeletedQsosOlderThanWithContext(ctx context.Context, age time.Duration) (int64, error) {
+	const op errors.Op = "sqlite.Service.PurgeDeletedQsosOlderThanWithContext"
 	if err := checkService(op, s); err != nil {
 		return 0, err
 	}
 
-	h, err := s.getOpenHandle(op)
-	if err != nil {
-		return 0, err
+	if age < 0 {
+		return 0, errors.New(op).Msg("Age cannot be negative.")
 	}
 
-	ctx, cancel := s.ensureCtxTimeout(ctx)
-	defer cancel()
+	cutoff := time.Now().Add(-age)
 
-	model, err := adapters.CountryTypeToModel(country)
+	var count int64
+	err := s.WithTx(ctx, func(tx *Tx) error {
+		var er error
+		count, er = models.Qsos(
+			qm.WithDeleted(),
+			models.QsoWhere.DeletedAt.LT(null.TimeFrom(cutoff)),
+			qm.Where(
+				"NOT EXISTS (SELECT 1 FROM qso_upload WHERE qso_upload.qso_id = qso.id AND qso_upload.status IN (?, ?, ?))",
+				status.Pending.String(), status.InProgress.String(), status.Failed.String(),
+			),
+		).DeleteAll(tx.ctx, tx.tx, true)
+		if er != nil {
+			return errors.New(op).Err(er).Msg("Failed to purge deleted QSOs.")
+		}
+		if count > 0 {
+			tx.publish(ChangeEvent{Entity: ChangeQso, Action: action.Delete})
+		}
+		return nil
+	})
 	if err != nil {
-		return 0, errors.New(op).Err(err)
-	}
-	if err = model.Insert(ctx, h, boil.Infer()); err != nil {
-		return 0, errors.New(op).Err(err).Msg("Inserting new country failed.")
+		return 0, err
 	}
 
-	return model.ID, nil
+	return count, nil
 }
 
-func (s *Service) UpdateCountryWithContext(ctx context.Context, country types.Country) error {
-	const op errors.Op = "sqlite.Service.UpdateCountryWithContext"
+// FetchQsoRevisionsWithContext returns the revisions of a QSO, newest first.
+func (s *Service) FetchQsoRevisionsWithContext(ctx context.Context, qsoID int64) ([]QsoRevision, error) {
+	const op errors.Op = "sqlite.Service.FetchQsoRevisionsWithContext"
 	if err := checkService(op, s); err != nil {
-		return err
+		return nil, err
+	}
+
+	if qsoID < 1 {
+		return nil, errors.New(op).Msg(errMsgInvalidId)
 	}
 
-	h, err := s.getOpenHandle(op)
+	h, err := s.executor(ctx, op)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	ctx, cancel := s.ensureCtxTimeout(ctx)
 	defer cancel()
 
-	model, err := adapters.CountryTypeToModel(country)
+	slice, err := models.QsoRevisions(
+		models.QsoRevisionWhere.QsoID.EQ(qsoID),
+		qm.OrderBy(models.QsoRevisionColumns.ID+" DESC"),
+	).All(ctx, h)
 	if err != nil {
-		return errors.New(op).Err(err)
+		return nil, errors.New(op).Err(err).Msg("Failed to fetch QSO revisions.")
 	}
 
-	if _, err = model.Update(ctx, h, boil.Infer()); err != nil {
-		return errors.New(op).Err(err).Msg("Updating country failed.")
+	revisions := make([]QsoRevision, 0, len(slice))
+	for _, rev := range slice {
+		qso, er := adapters.QsoModelToType(qsoModelFromRevision(rev))
+		if er != nil {
+			s.LoggerService.WarnWith().Int64("qso_revision.id", rev.ID).Err(er).Msg("Failed to adapt QSO revision.")
+			continue
+		}
+		revisions = append(revisions, QsoRevision{
+			ID:        rev.ID,
+			QsoID:     rev.QsoID,
+			CreatedAt: rev.CreatedAt,
+			Action:    action.Action(rev.Action),
+			Reason:    rev.Reason.String,
+			Qso:       qso,
+		})
 	}
 
-	return nil
+	return revisions, nil
 }
 
-/**********************************************************************************************************************
- * Logbook Methods
- **********************************************************************************************************************/
-
-func (s *Service) FetchLogbookByIDWithContext(ctx context.Context, id int64) (types.Logbook, error) {
-	const op errors.Op = "sqlite.Service.FetchLogbookByIDWithContext"
+// DiffQsoRevisionsWithContext lists the fields that changed between two revisions of the same QSO. A toRevisionID
+// of zero compares against the QSO as it is now.
+func (s *Service) DiffQsoRevisionsWithContext(ctx context.Context, fromRevisionID, toRevisionID int64) ([]QsoFieldChange, error) {
+	const op errors.Op = "sqlite.Service.DiffQsoRevisionsWithContext"
 	if err := checkService(op, s); err != nil {
-		return types.Logbook{}, err
+		return nil, err
 	}
 
-	if id < 1 {
-		return types.Logbook{}, errors.New(op).Msg(errMsgInvalidId)
+	if fromRevisionID < 1 || toRevisionID < 0 {
+		return nil, errors.New(op).Msg(errMsgInvalidId)
 	}
-	h, err := s.getOpenHandle(op)
+
+	h, err := s.executor(ctx, op)
 	if err != nil {
-		return types.Logbook{}, err
+		return nil, err
 	}
 
 	ctx, cancel := s.ensureCtxTimeout(ctx)
 	defer cancel()
 
-	model, err := models.FindLogbook(ctx, h, id)
+	fromRev, err := models.FindQsoRevision(ctx, h, fromRevisionID)
+	if err != nil {
+		if stderr.Is(err, sql.ErrNoRows) {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.New(op).Err(err)
+	}
+
+	var to *models.Qso
+	if toRevisionID == 0 {
+		to, err = models.Qsos(qm.WithDeleted(), models.QsoWhere.ID.EQ(fromRev.QsoID)).One(ctx, h)
+	} else {
+		var toRev *models.QsoRevision
+		if toRev, err = models.FindQsoRevision(ctx, h, toRevisionID); err == nil {
+			if toRev.QsoID != fromRev.QsoID {
+				return nil, errors.New(op).Msgf("Revisions %d and %d belong to different QSOs.", fromRevisionID, toRevisionID)
+			}
+			to = qsoModelFromRevision(toRev)
+		}
+	}
 	if err != nil {
 		if stderr.Is(err, sql.ErrNoRows) {
-			return types.Logbook{}, errors.ErrNotFound
+			return nil, errors.ErrNotFound
 		}
-		return types.Logbook{}, errors.New(op).Err(err)
+		return nil, errors.New(op).Err(err)
 	}
 
-	logbook, err := adapters.LogbookModelToType(model)
+	changes, err := diffQsoModels(qsoModelFromRevision(fromRev), to)
 	if err != nil {
-		return types.Logbook{}, errors.New(op).Err(err)
+		return nil, errors.New(op).Err(err)
 	}
 
-	return logbook, nil
+	return changes, nil
 }
 
-func (s *Service) FetchAllLogbooksWithContext(ctx context.Context) ([]types.Logbook, error) {
-	const op errors.Op = "sqlite.Service.FetchAllLogbooksWithContext"
+func (s *Service) RevertQsoToRevisionWithContext(ctx context.Context, revisionID int64) error {
+	const op errors.Op = "sqlite.Service.RevertQsoToRevisionWithContext"
 	if err := checkService(op, s); err != nil {
-		return nil, err
+		return err
 	}
 
-	h, err := s.getOpenHandle(op)
-	if err != nil {
-		return nil, err
+	return s.WithTx(ctx, func(tx *Tx) error {
+		return tx.RevertQsoToRevision(revisionID)
+	})
+}
+
+// SearchQsoTextWithContext searches the notes, comment, QTH, name and address of the logbook's QSOs. Every word
+// in the query must match; end a word with '*' for a prefix search. Results are best match first, each with a
+// snippet of the matching text.
+func (s *Service) SearchQsoTextWithContext(ctx context.Context, logbookID int64, query string) ([]QsoTextMatch, error) {
+	const op errors.Op = "sqlite.Service.SearchQsoTextWithContext"
+	if err := checkService(op, s); err != nil {
+		return nil, err
+	}
+
+	if logbookID < 1 {
+		return nil, errors.New(op).Msg(errMsgInvalidId)
+	}
+
+	match := ftsMatchQuery(query)
+	if match == emptyString {
+		return nil, errors.New(op).Msg("Search query cannot be empty.")
+	}
+
+	h, err := s.executor(ctx, op)
+	if err != nil {
+		return nil, err
 	}
 
 	ctx, cancel := s.ensureCtxTimeout(ctx)
 	defer cancel()
 
-	list, err := models.Logbooks().All(ctx, h)
+	const search = `
+		SELECT "qso".*, bm25(qso_fts) AS rank, snippet(qso_fts, -1, ?, ?, ?, ?) AS snippet
+		  FROM qso_fts
+		  JOIN "qso" ON "qso"."id" = qso_fts.rowid
+		 WHERE qso_fts MATCH ?
+		   AND "qso"."logbook_id" = ?
+		   AND "qso"."deleted_at" IS NULL
+		 ORDER BY rank
+		 LIMIT ?`
+
+	var rows []struct {
+		models.Qso `boil:",bind"`
+		Rank       float64 `boil:"rank"`
+		Snippet    string  `boil:"snippet"`
+	}
+
+	err = queries.Raw(search, snippetMatchOpen, snippetMatchClose, snippetEllipsis, snippetMaxTokens,
+		match, logbookID, defaultTextSearchLimit).Bind(ctx, h, &rows)
+	if err != nil && !stderr.Is(err, sql.ErrNoRows) {
+		return nil, errors.New(op).Err(err).Msg("Failed to search QSO text.")
+	}
+
+	matches := make([]QsoTextMatch, 0, len(rows))
+	for i := range rows {
+		qso, er := adapters.QsoModelToType(&rows[i].Qso)
+		if er != nil {
+			s.LoggerService.WarnWith().Int64("qso.id", rows[i].ID).Err(er).Msg("Failed to adapt QSO for text search.")
+			continue
+		}
+		matches = append(matches, QsoTextMatch{Qso: qso, Rank: rows[i].Rank, Snippet: rows[i].Snippet})
+	}
+
+	return matches, nil
+}
+
+/**********************************************************************************************************************
+ * QSL Methods
+ **********************************************************************************************************************/
+
+func (s *Service) SetQslStatusWithContext(ctx context.Context, qsl QslStatus) error {
+	const op errors.Op = "sqlite.Service.SetQslStatusWithContext"
+	if err := checkService(op, s); err != nil {
+		return err
+	}
+
+	return s.WithTx(ctx, func(tx *Tx) error {
+		return tx.SetQslStatus(qsl)
+	})
+}
+
+// MarkQslSentWithContext marks all the QSOs as sent on the channel in one transaction. An empty date means today.
+func (s *Service) MarkQslSentWithContext(ctx context.Context, channel QslChannel, qsoIDs []int64, date string) error {
+	const op errors.Op = "sqlite.Service.MarkQslSentWithContext"
+	if err := checkService(op, s); err != nil {
+		return err
+	}
+
+	return s.WithTx(ctx, func(tx *Tx) error {
+		return tx.MarkQslSent(channel, qsoIDs, date)
+	})
+}
+
+// MarkQslReceivedWithContext marks all the QSOs as confirmed on the channel in one transaction. An empty date means
+// today.
+func (s *Service) MarkQslReceivedWithContext(ctx context.Context, channel QslChannel, qsoIDs []int64, date string) error {
+	const op errors.Op = "sqlite.Service.MarkQslReceivedWithContext"
+	if err := checkService(op, s); err != nil {
+		return err
+	}
+
+	return s.WithTx(ctx, func(tx *Tx) error {
+		return tx.MarkQslReceived(channel, qsoIDs, date)
+	})
+}
+
+// FetchQslStatusByQsoIdWithContext returns the QSL state of the QSO on every channel that has one.
+func (s *Service) FetchQslStatusByQsoIdWithContext(ctx context.Context, qsoID int64) ([]QslStatus, error) {
+	const op errors.Op = "sqlite.Service.FetchQslStatusByQsoIdWithContext"
+	if err := checkService(op, s); err != nil {
+		return nil, err
+	}
+
+	if qsoID < 1 {
+		return nil, errors.New(op).Msg(errMsgInvalidId)
+	}
+
+	h, err := s.executor(ctx, op)
 	if err != nil {
-		return nil, errors.New(op).Err(err)
+		return nil, err
 	}
 
-	result := make([]types.Logbook, 0, len(list))
-	for _, logbook := range list {
-		typeLogbook, er := adapters.LogbookModelToType(logbook)
+	ctx, cancel := s.ensureCtxTimeout(ctx)
+	defer cancel()
+
+	slice, err := models.Qsls(
+		models.QslWhere.QsoID.EQ(qsoID),
+		qm.OrderBy(models.QslColumns.Channel),
+	).All(ctx, h)
+	if err != nil {
+		return nil, errors.New(op).Err(err).Msg("Failed to fetch QSL status.")
+	}
+
+	statuses := make([]QslStatus, 0, len(slice))
+	for _, m := range slice {
+		statuses = append(statuses, qslStatusFromModel(m))
+	}
+
+	return statuses, nil
+}
+
+// FetchQslRequestedNotSentWithContext lists the logbook's active QSOs whose QSL is requested or queued but not yet
+// sent, oldest QSO first. An empty channel means all channels.
+func (s *Service) FetchQslRequestedNotSentWithContext(ctx context.Context, logbookID int64, channel QslChannel) ([]QsoQsl, error) {
+	const op errors.Op = "sqlite.Service.FetchQslRequestedNotSentWithContext"
+	// Literal status terms so that idx_qsl_requested_not_sent can be used.
+	return s.fetchQsoQsls(ctx, op, logbookID, channel, `"qsl"."sent_status" IN ('R', 'Q')`)
+}
+
+// FetchQslSentNotConfirmedWithContext lists the logbook's active QSOs whose QSL has been sent but not confirmed,
+// oldest QSO first. An empty channel means all channels.
+func (s *Service) FetchQslSentNotConfirmedWithContext(ctx context.Context, logbookID int64, channel QslChannel) ([]QsoQsl, error) {
+	const op errors.Op = "sqlite.Service.FetchQslSentNotConfirmedWithContext"
+	// Literal status terms so that idx_qsl_sent_not_confirmed can be used.
+	return s.fetchQsoQsls(ctx, op, logbookID, channel, `"qsl"."sent_status" = 'Y' AND "qsl"."rcvd_status" NOT IN ('Y', 'V')`)
+}
+
+func (s *Service) fetchQsoQsls(ctx context.Context, op errors.Op, logbookID int64, channel QslChannel, statusWhere string) ([]QsoQsl, error) {
+	if err := checkService(op, s); err != nil {
+		return nil, err
+	}
+
+	if logbookID < 1 {
+		return nil, errors.New(op).Msg(errMsgInvalidId)
+	}
+	if channel != emptyString && !validQslChannel(channel) {
+		return nil, errors.New(op).Msgf("Unknown QSL channel: %q", channel)
+	}
+
+	h, err := s.executor(ctx, op)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := s.ensureCtxTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT "qso".*, "qsl"."channel" AS qsl_channel, "qsl"."sent_status" AS qsl_sent_status,
+		       "qsl"."sent_date" AS qsl_sent_date, "qsl"."rcvd_status" AS qsl_rcvd_status,
+		       "qsl"."rcvd_date" AS qsl_rcvd_date
+		  FROM "qsl"
+		  JOIN "qso" ON "qso"."id" = "qsl"."qso_id"
+		 WHERE ` + statusWhere + `
+		   AND "qso"."logbook_id" = ?
+		   AND "qso"."deleted_at" IS NULL`
+	args := []any{logbookID}
+	if channel != emptyString {
+		query += ` AND "qsl"."channel" = ?`
+		args = append(args, channel.String())
+	}
+	query += ` ORDER BY "qso"."qso_date", "qso"."time_on", "qsl"."channel"`
+
+	var rows []qslRow
+	if err = queries.Raw(query, args...).Bind(ctx, h, &rows); err != nil && !stderr.Is(err, sql.ErrNoRows) {
+		return nil, errors.New(op).Err(err).Msg("Failed to fetch QSLs.")
+	}
+
+	result := make([]QsoQsl, 0, len(rows))
+	for i := range rows {
+		qso, er := adapters.QsoModelToType(&rows[i].Qso)
 		if er != nil {
-			return nil, errors.New(op).Err(er).Msg("Converting logbook model to type failed.")
+			s.LoggerService.WarnWith().Int64("qso.id", rows[i].ID).Err(er).Msg("Failed to adapt QSO for QSL list.")
+			continue
+		}
+		result = append(result, QsoQsl{Qso: qso, Qsl: rows[i].status()})
+	}
+
+	return result, nil
+}
+
+/**********************************************************************************************************************
+ * Forwarding Rule Methods
+ **********************************************************************************************************************/
+
+func (s *Service) SetForwardingRuleWithContext(ctx context.Context, rule ForwardingRule) (int64, error) {
+	const op errors.Op = "sqlite.Service.SetForwardingRuleWithContext"
+	if err := checkService(op, s); err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err := s.WithTx(ctx, func(tx *Tx) error {
+		var er error
+		id, er = tx.SetForwardingRule(rule)
+		return er
+	})
+
+	return id, err
+}
+
+func (s *Service) DeleteForwardingRuleWithContext(ctx context.Context, logbookID int64, service upload.OnlineService) error {
+	const op errors.Op = "sqlite.Service.DeleteForwardingRuleWithContext"
+	if err := checkService(op, s); err != nil {
+		return err
+	}
+
+	return s.WithTx(ctx, func(tx *Tx) error {
+		return tx.DeleteForwardingRule(logbookID, service)
+	})
+}
+
+// FetchForwardingRulesWithContext returns the logbook's forwarding rules, enabled or not, ordered by service.
+func (s *Service) FetchForwardingRulesWithContext(ctx context.Context, logbookID int64) ([]ForwardingRule, error) {
+	const op errors.Op = "sqlite.Service.FetchForwardingRulesWithContext"
+	if err := checkService(op, s); err != nil {
+		return nil, err
+	}
+
+	if logbookID < 1 {
+		return nil, errors.New(op).Msg(errMsgInvalidId)
+	}
+
+	h, err := s.executor(ctx, op)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := s.ensureCtxTimeout(ctx)
+	defer cancel()
+
+	rules, err := fetchForwardingRules(ctx, h, logbookID, false)
+	if err != nil {
+		return nil, errors.New(op).Err(err).Msg("Failed to fetch forwarding rules.")
+	}
+
+	return rules, nil
+}
+
+/**********************************************************************************************************************
+ * ContactedStation Methods
+ **********************************************************************************************************************/
+
+func (s *Service) FetchContactedStationByCallsignWithContext(ctx context.Context, callsign string) (types.ContactedStation, error) {
+	const op errors.Op = "sqlite.Service.FetchContactedStationByCallsignWithContext"
+	if err := checkService(op, s); err != nil {
+		return types.ContactedStation{}, err
+	}
+
+	callsign = strings.TrimSpace(callsign)
+	if callsign == "" {
+		return types.ContactedStation{}, errors.New(op).Msg(errMsgEmptyCallsign)
+	}
+
+	h, err := s.executor(ctx, op)
+	if err != nil {
+		return types.ContactedStation{}, err
+	}
+
+	ctx, cancel := s.ensureCtxTimeout(ctx)
+	defer cancel()
+
+	return fetchContactedStationByCallsign(ctx, h, op, callsign)
+}
+
+func (s *Service) InsertContactedStationWithContext(ctx context.Context, station types.ContactedStation) (int64, error) {
+	const op errors.Op = "sqlite.Service.InsertContactedStationWithContext"
+	if err := checkService(op, s); err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err := s.WithTx(ctx, func(tx *Tx) error {
+		var err error
+		id, err = tx.InsertContactedStation(station)
+		return err
+	})
+
+	return id, err
+}
+
+func (s *Service) UpdateContactedStationWithContext(ctx context.Context, station types.ContactedStation) error {
+	const op errors.Op = "sqlite.Service.UpdateContactedStationWithContext"
+	if err := checkService(op, s); err != nil {
+		return err
+	}
+
+	return s.WithTx(ctx, func(tx *Tx) error {
+		return tx.UpdateContactedStation(station)
+	})
+}
+
+// ListContactedStationsWithContext returns a page of the contacted stations matching filter, ordered by call, each
+// with the number of active QSOs worked with it and when it was last worked.
+func (s *Service) ListContactedStationsWithContext(ctx context.Context, filter ContactedStationFilter, pageNum, pageSize int64) (ContactedStationList, error) {
+	const op errors.Op = "sqlite.Service.ListContactedStationsWithContext"
+	if err := checkService(op, s); err != nil {
+		return ContactedStationList{}, err
+	}
+
+	if pageNum < 1 {
+		return ContactedStationList{}, errors.New(op).Msg("Invalid page number. Must be greater than 0.")
+	}
+	if pageSize < 1 {
+		return ContactedStationList{}, errors.New(op).Msg("Invalid page size. Must be greater than 0.")
+	}
+
+	where, err := filter.whereMods()
+	if err != nil {
+		return ContactedStationList{}, errors.New(op).Err(err)
+	}
+
+	h, err := s.executor(ctx, op)
+	if err != nil {
+		return ContactedStationList{}, err
+	}
+
+	ctx, cancel := s.ensureCtxTimeout(ctx)
+	defer cancel()
+
+	total, err := models.ContactedStations(where...).Count(ctx, h)
+	if err != nil {
+		return ContactedStationList{}, errors.New(op).Err(err).Msg("Failed to count contacted stations matching filter.")
+	}
+
+	result := ContactedStationList{Stations: make([]ContactedStationSummary, 0), Total: total}
+	offset := (pageNum - 1) * pageSize
+	if total == 0 || offset >= total {
+		return result, nil
+	}
+
+	mods := append(where,
+		qm.Select(`"contacted_station".*`,
+			`count("qso"."id") AS qso_count`,
+			`coalesce(max("qso"."qso_date" || "qso"."time_on"), '') AS last_worked`),
+		qm.LeftOuterJoin(`qso ON "qso"."call" = "contacted_station"."call" AND "qso"."deleted_at" IS NULL`),
+		qm.GroupBy(`"contacted_station"."id"`),
+		qm.OrderBy(`"contacted_station"."call", "contacted_station"."id"`),
+		qm.Limit(int(pageSize)),
+		qm.Offset(int(offset)),
+	)
+
+	var rows []contactedStationRow
+	if err = models.ContactedStations(mods...).Bind(ctx, h, &rows); err != nil {
+		return ContactedStationList{}, errors.New(op).Err(err).Msg("Failed to list contacted stations.")
+	}
+
+	result.Stations = make([]ContactedStationSummary, 0, len(rows))
+	for i := range rows {
+		station, er := adapters.ContactedStationModelToType(&rows[i].ContactedStation)
+		if er != nil {
+			s.LoggerService.WarnWith().Int64("contacted_station.id", rows[i].ID).Err(er).Msg("Failed to adapt contacted station for listing.")
+			continue
+		}
+		result.Stations = append(result.Stations, ContactedStationSummary{
+			ContactedStation: station,
+			QsoCount:         rows[i].QsoCount,
+			LastWorked:       lastWorked(rows[i].LastWorked),
+		})
+	}
+
+	return result, nil
+}
+
+func (s *Service) DeleteContactedStationWithContext(ctx context.Context, id int64) error {
+	const op errors.Op = "sqlite.Service.DeleteContactedStationWithContext"
+	if err := checkService(op, s); err != nil {
+		return err
+	}
+
+	return s.WithTx(ctx, func(tx *Tx) error {
+		return tx.DeleteContactedStation(id)
+	})
+}
+
+func (s *Service) RestoreContactedStationWithContext(ctx context.Context, id int64) error {
+	const op errors.Op = "sqlite.Service.RestoreContactedStationWithContext"
+	if err := checkService(op, s); err != nil {
+		return err
+	}
+
+	return s.WithTx(ctx, func(tx *Tx) error {
+		return tx.RestoreContactedStation(id)
+	})
+}
+
+/**********************************************************************************************************************
+ * Country Methods
+ **********************************************************************************************************************/
+
+// FetchCountryByCallsignWithContext returns the country of the callsign: the row for the exact callsign if there is
+// one, otherwise the row with the longest prefix of it.
+func (s *Service) FetchCountryByCallsignWithContext(ctx context.Context, callsign string) (types.Country, error) {
+	const op errors.Op = "sqlite.Service.FetchCountryByCallsignWithContext"
+	if err := checkService(op, s); err != nil {
+		return types.Country{}, err
+	}
+
+	callsign = strings.ToUpper(strings.TrimSpace(callsign))
+	if callsign == "" {
+		return types.Country{}, errors.New(op).Msg(errMsgEmptyCallsign)
+	}
+
+	h, err := s.executor(ctx, op)
+	if err != nil {
+		return types.Country{}, err
+	}
+
+	ctx, cancel := s.ensureCtxTimeout(ctx)
+	defer cancel()
+
+	mods := []qm.QueryMod{
+		qm.Where("("+models.TableNames.Country+".exact AND "+models.TableNames.Country+".prefix = ?) OR "+
+			"(NOT "+models.TableNames.Country+".exact AND ? LIKE "+models.TableNames.Country+".prefix || '%')", callsign, callsign),
+		qm.OrderBy(models.TableNames.Country + ".exact DESC, LENGTH(" + models.TableNames.Country + ".prefix) DESC"),
+		qm.Limit(1),
+	}
+
+	model, err := models.Countries(mods...).One(ctx, h)
+	if err != nil {
+		if stderr.Is(err, sql.ErrNoRows) {
+			return types.Country{}, errors.ErrNotFound
+		}
+		return types.Country{}, errors.New(op).Err(err)
+	}
+
+	country, err := adapters.CountryModelToType(model)
+	if err != nil {
+		return types.Country{}, errors.New(op).Err(err)
+	}
+
+	return country, nil
+}
+
+func (s *Service) FetchCountryByNameWithContext(ctx context.Context, name string) (types.Country, error) {
+	const op errors.Op = "sqlite.Service.FetchCountryByNameWithContext"
+	if err := checkService(op, s); err != nil {
+		return types.Country{}, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return types.Country{}, errors.New(op).Msg("Country name cannot be empty")
+	}
+
+	h, err := s.executor(ctx, op)
+	if err != nil {
+		return types.Country{}, err
+	}
+
+	ctx, cancel := s.ensureCtxTimeout(ctx)
+	defer cancel()
+
+	// An imported country has a row per prefix; the one for its primary prefix comes first.
+	model, err := models.Countries(
+		models.CountryWhere.Name.EQ(name),
+		qm.OrderBy(models.CountryColumns.Exact+", "+models.CountryColumns.Prefix+" <> "+models.CountryColumns.DXCCPrefix+", "+models.CountryColumns.ID),
+	).One(ctx, h)
+	if err != nil {
+		if stderr.Is(err, sql.ErrNoRows) {
+			return types.Country{}, errors.ErrNotFound
 		}
-		result = append(result, typeLogbook)
+		return types.Country{}, errors.New(op).Err(err)
 	}
 
-	return result, nil
+	country, err := adapters.CountryModelToType(model)
+	if err != nil {
+		return types.Country{}, errors.New(op).Err(err)
+	}
+
+	return country, nil
 }
 
-func (s *Service) InsertLogbookWithContext(ctx context.Context, logbook types.Logbook) (int64, error) {
-	const op errors.Op = "sqlite.Service.InsertLogbookWithContext"
+func (s *Service) InsertCountryWithContext(ctx context.Context, country types.Country) (int64, error) {
+	const op errors.Op = "sqlite.Service.InsertCountryWithContext"
 	if err := checkService(op, s); err != nil {
 		return 0, err
 	}
 
-	h, err := s.getOpenHandle(op)
+	h, err := s.executor(ctx, op)
 	if err != nil {
 		return 0, err
 	}
@@ -716,48 +1280,123 @@ func (s *Service) InsertLogbookWithContext(ctx context.Context, logbook types.Lo
 	ctx, cancel := s.ensureCtxTimeout(ctx)
 	defer cancel()
 
-	model, err := adapters.LogbookTypeToModel(logbook)
+	model, err := adapters.CountryTypeToModel(country)
 	if err != nil {
 		return 0, errors.New(op).Err(err)
 	}
 	if err = model.Insert(ctx, h, boil.Infer()); err != nil {
-		return 0, errors.New(op).Err(err).Msg("Inserting new logbook failed.")
+		return 0, errors.New(op).Err(err).Msg("Inserting new country failed.")
 	}
 
 	return model.ID, nil
 }
 
-func (s *Service) DeleteLogbookByIDWithContext(ctx context.Context, id int64) error {
-	const op errors.Op = "sqlite.Service.DeleteLogbookWithContext"
+func (s *Service) UpdateCountryWithContext(ctx context.Context, country types.Country) error {
+	const op errors.Op = "sqlite.Service.UpdateCountryWithContext"
 	if err := checkService(op, s); err != nil {
 		return err
 	}
 
+	h, err := s.executor(ctx, op)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := s.ensureCtxTimeout(ctx)
+	defer cancel()
+
+	model, err := adapters.CountryTypeToModel(country)
+	if err != nil {
+		return errors.New(op).Err(err)
+	}
+
+	if _, err = model.Update(ctx, h, boil.Blacklist(models.CountryColumns.Exact)); err != nil {
+		return errors.New(op).Err(err).Msg("Updating country failed.")
+	}
+
+	return nil
+}
+
+/**********************************************************************************************************************
+ * Logbook Methods
+ **********************************************************************************************************************/
+
+func (s *Service) FetchLogbookByIDWithContext(ctx context.Context, id int64) (types.Logbook, error) {
+	const op errors.Op = "sqlite.Service.FetchLogbookByIDWithContext"
+	if err := checkService(op, s); err != nil {
+		return types.Logbook{}, err
+	}
+
 	if id < 1 {
-		return errors.New(op).Msg(errMsgInvalidId)
+		return types.Logbook{}, errors.New(op).Msg(errMsgInvalidId)
+	}
+	h, err := s.executor(ctx, op)
+	if err != nil {
+		return types.Logbook{}, err
+	}
+
+	ctx, cancel := s.ensureCtxTimeout(ctx)
+	defer cancel()
+
+	return fetchLogbookByID(ctx, h, op, id)
+}
+
+func (s *Service) FetchAllLogbooksWithContext(ctx context.Context) ([]types.Logbook, error) {
+	const op errors.Op = "sqlite.Service.FetchAllLogbooksWithContext"
+	if err := checkService(op, s); err != nil {
+		return nil, err
 	}
 
-	h, err := s.getOpenHandle(op)
+	h, err := s.executor(ctx, op)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	ctx, cancel := s.ensureCtxTimeout(ctx)
 	defer cancel()
 
-	logbook, err := models.FindLogbook(ctx, h, id)
+	list, err := models.Logbooks().All(ctx, h)
 	if err != nil {
-		if stderr.Is(err, sql.ErrNoRows) {
-			return errors.ErrNotFound
+		return nil, errors.New(op).Err(err)
+	}
+
+	result := make([]types.Logbook, 0, len(list))
+	for _, logbook := range list {
+		typeLogbook, er := adapters.LogbookModelToType(logbook)
+		if er != nil {
+			return nil, errors.New(op).Err(er).Msg("Converting logbook model to type failed.")
 		}
-		return errors.New(op).Err(err)
+		result = append(result, typeLogbook)
 	}
 
-	if _, err = logbook.Delete(ctx, h, false); err != nil {
-		return errors.New(op).Err(err).Msg("Failed to delete logbook.")
+	return result, nil
+}
+
+func (s *Service) InsertLogbookWithContext(ctx context.Context, logbook types.Logbook) (int64, error) {
+	const op errors.Op = "sqlite.Service.InsertLogbookWithContext"
+	if err := checkService(op, s); err != nil {
+		return 0, err
 	}
 
-	return nil
+	var id int64
+	err := s.WithTx(ctx, func(tx *Tx) error {
+		var err error
+		id, err = tx.InsertLogbook(logbook)
+		return err
+	})
+
+	return id, err
+}
+
+func (s *Service) DeleteLogbookByIDWithContext(ctx context.Context, id int64) error {
+	const op errors.Op = "sqlite.Service.DeleteLogbookWithContext"
+	if err := checkService(op, s); err != nil {
+		return err
+	}
+
+	return s.WithTx(ctx, func(tx *Tx) error {
+		return tx.DeleteLogbookByID(id)
+	})
 }
 
 func (s *Service) CheckDefaultLogbookExistsWithContext(ctx context.Context) (bool, error) {
@@ -766,7 +1405,7 @@ func (s *Service) CheckDefaultLogbookExistsWithContext(ctx context.Context) (boo
 		return false, err
 	}
 
-	h, err := s.getOpenHandle(op)
+	h, err := s.executor(ctx, op)
 	if err != nil {
 		return false, err
 	}
@@ -793,31 +1432,9 @@ func (s *Service) UpsertLogbookWithContext(ctx context.Context, logbook types.Lo
 		return err
 	}
 
-	if logbook.ID < 1 {
-		return errors.New(op).Msg("Logbook ID must be greater than 0")
-	}
-	//TODO: Other validation
-
-	h, err := s.getOpenHandle(op)
-	if err != nil {
-		return err
-	}
-
-	ctx, cancel := s.ensureCtxTimeout(ctx)
-	defer cancel()
-
-	model := models.Logbook{
-		ID:          logbook.ID,
-		Name:        logbook.Name,
-		Callsign:    logbook.Callsign,
-		Description: null.StringFrom(logbook.Description),
-	}
-
-	if err = model.Upsert(ctx, h, false, nil, boil.Infer(), boil.Infer()); err != nil {
-		return errors.New(op).Err(err).Msg("Upserting logbook failed.")
-	}
-
-	return nil
+	return s.WithTx(ctx, func(tx *Tx) error {
+		return tx.UpsertLogbook(logbook)
+	})
 }
 
 /**********************************************************************************************************************
@@ -833,7 +1450,7 @@ func (s *Service) SoftDeleteSessionByIDWithContext(ctx context.Context, id int64
 	if id < 1 {
 		return errors.New(op).Msg(errMsgInvalidId)
 	}
-	h, err := s.getOpenHandle(op)
+	h, err := s.executor(ctx, op)
 	if err != nil {
 		return err
 	}
@@ -862,20 +1479,14 @@ func (s *Service) GenerateSessionWithContext(ctx context.Context) (int64, error)
 		return 0, err
 	}
 
-	h, err := s.getOpenHandle(op)
-	if err != nil {
-		return 0, err
-	}
-
-	ctx, cancel := s.ensureCtxTimeout(ctx)
-	defer cancel()
-
-	session := models.Session{}
-	if err = session.Insert(ctx, h, boil.Infer()); err != nil {
-		return 0, errors.New(op).Err(err).Msg("Inserting new session failed.")
-	}
+	var id int64
+	err := s.WithTx(ctx, func(tx *Tx) error {
+		var err error
+		id, err = tx.GenerateSession()
+		return err
+	})
 
-	return session.ID, nil
+	return id, err
 }
 
 /**********************************************************************************************************************
@@ -884,6 +1495,25 @@ func (s *Service) GenerateSessionWithContext(ctx context.Context) (int64, error)
 
 func (s *Service) IsContestDuplicateByLogbookIDWithContext(ctx context.Context, id int64, callsign, band string) (bool, error) {
 	const op errors.Op = "sqlite.Service.IsContestDuplicatByLogbookIDWithContext"
+	return s.isContestDuplicate(ctx, op, id, callsign, band)
+}
+
+// IsContestDuplicateInContestWithContext reports whether the logbook already has an active QSO with the callsign on
+// the band in the given contest, using the indexed contest_id column.
+func (s *Service) IsContestDuplicateInContestWithContext(ctx context.Context, id int64, contestID, callsign, band string) (bool, error) {
+	const op errors.Op = "sqlite.Service.IsContestDuplicateInContestWithContext"
+
+	contestID = strings.TrimSpace(contestID)
+	if contestID == "" {
+		return false, errors.New(op).Msg("Contest ID cannot be empty")
+	}
+
+	return s.isContestDuplicate(ctx, op, id, callsign, band, models.QsoWhere.ContestID.EQ(null.StringFrom(contestID)))
+}
+
+// isContestDuplicate reports whether the logbook has an active QSO with the callsign on the band that also matches
+// mods.
+func (s *Service) isContestDuplicate(ctx context.Context, op errors.Op, id int64, callsign, band string, mods ...qm.QueryMod) (bool, error) {
 	if err := checkService(op, s); err != nil {
 		return false, err
 	}
@@ -901,7 +1531,7 @@ func (s *Service) IsContestDuplicateByLogbookIDWithContext(ctx context.Context,
 		return false, errors.New(op).Msg("Band cannot be empty")
 	}
 
-	h, err := s.getOpenHandle(op)
+	h, err := s.executor(ctx, op)
 	if err != nil {
 		return false, err
 	}
@@ -909,7 +1539,6 @@ func (s *Service) IsContestDuplicateByLogbookIDWithContext(ctx context.Context,
 	ctx, cancel := s.ensureCtxTimeout(ctx)
 	defer cancel()
 
-	var mods []qm.QueryMod
 	mods = append(mods, models.QsoWhere.Call.EQ(callsign))
 	mods = append(mods, models.QsoWhere.Band.EQ(band))
 	mods = append(mods, models.QsoWhere.LogbookID.EQ(id))
@@ -926,85 +1555,112 @@ func (s *Service) IsContestDuplicateByLogbookIDWithContext(ctx context.Context,
  * Upload Methods
  **********************************************************************************************************************/
 
+// FetchPendingUploadsWithContext reserves the next batch of uploads (QsoForwardingRowLimit rows) for this process and
+// returns them. Services take turns: the batch is shared between them and each one's uploads are taken oldest first.
 func (s *Service) FetchPendingUploadsWithContext(ctx context.Context) ([]types.QsoUpload, error) {
 	const op errors.Op = "sqlite.Service.FetchPendingUploadsWithContext"
 	if err := checkService(op, s); err != nil {
 		return nil, err
 	}
 
-	h, err := s.getOpenHandle(op)
-	if err != nil {
+	batchLimit := defaultUploadBatchLimit
+	if s.requiredCfgs.QsoForwardingRowLimit > 0 {
+		batchLimit = s.requiredCfgs.QsoForwardingRowLimit
+	}
+
+	first := s.uploadRound.Add(1) - 1
+
+	return s.fetchPendingUploads(ctx, op, func(ctx context.Context, exec boil.ContextExecutor, now time.Time) ([]int64, error) {
+		return s.reserveUploadsRoundRobin(ctx, exec, batchLimit, first, now)
+	})
+}
+
+// FetchPendingUploadsForServiceWithContext reserves up to limit of the service's uploads for this process, oldest
+// first, and returns them. A limit below 1 means QsoForwardingRowLimit.
+func (s *Service) FetchPendingUploadsForServiceWithContext(ctx context.Context, service upload.OnlineService, limit int) ([]types.QsoUpload, error) {
+	const op errors.Op = "sqlite.Service.FetchPendingUploadsForServiceWithContext"
+	if err := checkService(op, s); err != nil {
 		return nil, err
 	}
 
+	if service == emptyString {
+		return nil, errors.New(op).Msg("Upload service cannot be empty.")
+	}
+	if limit < 1 {
+		limit = defaultUploadBatchLimit
+		if s.requiredCfgs.QsoForwardingRowLimit > 0 {
+			limit = s.requiredCfgs.QsoForwardingRowLimit
+		}
+	}
+
+	return s.fetchPendingUploads(ctx, op, func(ctx context.Context, exec boil.ContextExecutor, now time.Time) ([]int64, error) {
+		return s.reserveUploads(ctx, exec, service.String(), limit, now)
+	})
+}
+
+// fetchPendingUploads runs reserve in a transaction and loads the reserved uploads with their QSOs. An upload whose
+// QSO cannot be read is not returned: it is marked dead with the reason, as retrying would not help.
+func (s *Service) fetchPendingUploads(ctx context.Context, op errors.Op, reserve func(ctx context.Context, exec boil.ContextExecutor, now time.Time) ([]int64, error)) ([]types.QsoUpload, error) {
 	ctx, cancel := s.ensureCtxTimeout(ctx)
 	defer cancel()
 
-	batchLimit := defaultUploadBatchLimit
-	if s.requiredCfgs.QsoForwardingRowLimit > 0 {
-		batchLimit = s.requiredCfgs.QsoForwardingRowLimit
-	}
+	var out []types.QsoUpload
+	err := s.WithTx(ctx, func(tx *Tx) error {
+		ids, er := reserve(tx.ctx, tx.tx, time.Now())
+		if er != nil || len(ids) == 0 {
+			return er
+		}
 
-	now := time.Now()
-	cutoff := now.Add(-uploadRetryCooldown).Unix()
-
-	// Reserve a batch and capture their IDs
-	updateAndReturn := `
-		UPDATE qso_upload
-		   SET status = ?, modified_at = ?, last_attempt_at = ?
-		 WHERE id IN (
-		     SELECT id
-		       FROM qso_upload
-		      WHERE status IN (?, ?)
-		        AND (last_attempt_at IS NULL OR last_attempt_at < ?)
-		      LIMIT ?
-		   )
-		RETURNING id`
-
-	type returnID struct {
-		ID int64 `boil:"id"`
-	}
-
-	var rows []returnID
-
-	err = queries.Raw(
-		updateAndReturn,
-		status.InProgress.String(),
-		null.TimeFrom(now),
-		null.Int64From(now.Unix()),
-		status.Pending.String(),
-		status.Failed.String(),
-		cutoff,
-		batchLimit,
-	).Bind(ctx, h, &rows)
-	if err != nil && !stderr.Is(err, sql.ErrNoRows) {
+		idArgs := make([]interface{}, len(ids))
+		for i, v := range ids {
+			idArgs[i] = v
+		}
+
+		// Fetch the reserved rows with QSO eagerly loaded.
+		uploads, unreadable, er := s.loadQsoUploads(tx.ctx, tx.tx,
+			qm.WhereIn("qso_upload.id IN ?", idArgs...),
+			qm.OrderBy(models.QsoUploadColumns.ID),
+		)
+		if er != nil {
+			return er
+		}
+
+		out = make([]types.QsoUpload, 0, len(uploads))
+		for _, up := range uploads {
+			if reason, ok := unreadable[up.ID]; ok {
+				if er = tx.killUpload(up, reason); er != nil {
+					return er
+				}
+				continue
+			}
+			tx.publish(ChangeEvent{Entity: ChangeUpload, Action: action.Update, ID: up.ID, QsoID: up.QsoID,
+				Service: up.Service, Status: status.InProgress})
+			out = append(out, up)
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, errors.New(op).Err(err).Msg("Failed to reserve pending uploads")
 	}
-
-	if len(rows) == 0 {
+	if len(out) == 0 {
 		return nil, nil
 	}
 
-	ids := make([]int64, 0, len(rows))
-	for _, r := range rows {
-		ids = append(ids, r.ID)
-	}
-	idArgs := make([]interface{}, len(ids))
-	for i, v := range ids {
-		idArgs[i] = v
-	}
+	return interleaveUploads(out), nil
+}
 
-	// Fetch the reserved rows with QSO eagerly loaded.
-	uploads, err := models.QsoUploads(
-		qm.WhereIn("qso_upload.id IN ?", idArgs...),
-		qm.Load(models.QsoUploadRels.Qso),
-	).All(ctx, h)
+// loadQsoUploads fetches the qso_upload rows selected by mods, each with its QSO. Uploads whose QSO cannot be adapted
+// are returned without it, and the reason is in unreadable under the upload's ID.
+func (s *Service) loadQsoUploads(ctx context.Context, exec boil.ContextExecutor, mods ...qm.QueryMod) (out []types.QsoUpload, unreadable map[int64]error, err error) {
+	// Deleted QSOs must still be loaded so that queued delete actions carry the QSO being removed.
+	mods = append(mods, qm.Load(models.QsoUploadRels.Qso, qm.WithDeleted()))
+	uploads, err := models.QsoUploads(mods...).All(ctx, exec)
 	if err != nil {
-		return nil, errors.New(op).Err(err).Msg("Failed to load reserved uploads")
+		return nil, nil, err
 	}
 
 	// Adapt to types.QsoUpload.
-	out := make([]types.QsoUpload, 0, len(uploads))
+	out = make([]types.QsoUpload, 0, len(uploads))
 	for _, ref := range uploads {
 		up := types.QsoUpload{
 			ID:            ref.ID,
@@ -1019,15 +1675,19 @@ func (s *Service) FetchPendingUploadsWithContext(ctx context.Context) ([]types.Q
 		if ref.R != nil && ref.R.Qso != nil {
 			qso, er := adapters.QsoModelToType(ref.R.Qso)
 			if er != nil {
-				s.LoggerService.ErrorWith().Err(er).Msg("Failed to adapt QSO for QsoUpload.")
-				continue
+				s.LoggerService.ErrorWith().Int64("qso_upload.id", ref.ID).Err(er).Msg("Failed to adapt QSO for QsoUpload.")
+				if unreadable == nil {
+					unreadable = map[int64]error{}
+				}
+				unreadable[ref.ID] = er
+			} else {
+				up.Qso = qso
 			}
-			up.Qso = qso
 		}
 		out = append(out, up)
 	}
 
-	return out, nil
+	return out, unreadable, nil
 }
 
 func (s *Service) UpdateQsoUploadStatusWithContext(ctx context.Context, id int64, status status.Status, action action.Action, attempts int64, lastError string) error {
@@ -1036,53 +1696,218 @@ func (s *Service) UpdateQsoUploadStatusWithContext(ctx context.Context, id int64
 		return err
 	}
 
-	if id < 1 {
-		return errors.New(op).Msg(errMsgInvalidId)
+	return s.WithTx(ctx, func(tx *Tx) error {
+		return tx.UpdateQsoUploadStatus(id, status, action, attempts, lastError)
+	})
+}
+
+// RecordUploadAttemptWithContext reports the outcome of an upload attempt with the service's response; see
+// Tx.RecordUploadAttempt.
+func (s *Service) RecordUploadAttemptWithContext(ctx context.Context, id int64, attempts int64, attempt UploadAttempt) error {
+	const op errors.Op = "sqlite.Service.RecordUploadAttemptWithContext"
+	if err := checkService(op, s); err != nil {
+		return err
+	}
+
+	return s.WithTx(ctx, func(tx *Tx) error {
+		return tx.RecordUploadAttempt(id, attempts, attempt)
+	})
+}
+
+// ReleaseUploadLeaseWithContext gives up this service's reservations of the given uploads, making them pending again
+// without counting an attempt, and returns how many were released. A nil or empty ids releases every reservation
+// this service holds, e.g. on shutdown. Reservations held by other owners are left alone.
+func (s *Service) ReleaseUploadLeaseWithContext(ctx context.Context, ids []int64) (int64, error) {
+	const op errors.Op = "sqlite.Service.ReleaseUploadLeaseWithContext"
+	if err := checkService(op, s); err != nil {
+		return 0, err
+	}
+
+	var n int64
+	err := s.WithTx(ctx, func(tx *Tx) error {
+		var er error
+		n, er = tx.ReleaseUploadLease(ids)
+		return er
+	})
+
+	return n, err
+}
+
+// FetchDeadUploadsWithContext returns the uploads that ran out of attempts, oldest first. An empty service returns
+// those of every service.
+func (s *Service) FetchDeadUploadsWithContext(ctx context.Context, service upload.OnlineService) ([]types.QsoUpload, error) {
+	const op errors.Op = "sqlite.Service.FetchDeadUploadsWithContext"
+	if err := checkService(op, s); err != nil {
+		return nil, err
 	}
 
-	h, err := s.getOpenHandle(op)
+	h, err := s.executor(ctx, op)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	ctx, cancel := s.ensureCtxTimeout(ctx)
 	defer cancel()
 
-	tx, err := h.BeginTx(ctx, nil)
+	mods := []qm.QueryMod{
+		models.QsoUploadWhere.Status.EQ(UploadStatusDead.String()),
+		qm.OrderBy(models.QsoUploadColumns.ID),
+	}
+	if service != emptyString {
+		mods = append(mods, models.QsoUploadWhere.Service.EQ(service.String()))
+	}
+
+	// Uploads whose QSO cannot be read are listed too, without it, so they can still be requeued once it is fixed.
+	out, _, err := s.loadQsoUploads(ctx, h, mods...)
+	if err != nil {
+		return nil, errors.New(op).Err(err).Msg("Failed to fetch dead uploads")
+	}
+
+	return out, nil
+}
+
+func (s *Service) RequeueDeadUploadsWithContext(ctx context.Context, ids []int64) (int64, error) {
+	const op errors.Op = "sqlite.Service.RequeueDeadUploadsWithContext"
+	if err := checkService(op, s); err != nil {
+		return 0, err
+	}
+
+	var n int64
+	err := s.WithTx(ctx, func(tx *Tx) error {
+		var er error
+		n, er = tx.RequeueDeadUploads(ids)
+		return er
+	})
+
+	return n, err
+}
+
+// RetryUploadsNowWithContext makes the given pending and failed uploads due immediately and returns how many were changed.
+func (s *Service) RetryUploadsNowWithContext(ctx context.Context, ids []int64) (int64, error) {
+	const op errors.Op = "sqlite.Service.RetryUploadsNowWithContext"
+	if err := checkService(op, s); err != nil {
+		return 0, err
+	}
+
+	var n int64
+	err := s.WithTx(ctx, func(tx *Tx) error {
+		var er error
+		n, er = tx.RetryUploadsNow(ids)
+		return er
+	})
+
+	return n, err
+}
+
+// CancelUploadsWithContext removes the given uploads from the queue unless they are in progress or uploaded, and
+// returns how many were removed.
+func (s *Service) CancelUploadsWithContext(ctx context.Context, ids []int64) (int64, error) {
+	const op errors.Op = "sqlite.Service.CancelUploadsWithContext"
+	if err := checkService(op, s); err != nil {
+		return 0, err
+	}
+
+	var n int64
+	err := s.WithTx(ctx, func(tx *Tx) error {
+		var er error
+		n, er = tx.CancelUploads(ids)
+		return er
+	})
+
+	return n, err
+}
+
+// RequeueFailedUploadsWithContext makes all the service's failed and dead uploads pending again with their attempts
+// reset, and returns how many were requeued.
+func (s *Service) RequeueFailedUploadsWithContext(ctx context.Context, service upload.OnlineService) (int64, error) {
+	const op errors.Op = "sqlite.Service.RequeueFailedUploadsWithContext"
+	if err := checkService(op, s); err != nil {
+		return 0, err
+	}
+
+	var n int64
+	err := s.WithTx(ctx, func(tx *Tx) error {
+		var er error
+		n, er = tx.RequeueFailedUploads(service)
+		return er
+	})
+
+	return n, err
+}
+
+// PurgeUploadedOlderThanWithContext removes update and delete uploads uploaded more than 'age' ago. Uploaded
+// inserts are kept.
+func (s *Service) PurgeUploadedOlderThanWithContext(ctx context.Context, age time.Duration) (int64, error) {
+	const op errors.Op = "sqlite.Service.PurgeUploadedOlderThanWithContext"
+	if err := checkService(op, s); err != nil {
+		return 0, err
+	}
+
+	var n int64
+	err := s.WithTx(ctx, func(tx *Tx) error {
+		var er error
+		n, er = tx.PurgeUploadedOlderThan(age)
+		return er
+	})
+
+	return n, err
+}
+
+// FetchUploadAttemptsByQsoIdWithContext returns every recorded attempt at uploading the QSO, to any service, newest
+// first.
+func (s *Service) FetchUploadAttemptsByQsoIdWithContext(ctx context.Context, qsoID int64) ([]UploadAttempt, error) {
+	const op errors.Op = "sqlite.Service.FetchUploadAttemptsByQsoIdWithContext"
+	if err := checkService(op, s); err != nil {
+		return nil, err
+	}
+
+	if qsoID < 1 {
+		return nil, errors.New(op).Msg(errMsgInvalidId)
+	}
+
+	h, err := s.executor(ctx, op)
 	if err != nil {
-		return errors.New(op).Err(err).Msg("Failed to begin transaction")
+		return nil, err
 	}
 
-	uploadModel, err := models.FindQsoUpload(ctx, tx, id)
+	ctx, cancel := s.ensureCtxTimeout(ctx)
+	defer cancel()
+
+	out, err := fetchUploadAttempts(ctx, h, models.QsoUploadAttemptWhere.QsoID.EQ(qsoID))
 	if err != nil {
-		_ = tx.Rollback()
-		return errors.New(op).Err(err).Msg("Failed to find QSO upload")
+		return nil, errors.New(op).Err(err).Msg("Failed to fetch upload attempts.")
 	}
 
-	uploadModel.Status = status.String()
-	uploadModel.Action = action.String()
-	uploadModel.Attempts = attempts
-	uploadModel.LastError = null.NewString(lastError, lastError != "")
-	uploadModel.ModifiedAt = null.TimeFrom(time.Now())
+	return out, nil
+}
+
+// FetchUploadAttemptsByServiceWithContext returns the service's most recent upload attempts, newest first. A limit
+// below 1 returns the last 100.
+func (s *Service) FetchUploadAttemptsByServiceWithContext(ctx context.Context, service upload.OnlineService, limit int) ([]UploadAttempt, error) {
+	const op errors.Op = "sqlite.Service.FetchUploadAttemptsByServiceWithContext"
+	if err := checkService(op, s); err != nil {
+		return nil, err
+	}
 
-	// Clear last_attempt_at on failure so it can be retried on next poll
-	if uploadModel.Status == "failed" {
-		uploadModel.LastAttemptAt = null.Int64{}
+	if service == emptyString {
+		return nil, errors.New(op).Msg("Upload service cannot be empty.")
+	}
+	if limit < 1 {
+		limit = defaultUploadAttemptLimit
 	}
 
-	_, err = uploadModel.Update(ctx, tx, boil.Infer())
+	h, err := s.executor(ctx, op)
 	if err != nil {
-		_ = tx.Rollback()
-		return errors.New(op).Err(err).Msg("Failed to update QSO upload status")
+		return nil, err
 	}
 
-	// At this point, we don't need to update the QSO itself as that SHOULD have been
-	// done by the online-forwarder, since the online-forwarder knows what fields in the
-	// qso object to update based on the service.
+	ctx, cancel := s.ensureCtxTimeout(ctx)
+	defer cancel()
 
-	if err = tx.Commit(); err != nil {
-		return errors.New(op).Err(err).Msg("Failed to commit transaction")
+	out, err := fetchUploadAttempts(ctx, h, models.QsoUploadAttemptWhere.Service.EQ(service.String()), qm.Limit(limit))
+	if err != nil {
+		return nil, errors.New(op).Err(err).Msg("Failed to fetch upload attempts.")
 	}
 
-	return nil
+	return out, nil
 }
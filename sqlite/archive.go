@@ -0,0 +1,676 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	stderr "errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Station-Manager/enums/upload/action"
+	"github.com/Station-Manager/enums/upload/status"
+	"github.com/Station-Manager/errors"
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+// maxLogbookNameLen matches the CHECK constraint on logbook.name.
+const maxLogbookNameLen = 64
+
+// ArchiveImportOptions controls how ImportLogbookArchive merges an archive into the database.
+type ArchiveImportOptions struct {
+	// StripAPIKey drops the logbook's API key, so the imported copy is not linked to the server.
+	StripAPIKey bool `json:"strip_api_key"`
+}
+
+// ArchiveReport counts the rows written by ExportLogbookArchive or ImportLogbookArchive.
+type ArchiveReport struct {
+	LogbookID   int64  `json:"logbook_id"` // ID of the logbook in the database written to
+	LogbookName string `json:"logbook_name"`
+
+	ForwardingRules   int64 `json:"forwarding_rules"`
+	Sessions          int64 `json:"sessions"`
+	Qsos              int64 `json:"qsos"`
+	Uploads           int64 `json:"uploads"`
+	UploadAttempts    int64 `json:"upload_attempts"`
+	Revisions         int64 `json:"revisions"`
+	Qsls              int64 `json:"qsls"`
+	ContactedStations int64 `json:"contacted_stations"`
+
+	// ContactedStationsSkipped counts archived stations left out on import because the database already has an
+	// active station with the same call.
+	ContactedStationsSkipped int64 `json:"contacted_stations_skipped"`
+}
+
+// ExportLogbookArchive writes a logbook to a new SQLite file at path: the logbook and its forwarding rules, all its
+// QSOs (soft-deleted ones included) with their sessions, upload queue entries and attempts, revisions and QSL state,
+// and the contacted stations it has worked. The file has the full schema, so it can be opened as a database on its
+// own. path must not exist; if the export fails the partly written file is removed. As with an import,
+// TransactionContextTimeout applies if ctx has no deadline.
+func (s *Service) ExportLogbookArchive(ctx context.Context, logbookID int64, path string) (ArchiveReport, error) {
+	const op errors.Op = "sqlite.Service.ExportLogbookArchive"
+	if err := checkService(op, s); err != nil {
+		return ArchiveReport{}, err
+	}
+
+	if path == emptyString {
+		return ArchiveReport{}, errors.New(op).Msg("Archive path cannot be empty.")
+	}
+	if _, err := os.Stat(path); err == nil {
+		return ArchiveReport{}, errors.New(op).Msgf("Archive file already exists: %s", path)
+	} else if !stderr.Is(err, os.ErrNotExist) {
+		return ArchiveReport{}, errors.New(op).Err(err).Msg("Failed to check the archive path.")
+	}
+
+	h, err := s.getOpenHandle(op)
+	if err != nil {
+		return ArchiveReport{}, err
+	}
+
+	// The export is one read transaction, so it gets the transaction timeout rather than the shorter query one.
+	ctx, cancel := s.ensureTxTimeout(ctx)
+	defer cancel()
+
+	logbook, err := fetchLogbookByID(ctx, h, op, logbookID)
+	if err != nil {
+		return ArchiveReport{}, err
+	}
+
+	archive, err := openArchive(path, false)
+	if err != nil {
+		return ArchiveReport{}, errors.New(op).Err(err).Msg("Failed to create the archive file.")
+	}
+	if err = runMigrations(archive); err != nil {
+		removeArchive(archive, path)
+		return ArchiveReport{}, errors.New(op).Err(err).Msg("Failed to create the archive schema.")
+	}
+
+	report := ArchiveReport{LogbookID: logbook.ID, LogbookName: logbook.Name}
+
+	// The read transaction gives a consistent snapshot of the logbook; nothing is written to the database.
+	err = s.WithTx(ctx, func(tx *Tx) error {
+		dst, er := archive.BeginTx(tx.ctx, nil)
+		if er != nil {
+			return er
+		}
+		if er = exportArchiveRows(tx.ctx, tx.tx, dst, logbookID, &report); er != nil {
+			_ = dst.Rollback()
+			return er
+		}
+		return dst.Commit()
+	})
+	if err != nil {
+		removeArchive(archive, path)
+		return ArchiveReport{}, errors.New(op).Err(err).Msg("Failed to export the logbook archive.")
+	}
+
+	if err = archive.Close(); err != nil {
+		_ = os.Remove(path)
+		return ArchiveReport{}, errors.New(op).Err(err).Msg("Failed to close the archive file.")
+	}
+
+	return report, nil
+}
+
+// ImportLogbookArchive merges a file written by ExportLogbookArchive into the database as a new logbook. Every row
+// gets a new ID. If the logbook's name is taken, " (2)", " (3)", ... is appended to it. Upload queue entries keep
+// their state, except that uploads the exporting machine had in progress are pending again. Contacted stations are
+// only added for calls the database does not already have.
+//
+// The whole import runs in one transaction: either everything is imported or nothing is.
+func (s *Service) ImportLogbookArchive(ctx context.Context, path string, opts ArchiveImportOptions) (ArchiveReport, error) {
+	const op errors.Op = "sqlite.Service.ImportLogbookArchive"
+	if err := checkService(op, s); err != nil {
+		return ArchiveReport{}, err
+	}
+
+	if path == emptyString {
+		return ArchiveReport{}, errors.New(op).Msg("Archive path cannot be empty.")
+	}
+	if _, err := os.Stat(path); err != nil {
+		return ArchiveReport{}, errors.New(op).Err(err).Msgf("Cannot read the archive file: %s", path)
+	}
+
+	archive, err := openArchive(path, true)
+	if err != nil {
+		return ArchiveReport{}, errors.New(op).Err(err).Msg("Failed to open the archive file.")
+	}
+	defer func() { _ = archive.Close() }()
+
+	var report ArchiveReport
+	err = s.WithTx(ctx, func(tx *Tx) error {
+		var er error
+		report, er = tx.importLogbookArchive(archive, opts)
+		return er
+	})
+	if err != nil {
+		return ArchiveReport{}, errors.New(op).Err(err).Msg("Failed to import the logbook archive.")
+	}
+
+	return report, nil
+}
+
+// openArchive opens an archive file on a single connection with foreign keys enforced.
+func openArchive(path string, readOnly bool) (*sql.DB, error) {
+	dsn := "file:" + path
+	if readOnly {
+		dsn += "?mode=ro"
+	}
+
+	db, err := sql.Open(SqliteDriver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	if _, err = db.Exec("PRAGMA foreign_keys = ON"); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// removeArchive closes and deletes a partly written archive.
+func removeArchive(archive *sql.DB, path string) {
+	_ = archive.Close()
+	for _, p := range []string{path, path + "-journal", path + "-wal", path + "-shm"} {
+		_ = os.Remove(p)
+	}
+}
+
+// exportArchiveRows copies the logbook's rows from src to dst, keeping their IDs. Tables are copied parents first so
+// the archive's foreign keys hold.
+func exportArchiveRows(ctx context.Context, src, dst archiveExecutor, logbookID int64, report *ArchiveReport) error {
+	const logbookQsos = `SELECT id FROM qso WHERE logbook_id = ?`
+
+	steps := []struct {
+		table string
+		where string
+		args  []any
+		count *int64
+	}{
+		{"logbook", `id = ?`, []any{logbookID}, nil},
+		{"forwarding_rule", `logbook_id = ?`, []any{logbookID}, &report.ForwardingRules},
+		{"session", `id IN (SELECT session_id FROM qso WHERE logbook_id = ?
+		                    UNION SELECT session_id FROM qso_revision WHERE qso_id IN (` + logbookQsos + `))`,
+			[]any{logbookID, logbookID}, &report.Sessions},
+		{"qso", `logbook_id = ?`, []any{logbookID}, &report.Qsos},
+		{"qso_upload", `qso_id IN (` + logbookQsos + `)`, []any{logbookID}, &report.Uploads},
+		{"qso_upload_attempt", `qso_id IN (` + logbookQsos + `)`, []any{logbookID}, &report.UploadAttempts},
+		{"qso_revision", `qso_id IN (` + logbookQsos + `)`, []any{logbookID}, &report.Revisions},
+		{"qsl", `qso_id IN (` + logbookQsos + `)`, []any{logbookID}, &report.Qsls},
+		{"contacted_station", `deleted_at IS NULL AND call IN (SELECT call FROM qso WHERE logbook_id = ? AND deleted_at IS NULL)`,
+			[]any{logbookID}, &report.ContactedStations},
+	}
+
+	for _, step := range steps {
+		t, err := newArchiveTable(ctx, src, dst, step.table, true)
+		if err != nil {
+			return err
+		}
+		err = t.each(ctx, src, step.where, step.args, func(row archiveRow) error {
+			if _, er := t.insert(ctx, row); er != nil {
+				return er
+			}
+			if step.count != nil {
+				*step.count++
+			}
+			return nil
+		})
+		t.close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importLogbookArchive copies the archive's logbook into the database, remapping every ID.
+func (t *Tx) importLogbookArchive(src *sql.DB, opts ArchiveImportOptions) (ArchiveReport, error) {
+	const op errors.Op = "sqlite.Tx.importLogbookArchive"
+
+	var report ArchiveReport
+	ctx := t.ctx
+
+	var logbooks int
+	if err := src.QueryRowContext(ctx, `SELECT count(*) FROM logbook`).Scan(&logbooks); err != nil {
+		return report, errors.New(op).Err(err).Msg("The file is not a logbook archive.")
+	}
+	if logbooks != 1 {
+		return report, errors.New(op).Msgf("A logbook archive holds one logbook; this file holds %d.", logbooks)
+	}
+
+	tables := map[string]*archiveTable{}
+	defer func() {
+		for _, table := range tables {
+			table.close()
+		}
+	}()
+	for _, name := range []string{"logbook", "forwarding_rule", "session", "qso", "qso_upload", "qso_upload_attempt", "qso_revision", "qsl", "contacted_station"} {
+		table, err := newArchiveTable(ctx, src, t.tx, name, false)
+		if err != nil {
+			return report, errors.New(op).Err(err).Msgf("Failed to read the archive's %s table.", name)
+		}
+		tables[name] = table
+	}
+
+	// Logbook
+	err := tables["logbook"].each(ctx, src, emptyString, nil, func(row archiveRow) error {
+		name, er := t.uniqueLogbookName(row.str("name"))
+		if er != nil {
+			return er
+		}
+		row.set("name", name)
+
+		if opts.StripAPIKey {
+			row.set("api_key", nil)
+		} else if key := row.str("api_key"); key != emptyString {
+			var taken string
+			er = t.tx.QueryRowContext(ctx, `SELECT name FROM logbook WHERE api_key = ?`, key).Scan(&taken)
+			if er == nil {
+				return errors.New(op).Msgf("The archive's API key is already used by logbook %q.", taken)
+			}
+			if !stderr.Is(er, sql.ErrNoRows) {
+				return er
+			}
+		}
+
+		report.LogbookName = name
+		report.LogbookID, er = tables["logbook"].insert(ctx, row)
+		return er
+	})
+	if err != nil {
+		return report, errors.New(op).Err(err).Msg("Failed to import the logbook.")
+	}
+
+	err = tables["forwarding_rule"].each(ctx, src, emptyString, nil, func(row archiveRow) error {
+		row.set("logbook_id", report.LogbookID)
+		_, er := tables["forwarding_rule"].insert(ctx, row)
+		report.ForwardingRules++
+		return er
+	})
+	if err != nil {
+		return report, errors.New(op).Err(err).Msg("Failed to import forwarding rules.")
+	}
+
+	// Sessions
+	sessionIDs := map[int64]int64{}
+	err = tables["session"].each(ctx, src, emptyString, nil, func(row archiveRow) error {
+		id, er := tables["session"].insert(ctx, row)
+		sessionIDs[row.id()] = id
+		report.Sessions++
+		return er
+	})
+	if err != nil {
+		return report, errors.New(op).Err(err).Msg("Failed to import sessions.")
+	}
+
+	// QSOs
+	qsoIDs := map[int64]int64{}
+	qsoSessions := map[int64]int64{} // New QSO ID to its new session ID
+	err = tables["qso"].each(ctx, src, emptyString, nil, func(row archiveRow) error {
+		sessionID, ok := sessionIDs[row.int64("session_id")]
+		if !ok {
+			return fmt.Errorf("QSO %d refers to session %d, which is not in the archive", row.id(), row.int64("session_id"))
+		}
+		row.set("logbook_id", report.LogbookID)
+		row.set("session_id", sessionID)
+
+		id, er := tables["qso"].insert(ctx, row)
+		qsoIDs[row.id()] = id
+		qsoSessions[id] = sessionID
+		report.Qsos++
+		return er
+	})
+	if err != nil {
+		return report, errors.New(op).Err(err).Msg("Failed to import QSOs.")
+	}
+
+	// Rows that belong to a QSO
+	remapQso := func(row archiveRow) (int64, error) {
+		id, ok := qsoIDs[row.int64("qso_id")]
+		if !ok {
+			return 0, fmt.Errorf("%s %d refers to QSO %d, which is not in the archive", row.table.name, row.id(), row.int64("qso_id"))
+		}
+		row.set("qso_id", id)
+		return id, nil
+	}
+
+	uploadIDs := map[int64]int64{}
+	err = tables["qso_upload"].each(ctx, src, emptyString, nil, func(row archiveRow) error {
+		if _, er := remapQso(row); er != nil {
+			return er
+		}
+		if row.str("status") == status.InProgress.String() {
+			row.set("status", status.Pending.String())
+		}
+		row.set("lease_owner", nil)
+		row.set("lease_expires_at", nil)
+		row.set("requeued", false)
+		id, er := tables["qso_upload"].insert(ctx, row)
+		uploadIDs[row.id()] = id
+		report.Uploads++
+		return er
+	})
+	if err != nil {
+		return report, errors.New(op).Err(err).Msg("Failed to import the upload queue.")
+	}
+
+	err = tables["qso_upload_attempt"].each(ctx, src, emptyString, nil, func(row archiveRow) error {
+		if _, er := remapQso(row); er != nil {
+			return er
+		}
+		if id, ok := uploadIDs[row.int64("qso_upload_id")]; ok {
+			row.set("qso_upload_id", id)
+		} else {
+			row.set("qso_upload_id", nil)
+		}
+		_, er := tables["qso_upload_attempt"].insert(ctx, row)
+		report.UploadAttempts++
+		return er
+	})
+	if err != nil {
+		return report, errors.New(op).Err(err).Msg("Failed to import upload attempts.")
+	}
+
+	err = tables["qso_revision"].each(ctx, src, emptyString, nil, func(row archiveRow) error {
+		qsoID, er := remapQso(row)
+		if er != nil {
+			return er
+		}
+		// A revision may predate a move to another session; fall back to the QSO's own session if that one was
+		// not archived.
+		sessionID, ok := sessionIDs[row.int64("session_id")]
+		if !ok {
+			sessionID = qsoSessions[qsoID]
+		}
+		row.set("logbook_id", report.LogbookID)
+		row.set("session_id", sessionID)
+		_, er = tables["qso_revision"].insert(ctx, row)
+		report.Revisions++
+		return er
+	})
+	if err != nil {
+		return report, errors.New(op).Err(err).Msg("Failed to import QSO revisions.")
+	}
+
+	err = tables["qsl"].each(ctx, src, emptyString, nil, func(row archiveRow) error {
+		if _, er := remapQso(row); er != nil {
+			return er
+		}
+		_, er := tables["qsl"].insert(ctx, row)
+		report.Qsls++
+		return er
+	})
+	if err != nil {
+		return report, errors.New(op).Err(err).Msg("Failed to import QSL state.")
+	}
+
+	// Contacted stations
+	err = tables["contacted_station"].each(ctx, src, `deleted_at IS NULL`, nil, func(row archiveRow) error {
+		var exists bool
+		er := t.tx.QueryRowContext(ctx,
+			`SELECT EXISTS (SELECT 1 FROM contacted_station WHERE call = ? AND deleted_at IS NULL)`,
+			row.str("call")).Scan(&exists)
+		if er != nil {
+			return er
+		}
+		if exists {
+			report.ContactedStationsSkipped++
+			return nil
+		}
+		_, er = tables["contacted_station"].insert(ctx, row)
+		report.ContactedStations++
+		return er
+	})
+	if err != nil {
+		return report, errors.New(op).Err(err).Msg("Failed to import contacted stations.")
+	}
+
+	t.publish(
+		ChangeEvent{Entity: ChangeLogbook, Action: action.Insert, ID: report.LogbookID, LogbookID: report.LogbookID},
+		ChangeEvent{Entity: ChangeQso, Action: action.Insert, LogbookID: report.LogbookID},
+	)
+	if report.ContactedStations > 0 {
+		t.publish(ChangeEvent{Entity: ChangeContactedStation, Action: action.Insert})
+	}
+
+	return report, nil
+}
+
+// uniqueLogbookName returns name, or name with " (n)" appended if a logbook (soft-deleted ones included) already
+// has it. The name is shortened as needed to stay within maxLogbookNameLen characters.
+func (t *Tx) uniqueLogbookName(name string) (string, error) {
+	taken := func(candidate string) (bool, error) {
+		var exists bool
+		err := t.tx.QueryRowContext(t.ctx, `SELECT EXISTS (SELECT 1 FROM logbook WHERE name = ?)`, candidate).Scan(&exists)
+		return exists, err
+	}
+
+	candidate := name
+	for n := 2; ; n++ {
+		exists, err := taken(candidate)
+		if err != nil || !exists {
+			return candidate, err
+		}
+
+		candidate = numberedLogbookName(name, n)
+	}
+}
+
+// numberedLogbookName appends " (n)" to name, shortening name so the result fits in maxLogbookNameLen characters.
+func numberedLogbookName(name string, n int) string {
+	suffix := fmt.Sprintf(" (%d)", n)
+	for utf8.RuneCountInString(name)+len(suffix) > maxLogbookNameLen {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return strings.TrimRight(name, " ") + suffix
+}
+
+/**********************************************************************************************************************
+ * Row copying
+ **********************************************************************************************************************/
+
+// archiveExecutor is a database or transaction that rows can be copied from or to.
+type archiveExecutor interface {
+	boil.ContextExecutor
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// archiveTable copies the rows of one table from one database to another. Only columns present in both databases
+// are copied, so an archive written by an older or newer schema version can still be read; generated columns are
+// never copied.
+type archiveTable struct {
+	name   string
+	cols   []string // Columns read from the source; the first is always id
+	dates  map[string]bool
+	keepID bool
+	stmt   *sql.Stmt // INSERT into the destination; nil if the source does not have the table
+}
+
+// archiveRow is one row read by archiveTable.each. Values are in the order of the table's columns.
+type archiveRow struct {
+	table *archiveTable
+	vals  []any
+}
+
+func newArchiveTable(ctx context.Context, src, dst archiveExecutor, name string, keepID bool) (*archiveTable, error) {
+	srcCols, srcDates, err := archiveColumns(ctx, src, name)
+	if err != nil {
+		return nil, err
+	}
+	dstCols, _, err := archiveColumns(ctx, dst, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(dstCols) == 0 {
+		return nil, fmt.Errorf("table %s does not exist", name)
+	}
+
+	t := &archiveTable{name: name, cols: []string{"id"}, dates: srcDates, keepID: keepID}
+	if len(srcCols) == 0 {
+		return t, nil
+	}
+
+	inDst := map[string]bool{}
+	for _, c := range dstCols {
+		inDst[c] = true
+	}
+	for _, c := range srcCols {
+		if c != "id" && inDst[c] {
+			t.cols = append(t.cols, c)
+		}
+	}
+
+	insertCols := t.cols
+	if !keepID {
+		insertCols = t.cols[1:]
+	}
+	query := fmt.Sprintf(`INSERT INTO %q (%s) VALUES (%s)`, name, quoteIdents(insertCols),
+		strings.TrimSuffix(strings.Repeat("?, ", len(insertCols)), ", "))
+	if t.stmt, err = dst.PrepareContext(ctx, query); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// archiveColumns returns the table's stored columns in declaration order, and which of them are declared as dates.
+// It returns no columns if the table does not exist.
+func archiveColumns(ctx context.Context, exec boil.ContextExecutor, table string) ([]string, map[string]bool, error) {
+	rows, err := exec.QueryContext(ctx, `SELECT name, type FROM pragma_table_xinfo(?) WHERE hidden = 0 ORDER BY cid`, table)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var cols []string
+	dates := map[string]bool{}
+	for rows.Next() {
+		var name, typ string
+		if err = rows.Scan(&name, &typ); err != nil {
+			return nil, nil, err
+		}
+		cols = append(cols, name)
+		switch strings.ToUpper(typ) {
+		case "DATE", "DATETIME", "TIMESTAMP":
+			dates[name] = true
+		}
+	}
+
+	return cols, dates, rows.Err()
+}
+
+// each calls fn with every row of the table in src matching where (all rows if it is empty), in ID order.
+func (t *archiveTable) each(ctx context.Context, src boil.ContextExecutor, where string, args []any, fn func(row archiveRow) error) error {
+	if t.stmt == nil {
+		return nil
+	}
+
+	// Date columns are read as text so they are copied exactly as stored, rather than reformatted by the driver.
+	selects := make([]string, len(t.cols))
+	for i, c := range t.cols {
+		selects[i] = fmt.Sprintf("%q", c)
+		if t.dates[c] {
+			selects[i] = fmt.Sprintf("CAST(%q AS TEXT)", c)
+		}
+	}
+	query := fmt.Sprintf(`SELECT %s FROM %q`, strings.Join(selects, ", "), t.name)
+	if where != emptyString {
+		query += " WHERE " + where
+	}
+	query += " ORDER BY id"
+
+	rows, err := src.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		row := archiveRow{table: t, vals: make([]any, len(t.cols))}
+		ptrs := make([]any, len(t.cols))
+		for i := range row.vals {
+			ptrs[i] = &row.vals[i]
+		}
+		if err = rows.Scan(ptrs...); err != nil {
+			return err
+		}
+		if err = fn(row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// insert writes the row to the destination and returns its ID there.
+func (t *archiveTable) insert(ctx context.Context, row archiveRow) (int64, error) {
+	vals := row.vals
+	if !t.keepID {
+		vals = vals[1:]
+	}
+	res, err := t.stmt.ExecContext(ctx, vals...)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (t *archiveTable) close() {
+	if t.stmt != nil {
+		_ = t.stmt.Close()
+	}
+}
+
+func (r archiveRow) index(col string) int {
+	for i, c := range r.table.cols {
+		if c == col {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r archiveRow) set(col string, v any) {
+	if i := r.index(col); i >= 0 {
+		r.vals[i] = v
+	}
+}
+
+func (r archiveRow) id() int64 {
+	return r.int64("id")
+}
+
+func (r archiveRow) int64(col string) int64 {
+	if i := r.index(col); i >= 0 {
+		if v, ok := r.vals[i].(int64); ok {
+			return v
+		}
+	}
+	return 0
+}
+
+func (r archiveRow) str(col string) string {
+	if i := r.index(col); i >= 0 {
+		switch v := r.vals[i].(type) {
+		case string:
+			return v
+		case []byte:
+			return string(v)
+		}
+	}
+	return emptyString
+}
+
+func quoteIdents(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = fmt.Sprintf("%q", n)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,154 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/enums/upload"
+	"github.com/Station-Manager/enums/upload/action"
+	"github.com/Station-Manager/enums/upload/status"
+	"github.com/Station-Manager/types"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumberedLogbookName(t *testing.T) {
+	assert.Equal(t, "Contest (2)", numberedLogbookName("Contest", 2))
+	assert.Equal(t, "Contest (12)", numberedLogbookName("Contest ", 12))
+
+	long := strings.Repeat("é", maxLogbookNameLen)
+	name := numberedLogbookName(long, 3)
+	assert.Equal(t, maxLogbookNameLen, utf8.RuneCountInString(name))
+	assert.True(t, utf8.ValidString(name))
+	assert.True(t, strings.HasSuffix(name, "é (3)"))
+}
+
+func TestLogbookArchiveRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	src := newTestService(t)
+	require.NoError(t, src.SetContactedStationSync(ContactedStationSyncNewest))
+	lb, err := src.InsertLogbook(types.Logbook{Name: "Main", Callsign: "W1AW", APIKey: "sm.0123abcd"})
+	require.NoError(t, err)
+	sess, err := src.GenerateSession()
+	require.NoError(t, err)
+	_, err = src.SetForwardingRule(ForwardingRule{LogbookID: lb, Service: upload.OnlineServiceQRZ, Enabled: true})
+	require.NoError(t, err)
+
+	first, err := src.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1430"))
+	require.NoError(t, err)
+	_, err = src.InsertQso(testQso(lb, sess, "DL2ABC", "20250107", "1431"))
+	require.NoError(t, err)
+
+	// The first QSO's insert is uploaded, the second's is still in progress when the logbook is exported.
+	uploads, err := src.FetchPendingUploadsForService(upload.OnlineServiceQRZ, 10)
+	require.NoError(t, err)
+	require.Len(t, uploads, 2)
+	require.NoError(t, src.UpdateQsoUploadStatus(uploads[0].ID, status.Uploaded, emptyString, 1, emptyString))
+
+	qso, err := src.FetchQsoById(first)
+	require.NoError(t, err)
+	qso.RstSent = "57"
+	require.NoError(t, src.UpdateQso(qso))
+	require.NoError(t, src.MarkQslSent(QslChannelLotw, []int64{first}, emptyString))
+
+	path := filepath.Join(t.TempDir(), "main.db")
+	exported, err := src.ExportLogbookArchive(ctx, lb, path)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, exported.Qsos)
+	assert.EqualValues(t, 3, exported.Uploads) // Both inserts and the update
+	assert.EqualValues(t, 1, exported.UploadAttempts)
+	assert.EqualValues(t, 2, exported.ContactedStations)
+	assert.NotZero(t, exported.Revisions)
+	assert.NotZero(t, exported.Qsls)
+
+	// The database imported into already has a logbook of the same name, and rows that push every ID along.
+	dst := newTestService(t)
+	require.NoError(t, dst.SetContactedStationSync(ContactedStationSyncNewest))
+	dstLb, err := dst.InsertLogbook(types.Logbook{Name: "Main", Callsign: "W1AW"})
+	require.NoError(t, err)
+	dstSess, err := dst.GenerateSession()
+	require.NoError(t, err)
+	_, err = dst.InsertQso(testQso(dstLb, dstSess, "DL1ABC", "20240101", "1200"))
+	require.NoError(t, err)
+
+	imported, err := dst.ImportLogbookArchive(ctx, path, ArchiveImportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "Main (2)", imported.LogbookName)
+	want := exported
+	want.LogbookID, want.LogbookName = imported.LogbookID, imported.LogbookName
+	want.ContactedStations, want.ContactedStationsSkipped = 1, 1 // DL1ABC is already there
+	assert.Equal(t, want, imported)
+
+	logbook, err := dst.FetchLogbookByID(imported.LogbookID)
+	require.NoError(t, err)
+	assert.Equal(t, "sm.0123abcd", logbook.APIKey)
+
+	// Every row refers to the imported copies.
+	qsos, err := models.Qsos(qm.WithDeleted(), models.QsoWhere.LogbookID.EQ(imported.LogbookID)).All(ctx, dst.handle)
+	require.NoError(t, err)
+	require.Len(t, qsos, 2)
+	qsoIDs := map[string]int64{}
+	var idArgs []interface{}
+	for _, q := range qsos {
+		qsoIDs[q.Call] = q.ID
+		idArgs = append(idArgs, q.ID)
+		assert.NotEqual(t, dstSess, q.SessionID)
+		_, er := models.FindSession(ctx, dst.handle, q.SessionID)
+		assert.NoError(t, er)
+	}
+	assert.NotEqual(t, first, qsoIDs["DL1ABC"], "QSO IDs must be remapped")
+	inImported := qm.WhereIn("qso_id IN ?", idArgs...)
+
+	ups, err := models.QsoUploads(inImported, qm.OrderBy("id")).All(ctx, dst.handle)
+	require.NoError(t, err)
+	require.Len(t, ups, int(imported.Uploads))
+	byQso := map[int64]map[string]*models.QsoUpload{}
+	for _, up := range ups {
+		if byQso[up.QsoID] == nil {
+			byQso[up.QsoID] = map[string]*models.QsoUpload{}
+		}
+		byQso[up.QsoID][up.Action] = up
+	}
+	assert.Equal(t, status.Uploaded.String(), byQso[qsoIDs["DL1ABC"]][action.Insert.String()].Status)
+	assert.Equal(t, status.Pending.String(), byQso[qsoIDs["DL1ABC"]][action.Update.String()].Status)
+	inProgress := byQso[qsoIDs["DL2ABC"]][action.Insert.String()]
+	assert.Equal(t, status.Pending.String(), inProgress.Status, "an upload in progress on export is pending again")
+	assert.False(t, inProgress.LeaseOwner.Valid)
+	assert.False(t, inProgress.LeaseExpiresAt.Valid)
+
+	attempts, err := models.QsoUploadAttempts(inImported).All(ctx, dst.handle)
+	require.NoError(t, err)
+	require.Len(t, attempts, int(imported.UploadAttempts))
+	for _, a := range attempts {
+		assert.Equal(t, byQso[a.QsoID][a.Action].ID, a.QsoUploadID.Int64)
+	}
+
+	revisions, err := models.QsoRevisions(inImported).Count(ctx, dst.handle)
+	require.NoError(t, err)
+	assert.Equal(t, imported.Revisions, revisions)
+	qsls, err := models.Qsls(inImported).All(ctx, dst.handle)
+	require.NoError(t, err)
+	require.Len(t, qsls, int(imported.Qsls))
+	assert.Equal(t, qsoIDs["DL1ABC"], qsls[0].QsoID)
+
+	// The API key is now taken, so the archive can only be imported again without it.
+	_, err = dst.ImportLogbookArchive(ctx, path, ArchiveImportOptions{})
+	assert.Error(t, err)
+	logbooks, err := dst.FetchAllLogbooks()
+	require.NoError(t, err)
+	assert.Len(t, logbooks, 2, "a failed import leaves nothing behind")
+
+	imported, err = dst.ImportLogbookArchive(ctx, path, ArchiveImportOptions{StripAPIKey: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Main (3)", imported.LogbookName)
+	assert.EqualValues(t, 2, imported.ContactedStationsSkipped)
+	logbook, err = dst.FetchLogbookByID(imported.LogbookID)
+	require.NoError(t, err)
+	assert.Empty(t, logbook.APIKey)
+}
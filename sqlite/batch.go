@@ -0,0 +1,14 @@
+package sqlite
+
+// BatchOptions controls InsertQsoBatchWithContext.
+type BatchOptions struct {
+	ErrorPolicy BatchErrorPolicy `json:"error_policy"` // Defaults to BatchStopOnFirstError
+}
+
+// BatchItemResult reports the outcome for the QSO at Index in the batch. Exactly one of ID or Err is set for
+// every QSO that was attempted.
+type BatchItemResult struct {
+	Index int   `json:"index"`
+	ID    int64 `json:"id"`
+	Err   error `json:"-"`
+}
@@ -0,0 +1,111 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/enums/upload"
+	"github.com/Station-Manager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertQsoBatchStopOnFirstError(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+
+	qsos := []types.Qso{
+		testQso(lb, sess, "DL1ABC", "20250107", "1430"),
+		testQso(999, sess, "DL2ABC", "20250107", "1431"), // No such logbook
+		testQso(lb, sess, "DL3ABC", "20250107", "1432"),
+	}
+	results, err := s.InsertQsoBatch(qsos, BatchOptions{})
+	require.Error(t, err)
+	require.Len(t, results, 2, "the batch stops at the failing QSO")
+	assert.Zero(t, results[0].ID, "no IDs are returned for a rolled back batch")
+	assert.NoError(t, results[0].Err)
+	assert.Error(t, results[1].Err)
+
+	count, err := s.FetchQsoCountByLogbookId(lb)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestInsertQsoBatchSkipBadRows(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+
+	// QSOs of the second logbook are inserted, then fail when their uploads are queued, as its rule cannot be read.
+	broken, err := s.InsertLogbook(types.Logbook{Name: "Broken", Callsign: "W1AW"})
+	require.NoError(t, err)
+	_, err = s.SetForwardingRule(ForwardingRule{LogbookID: broken, Service: upload.OnlineServiceQRZ, Enabled: true})
+	require.NoError(t, err)
+	_, err = s.handle.Exec(`UPDATE forwarding_rule SET modes = '[1]' WHERE logbook_id = ?`, broken)
+	require.NoError(t, err)
+
+	require.NoError(t, s.SetContactedStationSync(ContactedStationSyncNewest))
+	changes, err := s.Subscribe(t.Context(), ChangeFilter{Entities: []ChangeEntity{ChangeQso, ChangeContactedStation}})
+	require.NoError(t, err)
+
+	qsos := []types.Qso{
+		testQso(lb, sess, "DL1ABC", "20250107", "1430"),
+		testQso(999, sess, "DL2ABC", "20250107", "1431"), // No such logbook
+		testQso(broken, sess, "DL3ABC", "20250107", "1432"),
+		testQso(lb, sess, "DL4ABC", "20250107", "1433"),
+	}
+	results, err := s.InsertQsoBatch(qsos, BatchOptions{ErrorPolicy: BatchSkipBadRows})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	for i, r := range results {
+		assert.Equal(t, i, r.Index)
+	}
+	assert.NotZero(t, results[0].ID)
+	assert.Error(t, results[1].Err)
+	assert.Zero(t, results[1].ID)
+	assert.Error(t, results[2].Err)
+	assert.Zero(t, results[2].ID)
+	assert.NotZero(t, results[3].ID)
+
+	count, err := s.FetchQsoCountByLogbookId(lb)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+
+	// The failed rows left nothing behind: no QSO, no contacted station and no events.
+	n, err := models.Qsos(models.QsoWhere.LogbookID.EQ(broken)).Count(t.Context(), s.handle)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	_, err = s.FetchContactedStationByCallsign("DL3ABC")
+	assert.Error(t, err)
+
+	var inserted []int64
+	timeout := time.After(time.Second)
+	for len(inserted) < 4 {
+		select {
+		case ev := <-changes:
+			inserted = append(inserted, ev.ID)
+		case <-timeout:
+			t.Fatalf("got %d events, want 4", len(inserted))
+		}
+	}
+	stations, err := s.ListContactedStations(ContactedStationFilter{}, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, stations.Stations, 2)
+	assert.ElementsMatch(t, []int64{results[0].ID, results[3].ID, stations.Stations[0].CSID, stations.Stations[1].CSID}, inserted)
+	select {
+	case ev := <-changes:
+		t.Fatalf("unexpected event: %+v", ev)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestInsertQsoBatchErrorPolicy(t *testing.T) {
+	s := newTestService(t)
+
+	_, err := s.InsertQsoBatch([]types.Qso{{}}, BatchOptions{ErrorPolicy: "retry"})
+	assert.Error(t, err)
+
+	results, err := s.InsertQsoBatch(nil, BatchOptions{ErrorPolicy: BatchSkipBadRows})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
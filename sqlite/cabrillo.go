@@ -0,0 +1,226 @@
+package sqlite
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/goccy/go-json"
+)
+
+const cabrilloCreatedBy = "Station Manager"
+
+// CabrilloHeader holds the header fields of a Cabrillo log. Empty fields are left out of the log.
+type CabrilloHeader struct {
+	Callsign string `json:"callsign"` // Defaults to the logbook's callsign
+	Contest  string `json:"contest"`  // Defaults to the contest ID
+
+	CategoryOperator    string `json:"category_operator"`
+	CategoryAssisted    string `json:"category_assisted"`
+	CategoryBand        string `json:"category_band"`
+	CategoryMode        string `json:"category_mode"`
+	CategoryPower       string `json:"category_power"`
+	CategoryStation     string `json:"category_station"`
+	CategoryTransmitter string `json:"category_transmitter"`
+	CategoryOverlay     string `json:"category_overlay"`
+	CategoryTime        string `json:"category_time"`
+
+	ClaimedScore int64    `json:"claimed_score"`
+	Club         string   `json:"club"`
+	Location     string   `json:"location"`
+	GridLocator  string   `json:"grid_locator"`
+	Name         string   `json:"name"`
+	Email        string   `json:"email"`
+	Address      []string `json:"address"` // One ADDRESS line each
+	Operators    []string `json:"operators"`
+	Soapbox      []string `json:"soapbox"` // One SOAPBOX line each
+
+	// From and To select the QSOs by start time (UTC, inclusive, to the minute); they are not written to the log.
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// cabrilloBandFreqs holds the frequency designators Cabrillo uses for bands above 30 MHz.
+var cabrilloBandFreqs = map[string]string{
+	"6m": "50", "4m": "70", "2m": "144", "1.25m": "222", "70cm": "432", "33cm": "902", "23cm": "1.2G",
+	"13cm": "2.3G", "9cm": "3.4G", "6cm": "5.7G", "3cm": "10G", "1.25cm": "24G",
+}
+
+// ExportCabrillo writes the logbook's QSOs for a contest to w as a Cabrillo 3.0 log, oldest first, and reports how
+// many were written and which could not be. The exchange sent and received is the RST followed by STX/SRX. No
+// timeout is applied; cancel ctx to stop the export.
+func (s *Service) ExportCabrillo(ctx context.Context, logbookID int64, contestID string, header CabrilloHeader, w io.Writer) (ExportReport, error) {
+	const op errors.Op = "sqlite.Service.ExportCabrillo"
+	if err := checkService(op, s); err != nil {
+		return ExportReport{}, err
+	}
+
+	contestID = strings.TrimSpace(contestID)
+	if contestID == emptyString {
+		return ExportReport{}, errors.New(op).Msg("Contest ID cannot be empty.")
+	}
+	if w == nil {
+		return ExportReport{}, errors.New(op).Msg("Writer cannot be nil.")
+	}
+
+	filter := QsoFilter{LogbookID: logbookID, ContestID: contestID, From: header.From, To: header.To}
+	mods, err := filter.whereMods()
+	if err != nil {
+		return ExportReport{}, errors.New(op).Err(err)
+	}
+	mods = append(mods, qm.OrderBy(qsoChronological))
+
+	h, err := s.executor(ctx, op)
+	if err != nil {
+		return ExportReport{}, err
+	}
+
+	logbook, err := fetchLogbookByID(ctx, h, op, logbookID)
+	if err != nil {
+		return ExportReport{}, err
+	}
+	if header.Callsign == emptyString {
+		header.Callsign = logbook.Callsign
+	}
+	if header.Contest == emptyString {
+		header.Contest = contestID
+	}
+
+	bw := bufio.NewWriter(w)
+	writeCabrilloHeader(bw, header)
+
+	var report ExportReport
+	for model, er := range iterateQsoModels(ctx, h, mods) {
+		if er != nil {
+			err = er
+			break
+		}
+		line, er := cabrilloQsoLine(model, header.Callsign)
+		if er != nil {
+			s.LoggerService.WarnWith().Int64("qso.id", model.ID).Err(er).Msg("Skipping QSO in Cabrillo export.")
+			report.Skipped = append(report.Skipped, model.ID)
+			continue
+		}
+		if _, err = bw.WriteString(line); err != nil {
+			break
+		}
+		report.Qsos++
+	}
+	if err == nil {
+		_, _ = bw.WriteString("END-OF-LOG:\n")
+		err = bw.Flush()
+	}
+	if err != nil {
+		return report, errors.New(op).Err(err).Msg("Failed to export Cabrillo log.")
+	}
+
+	return report, nil
+}
+
+func writeCabrilloHeader(w *bufio.Writer, hdr CabrilloHeader) {
+	tag := func(name, value string) {
+		if value = strings.TrimSpace(value); value != emptyString {
+			_, _ = w.WriteString(name + ": " + value + "\n")
+		}
+	}
+
+	_, _ = w.WriteString("START-OF-LOG: 3.0\n")
+	tag("CALLSIGN", strings.ToUpper(hdr.Callsign))
+	tag("CONTEST", hdr.Contest)
+	tag("CATEGORY-OPERATOR", hdr.CategoryOperator)
+	tag("CATEGORY-ASSISTED", hdr.CategoryAssisted)
+	tag("CATEGORY-BAND", hdr.CategoryBand)
+	tag("CATEGORY-MODE", hdr.CategoryMode)
+	tag("CATEGORY-POWER", hdr.CategoryPower)
+	tag("CATEGORY-STATION", hdr.CategoryStation)
+	tag("CATEGORY-TRANSMITTER", hdr.CategoryTransmitter)
+	tag("CATEGORY-OVERLAY", hdr.CategoryOverlay)
+	tag("CATEGORY-TIME", hdr.CategoryTime)
+	if hdr.ClaimedScore > 0 {
+		tag("CLAIMED-SCORE", strconv.FormatInt(hdr.ClaimedScore, 10))
+	}
+	tag("CLUB", hdr.Club)
+	tag("LOCATION", hdr.Location)
+	tag("GRID-LOCATOR", hdr.GridLocator)
+	tag("NAME", hdr.Name)
+	for _, line := range hdr.Address {
+		tag("ADDRESS", line)
+	}
+	tag("EMAIL", hdr.Email)
+	tag("OPERATORS", strings.ToUpper(strings.Join(hdr.Operators, " ")))
+	for _, line := range hdr.Soapbox {
+		tag("SOAPBOX", line)
+	}
+	tag("CREATED-BY", cabrilloCreatedBy)
+}
+
+// cabrilloQsoLine formats a QSO in the usual column layout:
+//
+//	QSO: freq  mo date       time call          rst exch   call          rst exch
+func cabrilloQsoLine(model *models.Qso, defaultCall string) (string, error) {
+	var data types.QsoAdditionalData
+	if len(model.AdditionalData) > 0 {
+		if err := json.Unmarshal(model.AdditionalData, &data); err != nil {
+			return emptyString, err
+		}
+	}
+
+	date, err := time.Parse("20060102", model.QsoDate)
+	if err != nil {
+		return emptyString, err
+	}
+
+	sentCall := data.StationCallsign
+	if sentCall == emptyString {
+		sentCall = defaultCall
+	}
+
+	line := fmt.Sprintf("QSO: %5s %-2s %s %s %-13s %-3s %-6s %-13s %-3s %-6s",
+		cabrilloFreq(model.Freq, model.Band),
+		cabrilloMode(model.Mode),
+		date.Format("2006-01-02"),
+		model.TimeOn,
+		strings.ToUpper(sentCall), model.RstSent, data.STX,
+		strings.ToUpper(model.Call), model.RstRcvd, data.SRX,
+	)
+
+	return strings.TrimRight(line, " ") + "\n", nil
+}
+
+// cabrilloFreq returns the frequency in kHz below 30 MHz, and the band designator above it.
+func cabrilloFreq(khz int64, band string) string {
+	if khz > 0 && khz < 30000 {
+		return strconv.FormatInt(khz, 10)
+	}
+	if band == emptyString {
+		band = bandForFreqKHz(khz)
+	}
+	if f, ok := cabrilloBandFreqs[strings.ToLower(band)]; ok {
+		return f
+	}
+	return strconv.FormatInt(khz, 10)
+}
+
+// cabrilloMode maps an ADIF mode to the Cabrillo modes CW, PH, FM, RY and DG.
+func cabrilloMode(mode string) string {
+	switch strings.ToUpper(mode) {
+	case "CW":
+		return "CW"
+	case "SSB", "USB", "LSB", "AM", "PH":
+		return "PH"
+	case "FM":
+		return "FM"
+	case "RTTY", "RY":
+		return "RY"
+	default:
+		return "DG"
+	}
+}
@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCabrilloQsoLine(t *testing.T) {
+	model := &models.Qso{
+		Call:           "dl1abc",
+		Band:           "20m",
+		Mode:           "CW",
+		Freq:           14025,
+		QsoDate:        "20250107",
+		TimeOn:         "1430",
+		RstSent:        "599",
+		RstRcvd:        "599",
+		AdditionalData: []byte(`{"stx":"001","srx":"123","station_callsign":"W1AW"}`),
+	}
+
+	line, err := cabrilloQsoLine(model, "K1ABC")
+	require.NoError(t, err)
+	assert.Equal(t, "QSO: 14025 CW 2025-01-07 1430 W1AW          599 001    DL1ABC        599 123\n", line)
+}
+
+func TestCabrilloFreqAndMode(t *testing.T) {
+	assert.Equal(t, "7025", cabrilloFreq(7025, "40m"))
+	assert.Equal(t, "144", cabrilloFreq(144300, "2m"))
+	assert.Equal(t, "1.2G", cabrilloFreq(1296200, ""))
+	assert.Equal(t, "PH", cabrilloMode("usb"))
+	assert.Equal(t, "RY", cabrilloMode("RTTY"))
+	assert.Equal(t, "DG", cabrilloMode("FT8"))
+}
+
+func TestExportCabrilloReportsSkipped(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+
+	var ids []int64
+	for _, call := range []string{"DL1ABC", "DL2ABC"} {
+		qso := testQso(lb, sess, call, "20250107", "1430")
+		qso.ContestId = "CQ-WW-SSB"
+		id, err := s.InsertQso(qso)
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	_, err := s.handle.Exec(`UPDATE qso SET additional_data = json_set(additional_data, '$.name', 5) WHERE id = ?`, ids[1])
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	report, err := s.ExportCabrillo(context.Background(), lb, "CQ-WW-SSB", CabrilloHeader{}, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, ExportReport{Qsos: 1, Skipped: []int64{ids[1]}}, report)
+	assert.Contains(t, buf.String(), "DL1ABC")
+	assert.NotContains(t, buf.String(), "DL2ABC")
+	assert.Contains(t, buf.String(), "END-OF-LOG:")
+}
@@ -29,11 +29,17 @@ const (
 	// Kept short since SQLite is local and busy_timeout PRAGMA handles most wait scenarios.
 	pingRetryBackoff = 25 * time.Millisecond
 
-	// uploadRetryCooldown is the minimum time that must pass before a failed upload
-	// can be retried. This prevents rapid retry loops for persistently failing uploads.
+	// uploadRetryCooldown is the delay before the first retry of a failed upload under the default backoff.
+	// This prevents rapid retry loops for persistently failing uploads.
 	uploadRetryCooldown = 5 * time.Minute
 
 	// defaultUploadBatchLimit is the default number of pending uploads to process per batch
 	// when QsoForwardingRowLimit is not configured.
 	defaultUploadBatchLimit = 5
 )
+
+// Keys used inside the qso.additional_data JSON column (see types.QsoAdditionalData) that have no generated
+// column of their own.
+const (
+	jsonKeySig = "sig"
+)
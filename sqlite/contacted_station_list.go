@@ -0,0 +1,85 @@
+package sqlite
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+)
+
+// ContactedStationFilter describes which contacted stations a listing should return. An empty filter matches every
+// active station.
+type ContactedStationFilter struct {
+	// Query is matched, case-insensitively, against the call, name and QTH.
+	Query string      `json:"query"`
+	Match SearchMatch `json:"match"` // Defaults to SearchMatchPrefix
+
+	Deleted bool `json:"deleted"` // List soft-deleted stations instead of active ones
+}
+
+// ContactedStationSummary is a contacted station with how often, and when last, it was worked.
+type ContactedStationSummary struct {
+	types.ContactedStation
+
+	QsoCount   int64     `json:"qso_count"`   // Active QSOs with the station's call, across all logbooks
+	LastWorked time.Time `json:"last_worked"` // Start (UTC) of the newest of those QSOs; zero if none
+}
+
+// ContactedStationList holds one page of contacted stations along with the total number matching the filter.
+type ContactedStationList struct {
+	Stations []ContactedStationSummary `json:"stations"`
+	Total    int64                     `json:"total"`
+}
+
+// contactedStationRow is a contacted_station row joined with its QSO statistics.
+type contactedStationRow struct {
+	models.ContactedStation `boil:",bind"`
+
+	QsoCount   int64  `boil:"qso_count"`
+	LastWorked string `boil:"last_worked"`
+}
+
+// whereMods converts the filter into query mods on contacted_station.
+func (f ContactedStationFilter) whereMods() ([]qm.QueryMod, error) {
+	const op errors.Op = "sqlite.ContactedStationFilter.whereMods"
+
+	var mods []qm.QueryMod
+	if f.Deleted {
+		mods = append(mods, qm.WithDeleted(), qm.Where(`"contacted_station"."deleted_at" IS NOT NULL`))
+	}
+
+	query := strings.TrimSpace(f.Query)
+	if query == emptyString {
+		return mods, nil
+	}
+
+	var pattern string
+	switch f.Match {
+	case emptyString, SearchMatchPrefix:
+		pattern = escapeLike(query) + "%"
+	case SearchMatchSubstring:
+		pattern = "%" + escapeLike(query) + "%"
+	default:
+		return nil, errors.New(op).Msgf("Unknown search match: %q", f.Match)
+	}
+
+	mods = append(mods, qm.Where(`("contacted_station"."call" LIKE ? ESCAPE '\' OR "contacted_station"."name" LIKE ? ESCAPE '\' OR `+
+		`json_extract("contacted_station"."additional_data", '$.qth') LIKE ? ESCAPE '\')`, pattern, pattern, pattern))
+
+	return mods, nil
+}
+
+// lastWorked converts the newest qso_date || time_on of a station's QSOs into a time.
+func lastWorked(s string) time.Time {
+	if len(s) < len("200601021504") {
+		return time.Time{}
+	}
+	t, err := time.Parse("200601021504", s[:len("200601021504")])
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,152 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactedStationFilterWhereMods(t *testing.T) {
+	mods, err := ContactedStationFilter{}.whereMods()
+	assert.NoError(t, err)
+	assert.Empty(t, mods)
+
+	mods, err = ContactedStationFilter{Query: " dl1 ", Match: SearchMatchSubstring, Deleted: true}.whereMods()
+	assert.NoError(t, err)
+	assert.Len(t, mods, 3)
+
+	_, err = ContactedStationFilter{Query: "dl1", Match: "regex"}.whereMods()
+	assert.Error(t, err)
+}
+
+func TestLastWorked(t *testing.T) {
+	assert.Equal(t, time.Date(2025, 3, 1, 9, 15, 0, 0, time.UTC), lastWorked("202503010915"))
+	assert.Equal(t, time.Date(2025, 3, 1, 9, 15, 0, 0, time.UTC), lastWorked("20250301091530"))
+	assert.True(t, lastWorked("").IsZero())
+	assert.True(t, lastWorked("2025030109xx").IsZero())
+}
+
+func TestListContactedStations(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	other, err := s.InsertLogbook(types.Logbook{Name: "Other", Callsign: "W1AW"})
+	require.NoError(t, err)
+
+	stations := map[string]int64{}
+	for _, st := range []types.ContactedStation{
+		{Call: "G4XYZ", Name: "John", QTH: "Leeds"},
+		{Call: "DL2ABC", Name: "Karl", QTH: "Hamburg"},
+		{Call: "DL1ABC", Name: "Hans", QTH: "Berlin"},
+	} {
+		id, er := s.InsertContactedStation(st)
+		require.NoError(t, er)
+		stations[st.Call] = id
+	}
+
+	// DL1ABC is counted across logbooks, but not for its deleted QSO.
+	for _, q := range []types.Qso{
+		testQso(lb, sess, "DL1ABC", "20250107", "1430"),
+		testQso(lb, sess, "DL1ABC", "20250301", "0915"),
+		testQso(other, sess, "DL1ABC", "20250201", "1200"),
+		testQso(lb, sess, "G4XYZ", "20250105", "2359"),
+	} {
+		_, err = s.InsertQso(q)
+		require.NoError(t, err)
+	}
+	deleted, err := s.InsertQso(testQso(lb, sess, "DL1ABC", "20251231", "2359"))
+	require.NoError(t, err)
+	require.NoError(t, s.DeleteQso(deleted))
+
+	list := func(filter ContactedStationFilter, pageNum, pageSize int64) ([]string, int64) {
+		t.Helper()
+		result, er := s.ListContactedStations(filter, pageNum, pageSize)
+		require.NoError(t, er)
+		calls := make([]string, len(result.Stations))
+		for i, st := range result.Stations {
+			calls[i] = st.Call
+		}
+		return calls, result.Total
+	}
+
+	result, err := s.ListContactedStations(ContactedStationFilter{}, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, result.Stations, 3)
+	assert.Equal(t, int64(3), result.Total)
+	dl1, dl2, g4 := result.Stations[0], result.Stations[1], result.Stations[2]
+	assert.Equal(t, []string{"DL1ABC", "DL2ABC", "G4XYZ"}, []string{dl1.Call, dl2.Call, g4.Call})
+	assert.Equal(t, stations["DL1ABC"], dl1.CSID)
+	assert.Equal(t, "Berlin", dl1.QTH)
+	assert.Equal(t, int64(3), dl1.QsoCount)
+	assert.Equal(t, time.Date(2025, 3, 1, 9, 15, 0, 0, time.UTC), dl1.LastWorked)
+	assert.Zero(t, dl2.QsoCount)
+	assert.True(t, dl2.LastWorked.IsZero())
+	assert.Equal(t, int64(1), g4.QsoCount)
+
+	// Prefix (the default) and substring matches on call, name and QTH.
+	calls, total := list(ContactedStationFilter{Query: "dl"}, 1, 10)
+	assert.Equal(t, []string{"DL1ABC", "DL2ABC"}, calls)
+	assert.Equal(t, int64(2), total)
+	calls, _ = list(ContactedStationFilter{Query: "abc"}, 1, 10)
+	assert.Empty(t, calls)
+	calls, _ = list(ContactedStationFilter{Query: "abc", Match: SearchMatchSubstring}, 1, 10)
+	assert.Equal(t, []string{"DL1ABC", "DL2ABC"}, calls)
+	calls, _ = list(ContactedStationFilter{Query: "john"}, 1, 10)
+	assert.Equal(t, []string{"G4XYZ"}, calls)
+	calls, _ = list(ContactedStationFilter{Query: "ham"}, 1, 10)
+	assert.Equal(t, []string{"DL2ABC"}, calls)
+	calls, _ = list(ContactedStationFilter{Query: "erl", Match: SearchMatchSubstring}, 1, 10)
+	assert.Equal(t, []string{"DL1ABC"}, calls)
+	calls, _ = list(ContactedStationFilter{Query: "%"}, 1, 10)
+	assert.Empty(t, calls, "LIKE wildcards are matched literally")
+
+	// Paging keeps the total.
+	calls, total = list(ContactedStationFilter{}, 2, 2)
+	assert.Equal(t, []string{"G4XYZ"}, calls)
+	assert.Equal(t, int64(3), total)
+	calls, total = list(ContactedStationFilter{}, 3, 2)
+	assert.Empty(t, calls)
+	assert.Equal(t, int64(3), total)
+	_, err = s.ListContactedStations(ContactedStationFilter{}, 0, 2)
+	assert.Error(t, err)
+	_, err = s.ListContactedStations(ContactedStationFilter{}, 1, 0)
+	assert.Error(t, err)
+
+	// Deleted stations are listed on their own.
+	require.NoError(t, s.DeleteContactedStation(stations["G4XYZ"]))
+	calls, _ = list(ContactedStationFilter{}, 1, 10)
+	assert.Equal(t, []string{"DL1ABC", "DL2ABC"}, calls)
+	calls, total = list(ContactedStationFilter{Deleted: true}, 1, 10)
+	assert.Equal(t, []string{"G4XYZ"}, calls)
+	assert.Equal(t, int64(1), total)
+}
+
+func TestRestoreContactedStation(t *testing.T) {
+	s := newTestService(t)
+
+	old, err := s.InsertContactedStation(types.ContactedStation{Call: "G4XYZ", Name: "John"})
+	require.NoError(t, err)
+	assert.Error(t, s.RestoreContactedStation(old), "the station is not deleted")
+
+	require.NoError(t, s.DeleteContactedStation(old))
+	replacement, err := s.InsertContactedStation(types.ContactedStation{Call: "G4XYZ", Name: "Jon"})
+	require.NoError(t, err)
+
+	// An active station already has the call.
+	assert.Error(t, s.RestoreContactedStation(old))
+	station, err := s.FetchContactedStationByCallsign("G4XYZ")
+	require.NoError(t, err)
+	assert.Equal(t, replacement, station.CSID)
+
+	require.NoError(t, s.DeleteContactedStation(replacement))
+	require.NoError(t, s.RestoreContactedStation(old))
+	station, err = s.FetchContactedStationByCallsign("G4XYZ")
+	require.NoError(t, err)
+	assert.Equal(t, old, station.CSID)
+	assert.Equal(t, "John", station.Name)
+
+	assert.ErrorIs(t, s.RestoreContactedStation(old+100), errors.ErrNotFound)
+}
@@ -0,0 +1,371 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	stderr "errors"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Station-Manager/database/sqlite/adapters"
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/enums/upload/action"
+	"github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/goccy/go-json"
+)
+
+// ContactedStationRebuild reports what RebuildContactedStationsFromQsos did.
+type ContactedStationRebuild struct {
+	Qsos     int64 `json:"qsos"`     // QSOs read
+	Inserted int64 `json:"inserted"` // Stations created
+	Updated  int64 `json:"updated"`  // Existing stations changed
+	Skipped  int64 `json:"skipped"`  // QSOs whose call belongs to a station the user deleted
+}
+
+// stationField is a field of types.ContactedStation that QSOs can fill, identified by its JSON name.
+type stationField struct {
+	name  string
+	index int
+}
+
+// contactedStationFields lists the string fields of types.ContactedStation other than the call, which identifies
+// the station.
+var contactedStationFields = func() []stationField {
+	var fields []stationField
+	rt := reflect.TypeFor[types.ContactedStation]()
+	for i := range rt.NumField() {
+		f := rt.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if f.Type.Kind() != reflect.String || name == emptyString || name == "call" {
+			continue
+		}
+		fields = append(fields, stationField{name: name, index: i})
+	}
+	return fields
+}()
+
+// SetContactedStationSync configures whether inserted and updated QSOs are merged into the contacted station for
+// their call, creating it if there is none. Fields the user set through InsertContactedStation or
+// UpdateContactedStation are never overwritten. The default is ContactedStationSyncOff.
+func (s *Service) SetContactedStationSync(mode ContactedStationSync) error {
+	const op errors.Op = "sqlite.Service.SetContactedStationSync"
+	if s == nil {
+		return errors.New(op).Msg(errMsgNilService)
+	}
+
+	switch mode {
+	case emptyString:
+		mode = ContactedStationSyncOff
+	case ContactedStationSyncOff, ContactedStationSyncFillEmpty, ContactedStationSyncNewest:
+	default:
+		return errors.New(op).Msgf("Unknown contacted station sync mode: %q", mode)
+	}
+
+	s.mu.Lock()
+	s.stationSync = mode
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *Service) contactedStationSync() ContactedStationSync {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.stationSync == emptyString {
+		return ContactedStationSyncOff
+	}
+	return s.stationSync
+}
+
+// RebuildContactedStationsFromQsos merges every active QSO, oldest first, into the contacted station for its call,
+// creating stations that do not exist yet. It uses the configured sync mode, or ContactedStationSyncNewest when
+// syncing is off, and leaves fields the user set alone. Calls whose only station was deleted are not brought back.
+// The rebuild runs in a single transaction.
+func (s *Service) RebuildContactedStationsFromQsos(ctx context.Context) (ContactedStationRebuild, error) {
+	const op errors.Op = "sqlite.Service.RebuildContactedStationsFromQsos"
+	if err := checkService(op, s); err != nil {
+		return ContactedStationRebuild{}, err
+	}
+
+	mode := s.contactedStationSync()
+	if mode == ContactedStationSyncOff {
+		mode = ContactedStationSyncNewest
+	}
+
+	var report ContactedStationRebuild
+	err := s.WithTx(ctx, func(tx *Tx) error {
+		report = ContactedStationRebuild{}
+
+		existing, err := models.ContactedStations(qm.WithDeleted()).All(tx.ctx, tx.tx)
+		if err != nil {
+			return errors.New(op).Err(err).Msg("Failed to fetch contacted stations.")
+		}
+		stations := make(map[string]*syncedStation, len(existing))
+		deleted := map[string]bool{}
+		for _, m := range existing {
+			if m.DeletedAt.Valid {
+				deleted[m.Call] = true
+				continue
+			}
+			st, er := loadSyncedStation(m)
+			if er != nil {
+				return errors.New(op).Err(er).Msgf("Contacted station %d could not be read.", m.ID)
+			}
+			stations[m.Call] = st
+		}
+
+		for model, er := range iterateQsoModels(tx.ctx, tx.tx, []qm.QueryMod{qm.OrderBy(qsoChronological)}) {
+			if er != nil {
+				return errors.New(op).Err(er).Msg("Failed to read QSOs.")
+			}
+			report.Qsos++
+
+			seen, er := stationFromQso(model)
+			if er != nil {
+				return errors.New(op).Err(er).Msgf("QSO %d could not be read.", model.ID)
+			}
+			if strings.TrimSpace(seen.Call) == emptyString {
+				continue
+			}
+			st, ok := stations[seen.Call]
+			if !ok && deleted[seen.Call] {
+				report.Skipped++
+				continue
+			}
+			if !ok {
+				st = &syncedStation{station: types.ContactedStation{Call: seen.Call}}
+				stations[seen.Call] = st
+			}
+			st.merge(seen, qsoStartedAt(model), mode)
+		}
+
+		calls := make([]string, 0, len(stations))
+		for call, st := range stations {
+			if st.changed {
+				calls = append(calls, call)
+			}
+		}
+		sort.Strings(calls)
+
+		for _, call := range calls {
+			st := stations[call]
+			inserted := st.station.CSID == 0
+			if err = tx.saveSyncedStation(st); err != nil {
+				return errors.New(op).Err(err).Msgf("Contacted station %s could not be saved.", call)
+			}
+			if inserted {
+				report.Inserted++
+			} else {
+				report.Updated++
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return ContactedStationRebuild{}, err
+	}
+
+	return report, nil
+}
+
+// syncContactedStation merges the QSO into the contacted station for its call when syncing is on.
+func (t *Tx) syncContactedStation(model *models.Qso) error {
+	const op errors.Op = "sqlite.Tx.syncContactedStation"
+
+	mode := t.service.contactedStationSync()
+	if mode == ContactedStationSyncOff || model.DeletedAt.Valid {
+		return nil
+	}
+
+	seen, err := stationFromQso(model)
+	if err != nil {
+		return errors.New(op).Err(err)
+	}
+	if strings.TrimSpace(seen.Call) == emptyString {
+		return nil
+	}
+
+	// A deleted station is only looked at when there is no active one: the user removed it, so it is not recreated.
+	st := &syncedStation{station: types.ContactedStation{Call: seen.Call}}
+	existing, err := models.ContactedStations(
+		qm.WithDeleted(),
+		models.ContactedStationWhere.Call.EQ(seen.Call),
+		qm.OrderBy(models.ContactedStationColumns.DeletedAt+" IS NOT NULL"),
+	).One(t.ctx, t.tx)
+	switch {
+	case err == nil:
+		if existing.DeletedAt.Valid {
+			return nil
+		}
+		if st, err = loadSyncedStation(existing); err != nil {
+			return errors.New(op).Err(err).Msgf("Contacted station %d could not be read.", existing.ID)
+		}
+	case !stderr.Is(err, sql.ErrNoRows):
+		return errors.New(op).Err(err)
+	}
+
+	st.merge(seen, qsoStartedAt(model), mode)
+	if !st.changed {
+		return nil
+	}
+	if err = t.saveSyncedStation(st); err != nil {
+		return errors.New(op).Err(err).Msgf("Contacted station %s could not be saved.", seen.Call)
+	}
+
+	return nil
+}
+
+// syncedStation is a contacted station being brought up to date from QSOs.
+type syncedStation struct {
+	station    types.ContactedStation // CSID is zero until the station is inserted
+	userFields []string
+	lastQsoAt  string
+	changed    bool
+}
+
+func loadSyncedStation(m *models.ContactedStation) (*syncedStation, error) {
+	station, err := adapters.ContactedStationModelToType(m)
+	if err != nil {
+		return nil, err
+	}
+	userFields, err := decodeUserFields(m.UserFields)
+	if err != nil {
+		return nil, err
+	}
+	return &syncedStation{station: station, userFields: userFields, lastQsoAt: m.LastQsoAt.String}, nil
+}
+
+// merge applies the station as seen in a QSO that started at qsoAt. With ContactedStationSyncNewest, the values of
+// a QSO at least as new as any merged before replace the stored ones.
+func (st *syncedStation) merge(seen types.ContactedStation, qsoAt string, mode ContactedStationSync) {
+	newest := qsoAt >= st.lastQsoAt
+	if mergeContactedStation(&st.station, seen, mode == ContactedStationSyncNewest && newest, st.userFields) {
+		st.changed = true
+	}
+	if newest && qsoAt != st.lastQsoAt {
+		st.lastQsoAt = qsoAt
+		st.changed = true
+	}
+}
+
+// saveSyncedStation inserts or updates the station, leaving its user_fields as they are.
+func (t *Tx) saveSyncedStation(st *syncedStation) error {
+	model, err := adapters.ContactedStationTypeToModel(st.station)
+	if err != nil {
+		return err
+	}
+	if model.UserFields, err = encodeUserFields(st.userFields); err != nil {
+		return err
+	}
+	model.LastQsoAt = null.NewString(st.lastQsoAt, st.lastQsoAt != emptyString)
+
+	if model.ID == 0 {
+		if err = model.Insert(t.ctx, t.tx, boil.Infer()); err != nil {
+			return err
+		}
+		st.station.CSID = model.ID
+		t.publish(ChangeEvent{Entity: ChangeContactedStation, Action: action.Insert, ID: model.ID})
+		return nil
+	}
+
+	model.ModifiedAt = null.TimeFrom(time.Now())
+	if _, err = model.Update(t.ctx, t.tx, boil.Whitelist(
+		models.ContactedStationColumns.Name,
+		models.ContactedStationColumns.Country,
+		models.ContactedStationColumns.AdditionalData,
+		models.ContactedStationColumns.LastQsoAt,
+		models.ContactedStationColumns.ModifiedAt,
+	)); err != nil {
+		return err
+	}
+	t.publish(ChangeEvent{Entity: ChangeContactedStation, Action: action.Update, ID: model.ID})
+
+	return nil
+}
+
+// stationFromQso returns the contacted station as recorded in the QSO.
+func stationFromQso(model *models.Qso) (types.ContactedStation, error) {
+	qso, err := adapters.QsoModelToType(model)
+	if err != nil {
+		return types.ContactedStation{}, err
+	}
+	return qso.ContactedStation, nil
+}
+
+// qsoStartedAt is the QSO's start in a form that sorts chronologically.
+func qsoStartedAt(model *models.Qso) string {
+	return model.QsoDate + model.TimeOn
+}
+
+// mergeContactedStation merges the station as seen in a QSO into the stored one. Empty values in seen and fields
+// listed in userFields are ignored; other fields are filled when empty and, with overwrite, replaced when they
+// differ. It returns whether anything changed.
+func mergeContactedStation(stored *types.ContactedStation, seen types.ContactedStation, overwrite bool, userFields []string) bool {
+	dst := reflect.ValueOf(stored).Elem()
+	src := reflect.ValueOf(seen)
+
+	changed := false
+	for _, f := range contactedStationFields {
+		value := strings.TrimSpace(src.Field(f.index).String())
+		if value == emptyString || slices.Contains(userFields, f.name) {
+			continue
+		}
+		current := dst.Field(f.index)
+		if current.String() == value || (current.String() != emptyString && !overwrite) {
+			continue
+		}
+		current.SetString(value)
+		changed = true
+	}
+	return changed
+}
+
+// stationFieldsSet returns the JSON names of the fields that are set in station.
+func stationFieldsSet(station types.ContactedStation) []string {
+	v := reflect.ValueOf(station)
+	var names []string
+	for _, f := range contactedStationFields {
+		if strings.TrimSpace(v.Field(f.index).String()) != emptyString {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// stationFieldsChanged returns the JSON names of the fields whose values differ between before and after.
+func stationFieldsChanged(before, after types.ContactedStation) []string {
+	b, a := reflect.ValueOf(before), reflect.ValueOf(after)
+	var names []string
+	for _, f := range contactedStationFields {
+		if b.Field(f.index).String() != a.Field(f.index).String() {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+func decodeUserFields(data []byte) ([]string, error) {
+	var fields []string
+	if len(data) == 0 {
+		return fields, nil
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func encodeUserFields(fields []string) ([]byte, error) {
+	if fields == nil {
+		fields = []string{}
+	}
+	return json.Marshal(fields)
+}
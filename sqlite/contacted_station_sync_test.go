@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeContactedStation(t *testing.T) {
+	stored := types.ContactedStation{CSID: 7, Call: "G4ABC", Name: "John", QTH: "London"}
+	seen := types.ContactedStation{CSID: 9, Call: "G4XYZ", Name: "Jon", QTH: "Leeds", Gridsquare: "IO93", Email: " "}
+
+	fill := stored
+	assert.True(t, mergeContactedStation(&fill, seen, false, nil))
+	assert.Equal(t, types.ContactedStation{CSID: 7, Call: "G4ABC", Name: "John", QTH: "London", Gridsquare: "IO93"}, fill)
+
+	newest := stored
+	assert.True(t, mergeContactedStation(&newest, seen, true, []string{"name"}))
+	assert.Equal(t, types.ContactedStation{CSID: 7, Call: "G4ABC", Name: "John", QTH: "Leeds", Gridsquare: "IO93"}, newest)
+
+	assert.False(t, mergeContactedStation(&newest, seen, true, []string{"name"}))
+	assert.False(t, mergeContactedStation(&newest, types.ContactedStation{}, true, nil))
+}
+
+func TestSyncedStationMerge(t *testing.T) {
+	st := &syncedStation{station: types.ContactedStation{Call: "G4ABC"}}
+
+	st.merge(types.ContactedStation{QTH: "Leeds"}, "202401021200", ContactedStationSyncNewest)
+	assert.True(t, st.changed)
+	assert.Equal(t, "202401021200", st.lastQsoAt)
+
+	// An older QSO only fills empty fields.
+	st.merge(types.ContactedStation{QTH: "York", Name: "Jon"}, "202301011200", ContactedStationSyncNewest)
+	assert.Equal(t, "Leeds", st.station.QTH)
+	assert.Equal(t, "Jon", st.station.Name)
+	assert.Equal(t, "202401021200", st.lastQsoAt)
+
+	st.merge(types.ContactedStation{QTH: "York"}, "202501011200", ContactedStationSyncFillEmpty)
+	assert.Equal(t, "Leeds", st.station.QTH)
+	assert.Equal(t, "202501011200", st.lastQsoAt)
+}
+
+func TestStationFields(t *testing.T) {
+	before := types.ContactedStation{CSID: 1, Call: "G4ABC", Name: "John", Country: "England"}
+	after := types.ContactedStation{CSID: 1, Call: "G4ABC", Name: "John", QTH: "Leeds"}
+
+	assert.Equal(t, []string{"country", "name"}, stationFieldsSet(before))
+	assert.Equal(t, []string{"country", "qth"}, stationFieldsChanged(before, after))
+}
+
+func TestSyncKeepsDeletedStationDeleted(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	require.NoError(t, s.SetContactedStationSync(ContactedStationSyncNewest))
+
+	_, err := s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1430"))
+	require.NoError(t, err)
+	station, err := s.FetchContactedStationByCallsign("DL1ABC")
+	require.NoError(t, err)
+	require.NoError(t, s.DeleteContactedStation(station.CSID))
+
+	_, err = s.InsertQso(testQso(lb, sess, "DL1ABC", "20250108", "1430"))
+	require.NoError(t, err)
+	_, err = s.FetchContactedStationByCallsign("DL1ABC")
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+
+	report, err := s.RebuildContactedStationsFromQsos(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, ContactedStationRebuild{Qsos: 2, Skipped: 2}, report)
+	_, err = s.FetchContactedStationByCallsign("DL1ABC")
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+
+	deleted, err := s.ListContactedStations(ContactedStationFilter{Deleted: true}, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, deleted.Stations, 1)
+	assert.Equal(t, station.CSID, deleted.Stations[0].CSID)
+
+	// A station added by hand for the call is synced again.
+	_, err = s.InsertContactedStation(types.ContactedStation{Call: "DL1ABC"})
+	require.NoError(t, err)
+	report, err = s.RebuildContactedStationsFromQsos(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, ContactedStationRebuild{Qsos: 2, Updated: 1}, report)
+}
@@ -0,0 +1,386 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	stderr "errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/errors"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+)
+
+// CtyImportOptions controls ImportCountriesCty.
+type CtyImportOptions struct {
+	Format CtyFormat `json:"format"` // Defaults to CtyFormatAuto
+
+	// IncludeWAE also imports the entities that only count for the DARC WAE award (marked '*' in the file), such as
+	// Shetland. A prefix or callsign they share with a DXCC entity is then looked up as the WAE entity.
+	IncludeWAE bool `json:"include_wae"`
+}
+
+// CtyImportReport is the outcome of ImportCountriesCty.
+type CtyImportReport struct {
+	Entities   int64 `json:"entities"`    // Entities imported
+	Prefixes   int64 `json:"prefixes"`    // Prefix rows, the entities' primary prefixes included
+	ExactCalls int64 `json:"exact_calls"` // Exact callsign ('=') rows
+	SkippedWAE int64 `json:"skipped_wae"` // WAE-only entities left out
+}
+
+// ctyEntity is one entity of a country file.
+type ctyEntity struct {
+	name       string
+	primary    string // Primary prefix, without the '*' marking a WAE-only entity
+	dxcc       string // ADIF DXCC entity code; only in cty.csv
+	continent  string
+	cqZone     string
+	ituZone    string
+	timeOffset string // Hours west of UTC, as in the file
+	wae        bool
+	aliases    []string // Prefix and '=' callsign tokens, overrides included
+}
+
+// ctyAlias is a prefix or exact callsign with the overrides that follow it, e.g. "=K1ABC(5)[8]".
+type ctyAlias struct {
+	prefix     string
+	exact      bool
+	cqZone     string
+	ituZone    string
+	continent  string
+	timeOffset string
+}
+
+// ImportCountriesCty replaces the country table with the entities of a cty.dat or cty.csv file (as published at
+// country-files.com). Each entity gets a row for its primary prefix and one for every alias prefix, plus exact
+// rows for its '=' callsigns; zone, continent and time offset overrides on a prefix or callsign are applied to its
+// row. Time offsets are stored as hours east of UTC ("1" for Germany), the opposite sign to the file. The file is
+// read in full before anything is written, and the old rows are replaced in a single transaction, so a bad file
+// leaves the table as it was.
+func (s *Service) ImportCountriesCty(ctx context.Context, r io.Reader, opts CtyImportOptions) (CtyImportReport, error) {
+	const op errors.Op = "sqlite.Service.ImportCountriesCty"
+	if err := checkService(op, s); err != nil {
+		return CtyImportReport{}, err
+	}
+
+	if r == nil {
+		return CtyImportReport{}, errors.New(op).Msg("Reader cannot be nil.")
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return CtyImportReport{}, errors.New(op).Err(err).Msg("Failed to read country file.")
+	}
+
+	format := opts.Format
+	switch format {
+	case emptyString, CtyFormatAuto:
+		format = detectCtyFormat(data)
+	case CtyFormatDat, CtyFormatCSV:
+	default:
+		return CtyImportReport{}, errors.New(op).Msgf("Unknown country file format: %q", opts.Format)
+	}
+
+	var entities []ctyEntity
+	if format == CtyFormatCSV {
+		entities, err = parseCtyCSV(data)
+	} else {
+		entities, err = parseCtyDat(data)
+	}
+	if err != nil {
+		return CtyImportReport{}, errors.New(op).Err(err).Msg("Failed to parse country file.")
+	}
+
+	rows, report, err := ctyCountries(entities, opts.IncludeWAE)
+	if err != nil {
+		return CtyImportReport{}, errors.New(op).Err(err).Msg("Failed to parse country file.")
+	}
+	if len(rows) == 0 {
+		return CtyImportReport{}, errors.New(op).Msg("Country file has no entities.")
+	}
+
+	err = s.WithTx(ctx, func(tx *Tx) error {
+		if _, er := models.Countries(qm.WithDeleted()).DeleteAll(tx.ctx, tx.tx, true); er != nil {
+			return errors.New(op).Err(er).Msg("Failed to remove countries.")
+		}
+
+		const insert = `
+			INSERT INTO country (name, cq_zone, itu_zone, continent, prefix, ccode, dxcc_prefix, time_offset, exact)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+		stmt, er := tx.tx.PrepareContext(tx.ctx, insert)
+		if er != nil {
+			return errors.New(op).Err(er).Msg("Failed to prepare country insert.")
+		}
+		defer func() { _ = stmt.Close() }()
+
+		for _, c := range rows {
+			if _, er = stmt.ExecContext(tx.ctx, c.Name, c.CQZone, c.ItuZone, c.Continent, c.Prefix, c.Ccode,
+				c.DXCCPrefix, c.TimeOffset, c.Exact); er != nil {
+				return errors.New(op).Err(er).Msgf("Failed to insert country prefix %s.", c.Prefix)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return CtyImportReport{}, err
+	}
+
+	return report, nil
+}
+
+// detectCtyFormat tells cty.csv from cty.dat by the first record: cty.dat fields are separated by colons, cty.csv
+// fields by commas.
+func detectCtyFormat(data []byte) CtyFormat {
+	line, _, _ := bytes.Cut(bytes.TrimSpace(data), []byte("\n"))
+	colon, comma := bytes.IndexByte(line, ':'), bytes.IndexByte(line, ',')
+	if comma >= 0 && (colon < 0 || comma < colon) {
+		return CtyFormatCSV
+	}
+	return CtyFormatDat
+}
+
+// parseCtyDat parses cty.dat: each entity is a line of eight colon-terminated fields (name, CQ zone, ITU zone,
+// continent, latitude, longitude, time offset, primary prefix) followed by comma-separated aliases, over as many
+// lines as needed, ending with ';'.
+func parseCtyDat(data []byte) ([]ctyEntity, error) {
+	var entities []ctyEntity
+	for i, record := range strings.Split(string(data), ";") {
+		record = strings.TrimSpace(record)
+		if record == emptyString {
+			continue
+		}
+
+		fields := strings.SplitN(record, ":", 9)
+		if len(fields) != 9 {
+			return nil, fmt.Errorf("entity %d has %d fields instead of 8", i+1, len(fields)-1)
+		}
+		for j := range fields {
+			fields[j] = strings.TrimSpace(fields[j])
+		}
+
+		e := ctyEntity{
+			name:       fields[0],
+			cqZone:     fields[1],
+			ituZone:    fields[2],
+			continent:  fields[3],
+			timeOffset: fields[6],
+			primary:    fields[7],
+		}
+		e.primary, e.wae = strings.CutPrefix(e.primary, "*")
+		for _, alias := range strings.Split(fields[8], ",") {
+			if alias = strings.TrimSpace(alias); alias != emptyString {
+				e.aliases = append(e.aliases, alias)
+			}
+		}
+		entities = append(entities, e)
+	}
+	return entities, nil
+}
+
+// parseCtyCSV parses cty.csv: one line per entity with the primary prefix, name, ADIF DXCC entity code, continent,
+// CQ zone, ITU zone, latitude, longitude, time offset and the space-separated aliases ending with ';'.
+func parseCtyCSV(data []byte) ([]ctyEntity, error) {
+	cr := csv.NewReader(bytes.NewReader(data))
+	cr.FieldsPerRecord = 10
+	cr.TrimLeadingSpace = true
+
+	var entities []ctyEntity
+	for {
+		fields, err := cr.Read()
+		if stderr.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for j := range fields {
+			fields[j] = strings.TrimSpace(fields[j])
+		}
+
+		e := ctyEntity{
+			primary:    fields[0],
+			name:       fields[1],
+			dxcc:       fields[2],
+			continent:  fields[3],
+			cqZone:     fields[4],
+			ituZone:    fields[5],
+			timeOffset: fields[8],
+			aliases:    strings.Fields(strings.TrimSuffix(fields[9], ";")),
+		}
+		e.primary, e.wae = strings.CutPrefix(e.primary, "*")
+		entities = append(entities, e)
+	}
+	return entities, nil
+}
+
+// ctyCountries turns the entities into country rows. A prefix or callsign listed by two entities is an error,
+// unless one of them is a WAE entity, whose listing wins.
+func ctyCountries(entities []ctyEntity, includeWAE bool) ([]models.Country, CtyImportReport, error) {
+	type key struct {
+		prefix string
+		exact  bool
+	}
+	type listing struct {
+		row int
+		wae bool
+	}
+
+	var report CtyImportReport
+	var rows []models.Country
+	seen := map[key]listing{}
+
+	for _, e := range entities {
+		if e.wae && !includeWAE {
+			report.SkippedWAE++
+			continue
+		}
+		if e.name == emptyString || e.primary == emptyString {
+			return nil, report, fmt.Errorf("entity %q has no name or primary prefix", e.name+e.primary)
+		}
+
+		offset, err := ctyTimeOffset(e.timeOffset)
+		if err != nil {
+			return nil, report, fmt.Errorf("%s: %w", e.name, err)
+		}
+		if e.cqZone, err = ctyZone(e.cqZone); err != nil {
+			return nil, report, fmt.Errorf("%s: %w", e.name, err)
+		}
+		if e.ituZone, err = ctyZone(e.ituZone); err != nil {
+			return nil, report, fmt.Errorf("%s: %w", e.name, err)
+		}
+		report.Entities++
+
+		tokens := append([]string{e.primary}, e.aliases...)
+		for _, token := range tokens {
+			alias, er := parseCtyAlias(token)
+			if er != nil {
+				return nil, report, fmt.Errorf("%s: %w", e.name, er)
+			}
+
+			row := models.Country{
+				Name:       e.name,
+				CQZone:     e.cqZone,
+				ItuZone:    e.ituZone,
+				Continent:  e.continent,
+				Prefix:     alias.prefix,
+				Ccode:      e.dxcc,
+				DXCCPrefix: strings.ToUpper(e.primary),
+				TimeOffset: offset,
+				Exact:      alias.exact,
+			}
+			if alias.cqZone != emptyString {
+				if row.CQZone, er = ctyZone(alias.cqZone); er != nil {
+					return nil, report, fmt.Errorf("%s: %s: %w", e.name, token, er)
+				}
+			}
+			if alias.ituZone != emptyString {
+				if row.ItuZone, er = ctyZone(alias.ituZone); er != nil {
+					return nil, report, fmt.Errorf("%s: %s: %w", e.name, token, er)
+				}
+			}
+			if alias.continent != emptyString {
+				row.Continent = alias.continent
+			}
+			if alias.timeOffset != emptyString {
+				if row.TimeOffset, er = ctyTimeOffset(alias.timeOffset); er != nil {
+					return nil, report, fmt.Errorf("%s: %s: %w", e.name, token, er)
+				}
+			}
+
+			k := key{prefix: row.Prefix, exact: row.Exact}
+			if prev, ok := seen[k]; ok {
+				switch {
+				case rows[prev.row].Name == row.Name, prev.wae && !e.wae:
+					// Aliases normally repeat the primary prefix.
+				case e.wae && !prev.wae:
+					rows[prev.row] = row
+					seen[k] = listing{row: prev.row, wae: true}
+				default:
+					return nil, report, fmt.Errorf("%s is listed for both %s and %s", token, rows[prev.row].Name, row.Name)
+				}
+				continue
+			}
+			seen[k] = listing{row: len(rows), wae: e.wae}
+			rows = append(rows, row)
+			if row.Exact {
+				report.ExactCalls++
+			} else {
+				report.Prefixes++
+			}
+		}
+	}
+
+	return rows, report, nil
+}
+
+// parseCtyAlias parses a prefix or '=' callsign and its overrides: (CQ zone), [ITU zone], {continent},
+// <latitude/longitude> (ignored) and ~time offset~.
+func parseCtyAlias(token string) (ctyAlias, error) {
+	var alias ctyAlias
+	rest := token
+	if alias.exact = strings.HasPrefix(rest, "="); alias.exact {
+		rest = rest[1:]
+	}
+
+	end := strings.IndexAny(rest, "([<{~")
+	if end < 0 {
+		end = len(rest)
+	}
+	alias.prefix = strings.ToUpper(strings.TrimSpace(rest[:end]))
+	if alias.prefix == emptyString {
+		return ctyAlias{}, fmt.Errorf("%q has no prefix", token)
+	}
+
+	closers := map[byte]byte{'(': ')', '[': ']', '<': '>', '{': '}', '~': '~'}
+	for rest = rest[end:]; rest != emptyString; {
+		closer, ok := closers[rest[0]]
+		if !ok {
+			return ctyAlias{}, fmt.Errorf("%q has an unexpected %q", token, rest[0])
+		}
+		i := strings.IndexByte(rest[1:], closer)
+		if i < 0 {
+			return ctyAlias{}, fmt.Errorf("%q has an unterminated override", token)
+		}
+
+		value := strings.TrimSpace(rest[1 : 1+i])
+		switch rest[0] {
+		case '(':
+			alias.cqZone = value
+		case '[':
+			alias.ituZone = value
+		case '{':
+			alias.continent = value
+		case '~':
+			alias.timeOffset = value
+		}
+		rest = rest[i+2:]
+	}
+
+	return alias, nil
+}
+
+// ctyZone normalises a CQ or ITU zone, which cty.dat pads to two digits and cty.csv does not.
+func ctyZone(s string) (string, error) {
+	zone, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || zone < 1 {
+		return emptyString, fmt.Errorf("invalid zone %q", s)
+	}
+	return strconv.Itoa(zone), nil
+}
+
+// ctyTimeOffset converts a cty time offset, in hours west of UTC, to hours east of UTC.
+func ctyTimeOffset(s string) (string, error) {
+	hours, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return emptyString, fmt.Errorf("invalid time offset %q", s)
+	}
+	if hours == 0 {
+		return "0", nil
+	}
+	return strconv.FormatFloat(-hours, 'f', -1, 64), nil
+}
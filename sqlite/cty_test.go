@@ -0,0 +1,200 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCtyDat = `Fed. Rep. of Germany:     14:  28:  EU:   51.00:   -10.00:    -1.0:  DL:
+    DA,DB,DL,DM,
+    =DL0ABC(15);
+Italy:                    15:  28:  EU:   42.82:   -12.58:    -1.0:  I:
+    I,IH9;
+African Italy:            33:  37:  AF:   35.67:   -12.67:    -1.0:  *IG9:
+    IG9,IH9;
+United States:            05:  08:  NA:   37.53:    91.67:     5.0:  K:
+    AA,K,KH6ABC(31)[61]<21.0/157.0>{OC}~10~,=W1AW/KH6(31)[61];
+`
+
+const testCtyCSV = `DL,Fed. Rep. of Germany,230,EU,14,28,51.00,-10.00,-1.0,DA DB DL DM =DL0ABC(15);
+K,United States,291,NA,5,8,37.53,91.67,5.0,AA K KH6ABC(31)[61]{OC}~10~ =W1AW/KH6(31)[61];
+`
+
+func TestDetectCtyFormat(t *testing.T) {
+	assert.Equal(t, CtyFormatDat, detectCtyFormat([]byte(testCtyDat)))
+	assert.Equal(t, CtyFormatCSV, detectCtyFormat([]byte(testCtyCSV)))
+	assert.Equal(t, CtyFormatDat, detectCtyFormat(nil))
+}
+
+func TestParseCtyAlias(t *testing.T) {
+	alias, err := parseCtyAlias("=w1aw/KH6(31)[61]")
+	require.NoError(t, err)
+	assert.Equal(t, ctyAlias{prefix: "W1AW/KH6", exact: true, cqZone: "31", ituZone: "61"}, alias)
+
+	alias, err = parseCtyAlias("KH6ABC(31)[61]<21.0/157.0>{OC}~10~")
+	require.NoError(t, err)
+	assert.Equal(t, ctyAlias{prefix: "KH6ABC", cqZone: "31", ituZone: "61", continent: "OC", timeOffset: "10"}, alias)
+
+	_, err = parseCtyAlias("(31)")
+	assert.Error(t, err)
+	_, err = parseCtyAlias("K(31")
+	assert.Error(t, err)
+	_, err = parseCtyAlias("K(31)x")
+	assert.Error(t, err)
+}
+
+func TestCtyZone(t *testing.T) {
+	zone, err := ctyZone("05")
+	require.NoError(t, err)
+	assert.Equal(t, "5", zone)
+	_, err = ctyZone("")
+	assert.Error(t, err)
+	_, err = ctyZone("0")
+	assert.Error(t, err)
+}
+
+func TestCtyTimeOffset(t *testing.T) {
+	for in, want := range map[string]string{"-1.0": "1", "5.0": "-5", "-5.5": "5.5", "0.0": "0", " 10 ": "-10"} {
+		got, err := ctyTimeOffset(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	_, err := ctyTimeOffset("x")
+	assert.Error(t, err)
+}
+
+func TestCtyCountries(t *testing.T) {
+	entities, err := parseCtyDat([]byte(testCtyDat))
+	require.NoError(t, err)
+	require.Len(t, entities, 4)
+	assert.True(t, entities[2].wae)
+	assert.Equal(t, "IG9", entities[2].primary)
+
+	rows, report, err := ctyCountries(entities, false)
+	require.NoError(t, err)
+	assert.Equal(t, CtyImportReport{Entities: 3, Prefixes: 9, ExactCalls: 2, SkippedWAE: 1}, report)
+
+	byPrefix := map[string]models.Country{}
+	for _, r := range rows {
+		if r.Exact {
+			byPrefix["="+r.Prefix] = r
+		} else {
+			byPrefix[r.Prefix] = r
+		}
+	}
+	assert.Equal(t, models.Country{Name: "Fed. Rep. of Germany", CQZone: "15", ItuZone: "28", Continent: "EU",
+		Prefix: "DL0ABC", DXCCPrefix: "DL", TimeOffset: "1", Exact: true}, byPrefix["=DL0ABC"])
+	assert.Equal(t, models.Country{Name: "United States", CQZone: "31", ItuZone: "61", Continent: "OC",
+		Prefix: "KH6ABC", DXCCPrefix: "K", TimeOffset: "-10"}, byPrefix["KH6ABC"])
+	assert.Equal(t, "Italy", byPrefix["IH9"].Name)
+
+	rows, report, err = ctyCountries(entities, true)
+	require.NoError(t, err)
+	assert.Equal(t, CtyImportReport{Entities: 4, Prefixes: 10, ExactCalls: 2}, report)
+	for _, r := range rows {
+		if r.Prefix == "IH9" {
+			assert.Equal(t, "African Italy", r.Name)
+			assert.Equal(t, "IG9", r.DXCCPrefix)
+		}
+	}
+
+	entities = append(entities, ctyEntity{name: "Alaska", primary: "KL", timeOffset: "9.0", aliases: []string{"AA"}})
+	_, _, err = ctyCountries(entities, false)
+	assert.Error(t, err)
+}
+
+func TestParseCtyCSV(t *testing.T) {
+	entities, err := parseCtyCSV([]byte(testCtyCSV))
+	require.NoError(t, err)
+	require.Len(t, entities, 2)
+	assert.Equal(t, "230", entities[0].dxcc)
+	assert.Equal(t, []string{"DA", "DB", "DL", "DM", "=DL0ABC(15)"}, entities[0].aliases)
+
+	rows, report, err := ctyCountries(entities, false)
+	require.NoError(t, err)
+	assert.Equal(t, CtyImportReport{Entities: 2, Prefixes: 7, ExactCalls: 2}, report)
+	assert.Equal(t, "291", rows[len(rows)-1].Ccode)
+
+	_, err = parseCtyCSV([]byte("DL,Germany,230;\n"))
+	assert.Error(t, err)
+}
+
+func TestImportCountriesCty(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	countries := func() int64 {
+		t.Helper()
+		n, err := models.Countries().Count(ctx, s.handle)
+		require.NoError(t, err)
+		return n
+	}
+
+	_, err := s.InsertCountry(types.Country{Name: "Atlantis", Prefix: "ZZ", DXCCPrefix: "ZZ"})
+	require.NoError(t, err)
+
+	// Italy has a prefix that is a longer match for DL0ABC than Germany's, but not the exact callsign.
+	const input = testCtyDat + `Vatican:                  15:  28:  EU:   41.90:   -12.45:    -1.0:  HV:
+    HV,DL0AB;
+`
+	report, err := s.ImportCountriesCty(ctx, strings.NewReader(input), CtyImportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, CtyImportReport{Entities: 4, Prefixes: 11, ExactCalls: 2, SkippedWAE: 1}, report)
+	assert.Equal(t, int64(13), countries())
+	_, err = s.FetchCountryByName("Atlantis")
+	assert.ErrorIs(t, err, errors.ErrNotFound, "the old rows are replaced")
+
+	for call, want := range map[string]struct{ name, cqZone string }{
+		"DL0ABC":   {"Fed. Rep. of Germany", "15"},
+		"dl0abd":   {"Vatican", "15"},
+		"DL1XYZ":   {"Fed. Rep. of Germany", "14"},
+		"KH6ABCD":  {"United States", "31"},
+		"W1AW/KH6": {"United States", "31"},
+		"K1ABC":    {"United States", "5"},
+		"IH9A":     {"Italy", "15"},
+	} {
+		country, er := s.FetchCountryByCallsign(call)
+		require.NoError(t, er, call)
+		assert.Equal(t, want.name, country.Name, call)
+		assert.Equal(t, want.cqZone, country.CQZone, call)
+	}
+	_, err = s.FetchCountryByCallsign("W1AW")
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+
+	country, err := s.FetchCountryByName("United States")
+	require.NoError(t, err)
+	assert.Equal(t, "K", country.Prefix)
+	assert.Equal(t, "5", country.CQZone)
+	assert.Equal(t, "-5", country.TimeOffset)
+	country, err = s.FetchCountryByName("Fed. Rep. of Germany")
+	require.NoError(t, err)
+	assert.Equal(t, "DL", country.Prefix)
+
+	// A file that does not parse, or has nothing in it, changes nothing.
+	for name, bad := range map[string]string{
+		"malformed": "Germany:  14:  28:  EU:   51.00:   -10.00:    -1.0:  DL:\n    DL(14;\n",
+		"empty":     "",
+	} {
+		_, err = s.ImportCountriesCty(ctx, strings.NewReader(bad), CtyImportOptions{})
+		assert.Error(t, err, name)
+		assert.Equal(t, int64(13), countries(), name)
+	}
+
+	// A failure while writing rolls back the removal of the old rows.
+	_, err = s.handle.Exec(`CREATE TEMP TRIGGER fail_country BEFORE INSERT ON main.country WHEN NEW.prefix = 'KH6ABC'
+		BEGIN SELECT RAISE(ABORT, 'no KH6ABC'); END`)
+	require.NoError(t, err)
+	_, err = s.ImportCountriesCty(ctx, strings.NewReader(testCtyCSV), CtyImportOptions{})
+	assert.ErrorContains(t, err, "Failed to insert country prefix KH6ABC.")
+	assert.Equal(t, int64(13), countries())
+	country, err = s.FetchCountryByCallsign("HV1A")
+	require.NoError(t, err)
+	assert.Equal(t, "Vatican", country.Name)
+}
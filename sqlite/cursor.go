@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"encoding/base64"
+
+	"github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
+	"github.com/goccy/go-json"
+)
+
+// QsoCursorPage is one page of a keyset (cursor) paginated QSO listing. The cursors are opaque tokens that are
+// passed back to FetchQsoSliceByCursorWithContext to move forwards or backwards from this page.
+type QsoCursorPage struct {
+	Qsos       types.QsoSlice `json:"qsos"`
+	NextCursor string         `json:"next_cursor"` // Empty when there are no more QSOs after this page
+	PrevCursor string         `json:"prev_cursor"` // Empty when this is the first page
+}
+
+// qsoCursor identifies a position in the (qso_date, time_on, id) ordering. Before is set on cursors that page
+// backwards, i.e. towards the start of the listing.
+type qsoCursor struct {
+	QsoDate string `json:"d"`
+	TimeOn  string `json:"t"`
+	ID      int64  `json:"i"`
+	Before  bool   `json:"b,omitempty"`
+}
+
+func (c qsoCursor) encode() string {
+	data, _ := json.Marshal(c) // Cannot fail for this struct
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeQsoCursor(token string) (qsoCursor, error) {
+	const op errors.Op = "sqlite.decodeQsoCursor"
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return qsoCursor{}, errors.New(op).Err(err).Msg(errMsgInvalidCursor)
+	}
+
+	var c qsoCursor
+	if err = json.Unmarshal(data, &c); err != nil {
+		return qsoCursor{}, errors.New(op).Err(err).Msg(errMsgInvalidCursor)
+	}
+
+	if len(c.QsoDate) != 8 || len(c.TimeOn) != 4 || c.ID < 1 {
+		return qsoCursor{}, errors.New(op).Msg(errMsgInvalidCursor)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,106 @@
+package sqlite
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/Station-Manager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQsoCursorRoundTrip(t *testing.T) {
+	for _, c := range []qsoCursor{
+		{QsoDate: "20250107", TimeOn: "1430", ID: 42},
+		{QsoDate: "20250107", TimeOn: "1430", ID: 42, Before: true},
+	} {
+		got, err := decodeQsoCursor(c.encode())
+		require.NoError(t, err)
+		assert.Equal(t, c, got)
+	}
+}
+
+func TestDecodeQsoCursorRejectsMalformed(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	for name, token := range map[string]string{
+		"not base64":   "!!!",
+		"not JSON":     encode("cursor"),
+		"wrong types":  encode(`{"d":20250107,"t":"1430","i":1}`),
+		"short date":   encode(`{"d":"2025010","t":"1430","i":1}`),
+		"long time":    encode(`{"d":"20250107","t":"14300","i":1}`),
+		"missing ID":   encode(`{"d":"20250107","t":"1430"}`),
+		"negative ID":  encode(`{"d":"20250107","t":"1430","i":-1}`),
+		"empty object": encode(`{}`),
+	} {
+		_, err := decodeQsoCursor(token)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestFetchQsoSliceByCursor(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+
+	// Three QSOs share qso_date and time_on, so only the ID keeps pages from overlapping or skipping rows.
+	for _, q := range []types.Qso{
+		testQso(lb, sess, "DL3ABC", "20250107", "1430"),
+		testQso(lb, sess, "DL5ABC", "20250108", "0001"),
+		testQso(lb, sess, "DL4ABC", "20250107", "1430"),
+		testQso(lb, sess, "DL1ABC", "20250107", "1429"),
+		testQso(lb, sess, "DL2ABC", "20250107", "1430"),
+	} {
+		_, err := s.InsertQso(q)
+		require.NoError(t, err)
+	}
+
+	calls := func(page QsoCursorPage) []string {
+		out := make([]string, len(page.Qsos))
+		for i, q := range page.Qsos {
+			out[i] = q.Call
+		}
+		return out
+	}
+	fetch := func(cursor string, ordering Ordering) QsoCursorPage {
+		t.Helper()
+		page, err := s.FetchQsoSliceByCursor(lb, cursor, 2, ordering)
+		require.NoError(t, err)
+		return page
+	}
+
+	// Forwards through the log, oldest first. Tied rows come in insertion (ID) order.
+	p1 := fetch(emptyString, Ascending)
+	assert.Equal(t, []string{"DL1ABC", "DL3ABC"}, calls(p1))
+	assert.Empty(t, p1.PrevCursor)
+	p2 := fetch(p1.NextCursor, Ascending)
+	assert.Equal(t, []string{"DL4ABC", "DL2ABC"}, calls(p2))
+	p3 := fetch(p2.NextCursor, Ascending)
+	assert.Equal(t, []string{"DL5ABC"}, calls(p3))
+	assert.Empty(t, p3.NextCursor)
+
+	// And back again.
+	back := fetch(p3.PrevCursor, Ascending)
+	assert.Equal(t, calls(p2), calls(back))
+	assert.NotEmpty(t, back.NextCursor)
+	back = fetch(back.PrevCursor, Ascending)
+	assert.Equal(t, calls(p1), calls(back))
+	assert.Empty(t, back.PrevCursor)
+	assert.Equal(t, calls(p2), calls(fetch(back.NextCursor, Ascending)))
+
+	// Newest first.
+	p1 = fetch(emptyString, Descending)
+	assert.Equal(t, []string{"DL5ABC", "DL2ABC"}, calls(p1))
+	p2 = fetch(p1.NextCursor, Descending)
+	assert.Equal(t, []string{"DL4ABC", "DL3ABC"}, calls(p2))
+	p3 = fetch(p2.NextCursor, Descending)
+	assert.Equal(t, []string{"DL1ABC"}, calls(p3))
+	assert.Empty(t, p3.NextCursor)
+	back = fetch(p3.PrevCursor, Descending)
+	assert.Equal(t, calls(p2), calls(back))
+	back = fetch(back.PrevCursor, Descending)
+	assert.Equal(t, calls(p1), calls(back))
+	assert.Empty(t, back.PrevCursor)
+
+	_, err := s.FetchQsoSliceByCursor(lb, "!!!", 2, Ascending)
+	assert.Error(t, err)
+}
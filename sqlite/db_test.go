@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Station-Manager/config"
+	"github.com/Station-Manager/logging"
+	"github.com/Station-Manager/types"
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestService returns an open, migrated service on a database in a temporary directory. A single connection is
+// used, so a query that does not run on the transaction it should join blocks instead of passing by accident.
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+
+	dir := t.TempDir()
+	cfg := types.AppConfig{
+		DatastoreConfig: types.DatastoreConfig{
+			Driver:                    SqliteDriver,
+			Path:                      filepath.Join(dir, "test.db"),
+			Options:                   map[string]string{"_foreign_keys": "on", "_journal_mode": "WAL", "_busy_timeout": "2000"},
+			MaxOpenConns:              1,
+			MaxIdleConns:              1,
+			ConnMaxLifetime:           1,
+			ConnMaxIdleTime:           1,
+			ContextTimeout:            5,
+			TransactionContextTimeout: 5,
+		},
+		LoggingConfig: types.LoggingConfig{Level: "error", ConsoleLogging: true, RelLogFileDir: "logs", ShutdownTimeoutMS: 100},
+	}
+	data, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), data, 0o640))
+
+	cs := &config.Service{WorkingDir: dir}
+	require.NoError(t, cs.Initialize())
+	ls := &logging.Service{ConfigService: cs}
+	require.NoError(t, ls.Initialize())
+
+	s := &Service{ConfigService: cs, LoggerService: ls}
+	require.NoError(t, s.Initialize())
+	require.NoError(t, s.Open())
+	t.Cleanup(func() { _ = s.Close() })
+	require.NoError(t, s.Migrate())
+
+	return s
+}
+
+// newTestLogbook adds a logbook and a session to log QSOs in.
+func newTestLogbook(t *testing.T, s *Service) (logbookID, sessionID int64) {
+	t.Helper()
+
+	logbookID, err := s.InsertLogbook(types.Logbook{Name: "Test", Callsign: "W1AW"})
+	require.NoError(t, err)
+	sessionID, err = s.GenerateSession()
+	require.NoError(t, err)
+
+	return logbookID, sessionID
+}
+
+// testQso returns a QSO that passes validation.
+func testQso(logbookID, sessionID int64, call, date, timeOn string) types.Qso {
+	return types.Qso{
+		LogbookID: logbookID,
+		SessionID: sessionID,
+		QsoDetails: types.QsoDetails{Band: "20m", Mode: "SSB", Freq: "14250", QsoDate: date, TimeOn: timeOn,
+			TimeOff: timeOn, RstSent: "59", RstRcvd: "59"},
+		ContactedStation: types.ContactedStation{Call: call, Country: "Germany"},
+		LoggingStation:   types.LoggingStation{StationCallsign: "W1AW"},
+	}
+}
@@ -0,0 +1,112 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	stderr "errors"
+	"fmt"
+	"time"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/errors"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+)
+
+// ErrDuplicateQso is matched (via errors.Is) by every DuplicateQsoError.
+var ErrDuplicateQso = stderr.New("duplicate QSO")
+
+// DuplicateQsoError is returned by InsertQso when DuplicateReject finds a matching QSO. Nothing is inserted.
+type DuplicateQsoError struct {
+	ExistingID int64
+}
+
+func (e *DuplicateQsoError) Error() string {
+	return fmt.Sprintf("duplicate of QSO %d", e.ExistingID)
+}
+
+func (e *DuplicateQsoError) Is(target error) bool {
+	return target == ErrDuplicateQso
+}
+
+// InsertQsoResult describes a QSO inserted by InsertQsoChecked.
+type InsertQsoResult struct {
+	ID int64 `json:"id"`
+	// DuplicateOf is the existing QSO the inserted one matched under DuplicateWarn; zero when it matched none.
+	DuplicateOf int64 `json:"duplicate_of,omitempty"`
+}
+
+// duplicateGuard holds the duplicate policy. The zero value allows duplicates.
+type duplicateGuard struct {
+	policy DuplicatePolicy
+	window time.Duration
+}
+
+// SetDuplicatePolicy configures how InsertQso treats a QSO with the same logbook, call, band and mode as an
+// existing QSO whose start time is within 'window' of it. A zero window only matches QSOs starting in the same
+// minute. The default policy is DuplicateAllow.
+func (s *Service) SetDuplicatePolicy(policy DuplicatePolicy, window time.Duration) error {
+	const op errors.Op = "sqlite.Service.SetDuplicatePolicy"
+	if s == nil {
+		return errors.New(op).Msg(errMsgNilService)
+	}
+
+	switch policy {
+	case emptyString:
+		policy = DuplicateAllow
+	case DuplicateAllow, DuplicateWarn, DuplicateReject:
+	default:
+		return errors.New(op).Msgf("Unknown duplicate policy: %q", policy)
+	}
+
+	if window < 0 {
+		return errors.New(op).Msg("Duplicate window cannot be negative.")
+	}
+
+	s.mu.Lock()
+	s.duplicates = duplicateGuard{policy: policy, window: window}
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *Service) duplicatePolicy() duplicateGuard {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.duplicates
+}
+
+// findDuplicateQso returns the ID of an active QSO matching model (same logbook, call, band and mode, starting
+// within window of it), or zero if there is none.
+func findDuplicateQso(ctx context.Context, exec boil.ContextExecutor, model *models.Qso, window time.Duration) (int64, error) {
+	const op errors.Op = "sqlite.findDuplicateQso"
+
+	start, err := time.Parse("200601021504", model.QsoDate+model.TimeOn)
+	if err != nil {
+		return 0, errors.New(op).Err(err).Msg("Invalid QSO date/time.")
+	}
+
+	filter := QsoFilter{
+		LogbookID: model.LogbookID,
+		Call:      model.Call,
+		Bands:     []string{model.Band},
+		Modes:     []string{model.Mode},
+		From:      start.Add(-window),
+		To:        start.Add(window),
+	}
+	mods, err := filter.whereMods()
+	if err != nil {
+		return 0, errors.New(op).Err(err)
+	}
+	mods = append(mods, qm.Select(models.QsoColumns.ID), qm.OrderBy(models.QsoColumns.ID), qm.Limit(1))
+
+	existing, err := models.Qsos(mods...).One(ctx, exec)
+	if err != nil {
+		if stderr.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, errors.New(op).Err(err)
+	}
+
+	return existing.ID, nil
+}
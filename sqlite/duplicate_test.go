@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsContestDuplicate(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+
+	qso := testQso(lb, sess, "DL1ABC", "20250107", "1430")
+	qso.ContestId = "CQ-WW-SSB"
+	_, err := s.InsertQso(qso)
+	require.NoError(t, err)
+
+	dupe, err := s.IsContestDuplicateInContest(lb, "CQ-WW-SSB", "DL1ABC", "20m")
+	require.NoError(t, err)
+	assert.True(t, dupe)
+
+	dupe, err = s.IsContestDuplicateInContest(lb, "CQ-WPX-SSB", "DL1ABC", "20m")
+	require.NoError(t, err)
+	assert.False(t, dupe, "a QSO in another contest is not a duplicate")
+
+	dupe, err = s.IsContestDuplicateInContest(lb, "CQ-WW-SSB", "DL1ABC", "40m")
+	require.NoError(t, err)
+	assert.False(t, dupe)
+
+	_, err = s.IsContestDuplicateInContest(lb, " ", "DL1ABC", "20m")
+	assert.Error(t, err)
+
+	// The logbook-wide check ignores the contest.
+	dupe, err = s.IsContestDuplicateByLogbookID(lb, "DL1ABC", "20m")
+	require.NoError(t, err)
+	assert.True(t, dupe)
+	dupe, err = s.IsContestDuplicateByLogbookID(lb, "DL1ABC", "40m")
+	require.NoError(t, err)
+	assert.False(t, dupe)
+}
+
+func TestDuplicatePolicy(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+
+	existing, err := s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1430"))
+	require.NoError(t, err)
+
+	// Allow (the default) does not look.
+	id, err := s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1430"))
+	require.NoError(t, err)
+	assert.NotZero(t, id)
+
+	require.NoError(t, s.SetDuplicatePolicy(DuplicateReject, 10*time.Minute))
+	id, err = s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1435"))
+	var dup *DuplicateQsoError
+	require.ErrorAs(t, err, &dup)
+	assert.ErrorIs(t, err, ErrDuplicateQso)
+	assert.Equal(t, DuplicateQsoError{ExistingID: existing}, *dup)
+	assert.Zero(t, id)
+
+	require.NoError(t, s.SetDuplicatePolicy(DuplicateWarn, 10*time.Minute))
+	result, err := s.InsertQsoChecked(testQso(lb, sess, "DL1ABC", "20250107", "1435"))
+	require.NoError(t, err)
+	assert.NotZero(t, result.ID)
+	assert.Equal(t, existing, result.DuplicateOf)
+
+	// A warned insert is an ordinary success inside a transaction, too.
+	err = s.WithTx(context.Background(), func(tx *Tx) error {
+		r, er := tx.InsertQsoChecked(testQso(lb, sess, "DL1ABC", "20250107", "1436"))
+		assert.Equal(t, existing, r.DuplicateOf)
+		return er
+	})
+	require.NoError(t, err)
+
+	count, err := s.FetchQsoCountByLogbookId(lb)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, count, "warned QSOs are committed")
+
+	result, err = s.InsertQsoChecked(testQso(lb, sess, "DL2ABC", "20250107", "1435"))
+	require.NoError(t, err)
+	assert.Zero(t, result.DuplicateOf)
+
+	// Another band or mode is not a duplicate.
+	other := testQso(lb, sess, "DL1ABC", "20250107", "1435")
+	other.Band = "40m"
+	other.Freq = "7100"
+	_, err = s.InsertQso(other)
+	assert.NoError(t, err)
+}
+
+func TestDuplicateWindow(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	_, err := s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "2355"))
+	require.NoError(t, err)
+	require.NoError(t, s.SetDuplicatePolicy(DuplicateReject, 10*time.Minute))
+
+	for _, tc := range []struct {
+		date, timeOn string
+		duplicate    bool
+	}{
+		{"20250107", "2345", true}, // Both ends of the window count
+		{"20250107", "2344", false},
+		{"20250108", "0005", true}, // Across midnight
+		{"20250108", "0006", false},
+	} {
+		_, err = s.InsertQso(testQso(lb, sess, "DL1ABC", tc.date, tc.timeOn))
+		if tc.duplicate {
+			assert.ErrorIs(t, err, ErrDuplicateQso, tc.date+tc.timeOn)
+		} else {
+			assert.NoError(t, err, tc.date+tc.timeOn)
+		}
+	}
+
+	// A zero window only matches the same minute.
+	require.NoError(t, s.SetDuplicatePolicy(DuplicateReject, 0))
+	_, err = s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "2355"))
+	assert.ErrorIs(t, err, ErrDuplicateQso)
+	_, err = s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "2356"))
+	assert.NoError(t, err)
+}
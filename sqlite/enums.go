@@ -1,5 +1,7 @@
 package sqlite
 
+import "github.com/Station-Manager/enums/upload/status"
+
 type Ordering string
 
 const (
@@ -18,3 +20,270 @@ var OrderingNames = []struct {
 func (o Ordering) String() string {
 	return string(o)
 }
+
+// CallMatch controls how QsoFilter.Call is compared against the logged callsign.
+type CallMatch string
+
+const (
+	CallMatchExact    CallMatch = "exact"
+	CallMatchPrefix   CallMatch = "prefix"
+	CallMatchWildcard CallMatch = "wildcard" // '*' matches any run of characters, '?' a single character
+)
+
+var CallMatchNames = []struct {
+	Value  CallMatch
+	TSName string
+}{
+	{Value: CallMatchExact, TSName: "EXACT"},
+	{Value: CallMatchPrefix, TSName: "PREFIX"},
+	{Value: CallMatchWildcard, TSName: "WILDCARD"},
+}
+
+func (c CallMatch) String() string {
+	return string(c)
+}
+
+// QsoSortField identifies a column (or column pair) that QSO searches can be sorted by.
+type QsoSortField string
+
+const (
+	QsoSortID        QsoSortField = "id"
+	QsoSortDateTime  QsoSortField = "datetime" // qso_date, then time_on
+	QsoSortCall      QsoSortField = "call"
+	QsoSortBand      QsoSortField = "band"
+	QsoSortMode      QsoSortField = "mode"
+	QsoSortFreq      QsoSortField = "freq"
+	QsoSortCountry   QsoSortField = "country"
+	QsoSortCreatedAt QsoSortField = "created_at"
+)
+
+var QsoSortFieldNames = []struct {
+	Value  QsoSortField
+	TSName string
+}{
+	{Value: QsoSortID, TSName: "ID"},
+	{Value: QsoSortDateTime, TSName: "DATETIME"},
+	{Value: QsoSortCall, TSName: "CALL"},
+	{Value: QsoSortBand, TSName: "BAND"},
+	{Value: QsoSortMode, TSName: "MODE"},
+	{Value: QsoSortFreq, TSName: "FREQ"},
+	{Value: QsoSortCountry, TSName: "COUNTRY"},
+	{Value: QsoSortCreatedAt, TSName: "CREATED_AT"},
+}
+
+func (f QsoSortField) String() string {
+	return string(f)
+}
+
+// BatchErrorPolicy decides what InsertQsoBatchWithContext does when a QSO in the batch cannot be inserted.
+type BatchErrorPolicy string
+
+const (
+	// BatchStopOnFirstError rolls back the whole batch as soon as one QSO fails.
+	BatchStopOnFirstError BatchErrorPolicy = "stop"
+	// BatchSkipBadRows inserts every QSO that can be inserted and reports the ones that could not.
+	BatchSkipBadRows BatchErrorPolicy = "skip"
+)
+
+var BatchErrorPolicyNames = []struct {
+	Value  BatchErrorPolicy
+	TSName string
+}{
+	{Value: BatchStopOnFirstError, TSName: "STOP"},
+	{Value: BatchSkipBadRows, TSName: "SKIP"},
+}
+
+func (p BatchErrorPolicy) String() string {
+	return string(p)
+}
+
+// DuplicatePolicy controls what InsertQso does when a matching QSO already exists (see Service.SetDuplicatePolicy).
+type DuplicatePolicy string
+
+const (
+	DuplicateAllow  DuplicatePolicy = "allow"
+	DuplicateWarn   DuplicatePolicy = "warn" // Insert anyway, but log the existing QSO
+	DuplicateReject DuplicatePolicy = "reject"
+)
+
+var DuplicatePolicyNames = []struct {
+	Value  DuplicatePolicy
+	TSName string
+}{
+	{Value: DuplicateAllow, TSName: "ALLOW"},
+	{Value: DuplicateWarn, TSName: "WARN"},
+	{Value: DuplicateReject, TSName: "REJECT"},
+}
+
+func (d DuplicatePolicy) String() string {
+	return string(d)
+}
+
+// ContactedStationSync controls how inserted and updated QSOs are merged into the contacted station for their call
+// (see Service.SetContactedStationSync).
+type ContactedStationSync string
+
+const (
+	ContactedStationSyncOff       ContactedStationSync = "off"
+	ContactedStationSyncFillEmpty ContactedStationSync = "fill_empty" // QSOs only fill fields that are empty
+	ContactedStationSyncNewest    ContactedStationSync = "newest"     // Non-empty values from the newest QSO win
+)
+
+var ContactedStationSyncNames = []struct {
+	Value  ContactedStationSync
+	TSName string
+}{
+	{Value: ContactedStationSyncOff, TSName: "OFF"},
+	{Value: ContactedStationSyncFillEmpty, TSName: "FILL_EMPTY"},
+	{Value: ContactedStationSyncNewest, TSName: "NEWEST"},
+}
+
+func (c ContactedStationSync) String() string {
+	return string(c)
+}
+
+// SearchMatch controls how ContactedStationFilter.Query is compared against the searched fields.
+type SearchMatch string
+
+const (
+	SearchMatchPrefix    SearchMatch = "prefix"
+	SearchMatchSubstring SearchMatch = "substring"
+)
+
+var SearchMatchNames = []struct {
+	Value  SearchMatch
+	TSName string
+}{
+	{Value: SearchMatchPrefix, TSName: "PREFIX"},
+	{Value: SearchMatchSubstring, TSName: "SUBSTRING"},
+}
+
+func (m SearchMatch) String() string {
+	return string(m)
+}
+
+// CtyFormat is the layout of a country file (see Service.ImportCountriesCty).
+type CtyFormat string
+
+const (
+	CtyFormatAuto CtyFormat = "auto" // Work it out from the first record
+	CtyFormatDat  CtyFormat = "dat"  // cty.dat: colon-separated fields, prefixes on the following lines
+	CtyFormatCSV  CtyFormat = "csv"  // cty.csv: one entity per line, with its ADIF DXCC entity code
+)
+
+var CtyFormatNames = []struct {
+	Value  CtyFormat
+	TSName string
+}{
+	{Value: CtyFormatAuto, TSName: "AUTO"},
+	{Value: CtyFormatDat, TSName: "DAT"},
+	{Value: CtyFormatCSV, TSName: "CSV"},
+}
+
+func (c CtyFormat) String() string {
+	return string(c)
+}
+
+// QslChannel is a route by which QSL confirmations are exchanged.
+type QslChannel string
+
+const (
+	QslChannelBureau QslChannel = "bureau" // Paper card via the QSL bureau
+	QslChannelDirect QslChannel = "direct" // Paper card sent directly
+	QslChannelLotw   QslChannel = "lotw"
+	QslChannelEqsl   QslChannel = "eqsl"
+	QslChannelQrz    QslChannel = "qrz"
+)
+
+var QslChannelNames = []struct {
+	Value  QslChannel
+	TSName string
+}{
+	{Value: QslChannelBureau, TSName: "BUREAU"},
+	{Value: QslChannelDirect, TSName: "DIRECT"},
+	{Value: QslChannelLotw, TSName: "LOTW"},
+	{Value: QslChannelEqsl, TSName: "EQSL"},
+	{Value: QslChannelQrz, TSName: "QRZ"},
+}
+
+func (c QslChannel) String() string {
+	return string(c)
+}
+
+// QslSentStatus uses the ADIF QSL_SENT values.
+type QslSentStatus string
+
+const (
+	QslSentYes       QslSentStatus = "Y"
+	QslSentNo        QslSentStatus = "N"
+	QslSentRequested QslSentStatus = "R" // The other station asked for a QSL
+	QslSentQueued    QslSentStatus = "Q"
+	QslSentIgnore    QslSentStatus = "I"
+)
+
+var QslSentStatusNames = []struct {
+	Value  QslSentStatus
+	TSName string
+}{
+	{Value: QslSentYes, TSName: "YES"},
+	{Value: QslSentNo, TSName: "NO"},
+	{Value: QslSentRequested, TSName: "REQUESTED"},
+	{Value: QslSentQueued, TSName: "QUEUED"},
+	{Value: QslSentIgnore, TSName: "IGNORE"},
+}
+
+func (q QslSentStatus) String() string {
+	return string(q)
+}
+
+// QslRcvdStatus uses the ADIF QSL_RCVD values.
+type QslRcvdStatus string
+
+const (
+	QslRcvdYes       QslRcvdStatus = "Y"
+	QslRcvdNo        QslRcvdStatus = "N"
+	QslRcvdRequested QslRcvdStatus = "R" // We asked the other station for a QSL
+	QslRcvdIgnore    QslRcvdStatus = "I"
+	QslRcvdVerified  QslRcvdStatus = "V"
+)
+
+var QslRcvdStatusNames = []struct {
+	Value  QslRcvdStatus
+	TSName string
+}{
+	{Value: QslRcvdYes, TSName: "YES"},
+	{Value: QslRcvdNo, TSName: "NO"},
+	{Value: QslRcvdRequested, TSName: "REQUESTED"},
+	{Value: QslRcvdIgnore, TSName: "IGNORE"},
+	{Value: QslRcvdVerified, TSName: "VERIFIED"},
+}
+
+func (q QslRcvdStatus) String() string {
+	return string(q)
+}
+
+// ImportDuplicatePolicy controls what an ADIF import does with a record that matches an existing QSO.
+type ImportDuplicatePolicy string
+
+const (
+	ImportSkipDuplicates   ImportDuplicatePolicy = "skip"
+	ImportUpdateDuplicates ImportDuplicatePolicy = "update" // Merge the record into the existing QSO
+	ImportInsertDuplicates ImportDuplicatePolicy = "insert" // Insert every record, even if it matches a QSO
+)
+
+var ImportDuplicatePolicyNames = []struct {
+	Value  ImportDuplicatePolicy
+	TSName string
+}{
+	{Value: ImportSkipDuplicates, TSName: "SKIP"},
+	{Value: ImportUpdateDuplicates, TSName: "UPDATE"},
+	{Value: ImportInsertDuplicates, TSName: "INSERT"},
+}
+
+func (i ImportDuplicatePolicy) String() string {
+	return string(i)
+}
+
+// UploadStatusDead is the status of a qso_upload row that ran out of attempts. It extends the statuses in
+// github.com/Station-Manager/enums/upload/status; dead uploads are only retried once requeued.
+const UploadStatusDead status.Status = "dead"
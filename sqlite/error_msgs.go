@@ -2,4 +2,5 @@ package sqlite
 
 var (
 	errMsgEmptyCallsign = "Callsign cannot be empty."
+	errMsgInvalidCursor = "Invalid or corrupt paging cursor."
 )
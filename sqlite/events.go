@@ -0,0 +1,152 @@
+package sqlite
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/Station-Manager/enums/upload/action"
+	"github.com/Station-Manager/enums/upload/status"
+	"github.com/Station-Manager/errors"
+)
+
+// defaultChangeBuffer is the number of events a subscription holds for its reader when ChangeFilter.Buffer is not
+// set.
+const defaultChangeBuffer = 256
+
+// ChangeEntity names the kind of row a ChangeEvent is about.
+type ChangeEntity string
+
+const (
+	ChangeQso              ChangeEntity = "qso"
+	ChangeLogbook          ChangeEntity = "logbook"
+	ChangeContactedStation ChangeEntity = "contacted_station"
+	ChangeUpload           ChangeEntity = "qso_upload"
+)
+
+// ChangeEvent reports a committed change. Action is what happened to the row: an upload that is queued (or queued
+// again) is an insert, a status change an update and a cancelled or purged upload a delete; a restored QSO is an
+// update. Changes to many rows at once, whose IDs are not known, are reported as one event with a zero ID.
+type ChangeEvent struct {
+	Entity ChangeEntity  `json:"entity"`
+	Action action.Action `json:"action"`
+	ID     int64         `json:"id"`
+
+	LogbookID int64         `json:"logbook_id,omitempty"` // QSO and logbook events
+	QsoID     int64         `json:"qso_id,omitempty"`     // Upload events
+	Service   string        `json:"service,omitempty"`    // Upload events
+	Status    status.Status `json:"status,omitempty"`     // Upload events: the new status
+
+	// Dropped is the number of events this subscriber missed, because its buffer was full, just before this one.
+	// Anything cached from earlier events should be re-read when it is not zero.
+	Dropped int64 `json:"dropped,omitempty"`
+}
+
+// ChangeFilter selects the events a subscription receives. Zero-valued fields select everything.
+type ChangeFilter struct {
+	Entities []ChangeEntity `json:"entities"`
+	// LogbookID drops QSO and logbook events of other logbooks; events not tied to a logbook are kept.
+	LogbookID int64 `json:"logbook_id"`
+	// Buffer is the number of events held for a slow reader before further events are dropped; default 256.
+	Buffer int `json:"buffer"`
+}
+
+func (f ChangeFilter) matches(ev ChangeEvent) bool {
+	if len(f.Entities) > 0 && !slices.Contains(f.Entities, ev.Entity) {
+		return false
+	}
+	if f.LogbookID > 0 && ev.LogbookID > 0 && ev.LogbookID != f.LogbookID {
+		return false
+	}
+	return true
+}
+
+// changeHub fans committed changes out to the subscriptions.
+type changeHub struct {
+	// commitMu is held from a transaction's commit until its events are published, so subscribers see them in
+	// commit order.
+	commitMu sync.Mutex
+	mu       sync.Mutex
+	subs     map[*subscription]struct{}
+}
+
+type subscription struct {
+	ch      chan ChangeEvent
+	filter  ChangeFilter
+	dropped int64
+}
+
+// Subscribe returns a channel on which the changes selected by filter are delivered once their transaction has
+// committed, in commit order. Publishing never waits for a subscriber: when its buffer is full, events are dropped
+// and the next one delivered says how many (see ChangeEvent.Dropped). The channel is closed when ctx is done.
+func (s *Service) Subscribe(ctx context.Context, filter ChangeFilter) (<-chan ChangeEvent, error) {
+	const op errors.Op = "sqlite.Service.Subscribe"
+	if err := checkService(op, s); err != nil {
+		return nil, err
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if filter.Buffer < 1 {
+		filter.Buffer = defaultChangeBuffer
+	}
+
+	sub := &subscription{ch: make(chan ChangeEvent, filter.Buffer), filter: filter}
+
+	s.changes.mu.Lock()
+	if s.changes.subs == nil {
+		s.changes.subs = map[*subscription]struct{}{}
+	}
+	s.changes.subs[sub] = struct{}{}
+	s.changes.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.changes.mu.Lock()
+		delete(s.changes.subs, sub)
+		close(sub.ch)
+		s.changes.mu.Unlock()
+	}()
+
+	return sub.ch, nil
+}
+
+// publishChanges delivers committed changes to the subscriptions.
+func (s *Service) publishChanges(events []ChangeEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	s.changes.mu.Lock()
+	defer s.changes.mu.Unlock()
+
+	for sub := range s.changes.subs {
+		for _, ev := range events {
+			if !sub.filter.matches(ev) {
+				continue
+			}
+			ev.Dropped = sub.dropped
+			select {
+			case sub.ch <- ev:
+				sub.dropped = 0
+			default:
+				if sub.dropped == 0 {
+					s.LoggerService.WarnWith().Int("buffer", sub.filter.Buffer).Msg("Change subscriber is not keeping up; dropping events.")
+				}
+				sub.dropped++
+			}
+		}
+	}
+}
+
+// publish queues events to be delivered once the transaction commits. Events of a savepoint that is rolled back are
+// discarded with it.
+func (t *Tx) publish(events ...ChangeEvent) {
+	t.events = append(t.events, events...)
+}
+
+// uploadQueued is the event for an upload that was queued, or queued again.
+func uploadQueued(id, qsoID int64, service string) ChangeEvent {
+	return ChangeEvent{Entity: ChangeUpload, Action: action.Insert, ID: id, QsoID: qsoID, Service: service, Status: status.Pending}
+}
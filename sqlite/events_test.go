@@ -0,0 +1,45 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Station-Manager/enums/upload/action"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeFilterMatches(t *testing.T) {
+	qso := ChangeEvent{Entity: ChangeQso, Action: action.Insert, ID: 1, LogbookID: 2}
+	upload := ChangeEvent{Entity: ChangeUpload, Action: action.Update, ID: 3, QsoID: 1}
+
+	assert.True(t, ChangeFilter{}.matches(qso))
+	assert.True(t, ChangeFilter{Entities: []ChangeEntity{ChangeQso}}.matches(qso))
+	assert.False(t, ChangeFilter{Entities: []ChangeEntity{ChangeQso}}.matches(upload))
+
+	assert.True(t, ChangeFilter{LogbookID: 2}.matches(qso))
+	assert.False(t, ChangeFilter{LogbookID: 5}.matches(qso))
+	assert.True(t, ChangeFilter{LogbookID: 5}.matches(upload))
+}
+
+func TestSubscribe(t *testing.T) {
+	_, err := (&Service{}).Subscribe(context.Background(), ChangeFilter{})
+	require.Error(t, err)
+
+	s := newTestService(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	logbooks, err := s.Subscribe(ctx, ChangeFilter{Entities: []ChangeEntity{ChangeLogbook}})
+	require.NoError(t, err)
+	s.publishChanges([]ChangeEvent{
+		{Entity: ChangeQso, Action: action.Insert, ID: 1},
+		{Entity: ChangeLogbook, Action: action.Delete, ID: 7, LogbookID: 7},
+	})
+
+	ev := <-logbooks
+	assert.Equal(t, ChangeEvent{Entity: ChangeLogbook, Action: action.Delete, ID: 7, LogbookID: 7}, ev)
+
+	cancel()
+	_, ok := <-logbooks
+	require.False(t, ok)
+}
@@ -0,0 +1,210 @@
+package sqlite
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+)
+
+// QsoFilter describes which QSOs a search should return. Zero-valued fields are ignored, so an empty filter
+// matches every active QSO.
+type QsoFilter struct {
+	LogbookID int64 `json:"logbook_id"`
+	SessionID int64 `json:"session_id"`
+
+	Call      string    `json:"call"`
+	CallMatch CallMatch `json:"call_match"` // Defaults to CallMatchExact
+
+	Bands   []string `json:"bands"`
+	Modes   []string `json:"modes"`
+	Country string   `json:"country"`
+
+	// From and To bound the QSO start (qso_date + time_on, UTC). Both ends are inclusive, to the minute.
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	// The following live in the additional_data JSON column. All but Sig are indexed generated columns.
+	ContestID  string `json:"contest_id"`
+	Gridsquare string `json:"gridsquare"` // Prefix match, so "FN42" also matches "FN42ab"
+	Sig        string `json:"sig"`
+	SigInfo    string `json:"sig_info"`
+}
+
+// Page selects a window of search results. Number is 1-based.
+type Page struct {
+	Number int64     `json:"number"`
+	Size   int64     `json:"size"`
+	Sort   []QsoSort `json:"sort"` // Defaults to newest QSO first
+}
+
+// QsoSort is a single sort key. The QSO ID is always appended as a final tie-breaker so paging is stable.
+type QsoSort struct {
+	Field QsoSortField `json:"field"`
+	Order Ordering     `json:"order"`
+}
+
+// QsoSearchResult holds one page of QSOs along with the total number of QSOs matching the filter.
+type QsoSearchResult struct {
+	Qsos  types.QsoSlice `json:"qsos"`
+	Total int64          `json:"total"`
+}
+
+var qsoSortColumns = map[QsoSortField][]string{
+	QsoSortID:        {models.QsoColumns.ID},
+	QsoSortDateTime:  {models.QsoColumns.QsoDate, models.QsoColumns.TimeOn},
+	QsoSortCall:      {models.QsoColumns.Call},
+	QsoSortBand:      {models.QsoColumns.Band},
+	QsoSortMode:      {models.QsoColumns.Mode},
+	QsoSortFreq:      {models.QsoColumns.Freq},
+	QsoSortCountry:   {models.QsoColumns.Country},
+	QsoSortCreatedAt: {models.QsoColumns.CreatedAt},
+}
+
+var defaultQsoSort = []QsoSort{{Field: QsoSortDateTime, Order: Descending}}
+
+// whereMods converts the filter into sqlboiler query mods.
+func (f QsoFilter) whereMods() ([]qm.QueryMod, error) {
+	const op errors.Op = "sqlite.QsoFilter.whereMods"
+
+	var mods []qm.QueryMod
+
+	if f.LogbookID < 0 || f.SessionID < 0 {
+		return nil, errors.New(op).Msg(errMsgInvalidId)
+	}
+	if f.LogbookID > 0 {
+		mods = append(mods, models.QsoWhere.LogbookID.EQ(f.LogbookID))
+	}
+	if f.SessionID > 0 {
+		mods = append(mods, models.QsoWhere.SessionID.EQ(f.SessionID))
+	}
+
+	if call := strings.TrimSpace(f.Call); call != emptyString {
+		switch f.CallMatch {
+		case emptyString, CallMatchExact:
+			mods = append(mods, models.QsoWhere.Call.EQ(call))
+		case CallMatchPrefix:
+			mods = append(mods, qm.Where(`"qso"."call" LIKE ? ESCAPE '\'`, escapeLike(call)+"%"))
+		case CallMatchWildcard:
+			mods = append(mods, qm.Where(`"qso"."call" LIKE ? ESCAPE '\'`, wildcardToLike(call)))
+		default:
+			return nil, errors.New(op).Msgf("Unknown call match: %q", f.CallMatch)
+		}
+	}
+
+	if bands := trimmedNonEmpty(f.Bands); len(bands) > 0 {
+		mods = append(mods, models.QsoWhere.Band.IN(bands))
+	}
+	if modes := trimmedNonEmpty(f.Modes); len(modes) > 0 {
+		mods = append(mods, models.QsoWhere.Mode.IN(modes))
+	}
+	if country := strings.TrimSpace(f.Country); country != emptyString {
+		mods = append(mods, models.QsoWhere.Country.EQ(country))
+	}
+
+	if !f.From.IsZero() && !f.To.IsZero() && f.To.Before(f.From) {
+		return nil, errors.New(op).Msg("Invalid date range. 'To' is before 'From'.")
+	}
+	if !f.From.IsZero() {
+		d, t := qsoDateTime(f.From)
+		mods = append(mods, qm.Where(`("qso"."qso_date" > ? OR ("qso"."qso_date" = ? AND "qso"."time_on" >= ?))`, d, d, t))
+	}
+	if !f.To.IsZero() {
+		d, t := qsoDateTime(f.To)
+		mods = append(mods, qm.Where(`("qso"."qso_date" < ? OR ("qso"."qso_date" = ? AND "qso"."time_on" <= ?))`, d, d, t))
+	}
+
+	if v := strings.TrimSpace(f.ContestID); v != emptyString {
+		mods = append(mods, models.QsoWhere.ContestID.EQ(null.StringFrom(v)))
+	}
+	if v := strings.TrimSpace(f.Gridsquare); v != emptyString {
+		mods = append(mods, qm.Where(`"qso"."gridsquare" LIKE ? ESCAPE '\'`, escapeLike(v)+"%"))
+	}
+	if v := strings.TrimSpace(f.Sig); v != emptyString {
+		mods = append(mods, qm.Where(jsonExtractQso(jsonKeySig)+" = ?", v))
+	}
+	if v := strings.TrimSpace(f.SigInfo); v != emptyString {
+		mods = append(mods, models.QsoWhere.SigInfo.EQ(null.StringFrom(v)))
+	}
+
+	return mods, nil
+}
+
+// qsoOrderBy builds the ORDER BY clause for the given sort keys.
+func qsoOrderBy(sorts []QsoSort) (string, error) {
+	const op errors.Op = "sqlite.qsoOrderBy"
+
+	if len(sorts) == 0 {
+		sorts = defaultQsoSort
+	}
+
+	clauses := make([]string, 0, len(sorts)+1)
+	hasID := false
+	for _, srt := range sorts {
+		cols, ok := qsoSortColumns[srt.Field]
+		if !ok {
+			return emptyString, errors.New(op).Msgf("Unknown sort field: %q", srt.Field)
+		}
+		order := srt.Order
+		if order == emptyString {
+			order = Ascending
+		}
+		if order != Ascending && order != Descending {
+			return emptyString, errors.New(op).Msgf("Unknown sort order: %q", srt.Order)
+		}
+		for _, col := range cols {
+			clauses = append(clauses, `"qso"."`+col+`" `+order.String())
+		}
+		if srt.Field == QsoSortID {
+			hasID = true
+		}
+	}
+
+	if !hasID {
+		clauses = append(clauses, `"qso"."id" `+sorts[len(sorts)-1].orderOrDefault().String())
+	}
+
+	return strings.Join(clauses, ", "), nil
+}
+
+func (q QsoSort) orderOrDefault() Ordering {
+	if q.Order == emptyString {
+		return Ascending
+	}
+	return q.Order
+}
+
+// qsoDateTime splits a time into the qso_date (YYYYMMDD) and time_on (HHMM) column formats.
+func qsoDateTime(t time.Time) (string, string) {
+	t = t.UTC()
+	return t.Format("20060102"), t.Format("1504")
+}
+
+// jsonExtractQso returns an expression extracting the given key from qso.additional_data.
+func jsonExtractQso(key string) string {
+	return `json_extract("qso"."additional_data", '$.` + key + `')`
+}
+
+// escapeLike escapes the LIKE metacharacters in s, using '\' as the escape character.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// wildcardToLike converts a '*'/'?' wildcard pattern into a LIKE pattern.
+func wildcardToLike(s string) string {
+	return strings.NewReplacer("*", "%", "?", "_").Replace(escapeLike(s))
+}
+
+func trimmedNonEmpty(in []string) []string {
+	out := make([]string, 0, len(in))
+	for _, v := range in {
+		if v = strings.TrimSpace(v); v != emptyString {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Station-Manager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWildcardToLike(t *testing.T) {
+	assert.Equal(t, "DL%", wildcardToLike("DL*"))
+	assert.Equal(t, "DL1AB_", wildcardToLike("DL1AB?"))
+	assert.Equal(t, `K1\_X%`, wildcardToLike("K1_X*"))
+	assert.Equal(t, `100\%`, escapeLike("100%"))
+}
+
+func TestQsoOrderBy_Default(t *testing.T) {
+	clause, err := qsoOrderBy(nil)
+	require.NoError(t, err)
+	assert.Equal(t, `"qso"."qso_date" DESC, "qso"."time_on" DESC, "qso"."id" DESC`, clause)
+}
+
+func TestQsoOrderBy_MultipleKeys(t *testing.T) {
+	clause, err := qsoOrderBy([]QsoSort{{Field: QsoSortBand}, {Field: QsoSortCall, Order: Descending}})
+	require.NoError(t, err)
+	assert.Equal(t, `"qso"."band" ASC, "qso"."call" DESC, "qso"."id" DESC`, clause)
+}
+
+func TestQsoOrderBy_Invalid(t *testing.T) {
+	_, err := qsoOrderBy([]QsoSort{{Field: "rowid; DROP TABLE qso"}})
+	assert.Error(t, err)
+
+	_, err = qsoOrderBy([]QsoSort{{Field: QsoSortCall, Order: "SIDEWAYS"}})
+	assert.Error(t, err)
+}
+
+func TestSearchQsos(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	other, err := s.InsertLogbook(types.Logbook{Name: "Other", Callsign: "W1AW"})
+	require.NoError(t, err)
+
+	insert := func(q types.Qso) int64 {
+		t.Helper()
+		id, er := s.InsertQso(q)
+		require.NoError(t, er)
+		return id
+	}
+	cw := testQso(lb, sess, "K1AX", "20250108", "0000")
+	cw.Band, cw.Mode, cw.Freq = "40m", "CW", "7025"
+	cw.ContactedStation.Gridsquare = "FN42ab"
+	insert(testQso(lb, sess, "K1_X", "20250107", "2359"))
+	insert(cw)
+	insert(testQso(lb, sess, "DL1ABC", "20250108", "0001"))
+	insert(testQso(lb, sess, "DL1ABD", "20250108", "1200"))
+	require.NoError(t, s.DeleteQso(insert(testQso(lb, sess, "DL9ZZZ", "20250108", "0002"))))
+	insert(testQso(other, sess, "DL1ABC", "20250108", "0003"))
+
+	search := func(filter QsoFilter, page Page) QsoSearchResult {
+		t.Helper()
+		if page.Number == 0 {
+			page.Number, page.Size = 1, 10
+		}
+		result, er := s.SearchQsos(filter, page)
+		require.NoError(t, er)
+		return result
+	}
+	calls := func(result QsoSearchResult) []string {
+		out := make([]string, len(result.Qsos))
+		for i, q := range result.Qsos {
+			out[i] = q.Call
+		}
+		return out
+	}
+
+	// Deleted QSOs and other logbooks are left out; newest first by default.
+	result := search(QsoFilter{LogbookID: lb}, Page{})
+	assert.EqualValues(t, 4, result.Total)
+	assert.Equal(t, []string{"DL1ABD", "DL1ABC", "K1AX", "K1_X"}, calls(result))
+	assert.EqualValues(t, 2, search(QsoFilter{Call: "DL1ABC"}, Page{}).Total)
+
+	// Both ends of the range are inclusive, to the minute, and compared in UTC.
+	from := time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 8, 0, 1, 59, 0, time.UTC)
+	assert.Equal(t, []string{"DL1ABC", "K1AX"}, calls(search(QsoFilter{LogbookID: lb, From: from, To: to}, Page{})))
+	local := time.FixedZone("UTC+1", 3600)
+	assert.Equal(t, []string{"DL1ABC", "K1AX"},
+		calls(search(QsoFilter{LogbookID: lb, From: from.In(local), To: to.In(local)}, Page{})))
+	_, err = s.SearchQsos(QsoFilter{From: to, To: from}, Page{Number: 1, Size: 10})
+	assert.Error(t, err)
+
+	// LIKE metacharacters in the input are matched literally.
+	assert.Equal(t, []string{"K1_X"}, calls(search(QsoFilter{LogbookID: lb, Call: "K1_", CallMatch: CallMatchPrefix}, Page{})))
+	assert.Equal(t, []string{"K1_X"}, calls(search(QsoFilter{LogbookID: lb, Call: "K1_*", CallMatch: CallMatchWildcard}, Page{})))
+	assert.Equal(t, []string{"K1AX", "K1_X"}, calls(search(QsoFilter{LogbookID: lb, Call: "K1?X", CallMatch: CallMatchWildcard}, Page{})))
+	assert.Empty(t, calls(search(QsoFilter{LogbookID: lb, Call: "DL%", CallMatch: CallMatchPrefix}, Page{})))
+	assert.Equal(t, []string{"K1AX"}, calls(search(QsoFilter{Gridsquare: "FN42"}, Page{})))
+	assert.Empty(t, calls(search(QsoFilter{Gridsquare: "FN4_"}, Page{})))
+
+	assert.Equal(t, []string{"K1AX"}, calls(search(QsoFilter{LogbookID: lb, Bands: []string{" 40m "}, Modes: []string{"CW"}}, Page{})))
+
+	// The total counts every match, whatever the page.
+	byCall := []QsoSort{{Field: QsoSortCall}}
+	result = search(QsoFilter{LogbookID: lb}, Page{Number: 2, Size: 3, Sort: byCall})
+	assert.EqualValues(t, 4, result.Total)
+	assert.Equal(t, []string{"K1_X"}, calls(result))
+	result = search(QsoFilter{LogbookID: lb}, Page{Number: 3, Size: 3, Sort: byCall})
+	assert.EqualValues(t, 4, result.Total)
+	assert.Empty(t, result.Qsos)
+	_, err = s.SearchQsos(QsoFilter{}, Page{Number: 0, Size: 10})
+	assert.Error(t, err)
+}
@@ -0,0 +1,237 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	stderr "errors"
+	"strings"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/enums/upload"
+	"github.com/Station-Manager/enums/upload/action"
+	"github.com/Station-Manager/enums/upload/status"
+	"github.com/Station-Manager/errors"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/goccy/go-json"
+)
+
+// ForwardingRule says whether, and which of, a logbook's QSOs are uploaded to an online service. Inserting or
+// updating a QSO queues an upload for every enabled rule it passes.
+type ForwardingRule struct {
+	ID        int64                `json:"id"`
+	LogbookID int64                `json:"logbook_id"`
+	Service   upload.OnlineService `json:"service"`
+	Enabled   bool                 `json:"enabled"`
+
+	OnlyComplete   bool     `json:"only_complete"`   // Skip QSOs whose qso_complete is set to anything but Y
+	Modes          []string `json:"modes"`           // Forward only these modes; empty forwards all
+	ExcludeContest bool     `json:"exclude_contest"` // Skip QSOs that have a contest_id
+}
+
+// forwardingFields are the additional_data fields the rule filters look at.
+type forwardingFields struct {
+	ContestID   string `json:"contest_id"`
+	QsoComplete string `json:"qso_complete"`
+}
+
+func forwardingRuleFromModel(m *models.ForwardingRule) (ForwardingRule, error) {
+	rule := ForwardingRule{
+		ID:             m.ID,
+		LogbookID:      m.LogbookID,
+		Service:        upload.OnlineService(m.Service),
+		Enabled:        m.Enabled,
+		OnlyComplete:   m.OnlyComplete,
+		ExcludeContest: m.ExcludeContest,
+	}
+	if err := m.Modes.Unmarshal(&rule.Modes); err != nil {
+		return ForwardingRule{}, err
+	}
+	return rule, nil
+}
+
+// matches reports whether the QSO passes the rule's filters.
+func (r ForwardingRule) matches(model *models.Qso, fields forwardingFields) bool {
+	if r.OnlyComplete && fields.QsoComplete != emptyString && !strings.EqualFold(fields.QsoComplete, "Y") {
+		return false
+	}
+	if r.ExcludeContest && strings.TrimSpace(fields.ContestID) != emptyString {
+		return false
+	}
+	if len(r.Modes) == 0 {
+		return true
+	}
+	for _, mode := range r.Modes {
+		if strings.EqualFold(strings.TrimSpace(mode), model.Mode) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetForwardingRule stores the rule for its logbook and service, replacing any earlier rule for the pair, and
+// returns its ID. QSOs already logged are not queued; the rule applies from their next update.
+func (t *Tx) SetForwardingRule(rule ForwardingRule) (int64, error) {
+	const op errors.Op = "sqlite.Tx.SetForwardingRule"
+
+	if rule.LogbookID < 1 {
+		return 0, errors.New(op).Msg(errMsgInvalidId)
+	}
+	service := strings.TrimSpace(rule.Service.String())
+	if service == emptyString {
+		return 0, errors.New(op).Msg("Forwarding service cannot be empty.")
+	}
+
+	modes := make([]string, 0, len(rule.Modes))
+	for _, mode := range rule.Modes {
+		if mode = strings.ToUpper(strings.TrimSpace(mode)); mode != emptyString {
+			modes = append(modes, mode)
+		}
+	}
+	modesJSON, err := json.Marshal(modes)
+	if err != nil {
+		return 0, errors.New(op).Err(err)
+	}
+
+	const upsert = `
+		INSERT INTO forwarding_rule (logbook_id, service, enabled, only_complete, modes, exclude_contest)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (logbook_id, service) DO UPDATE
+		   SET enabled         = excluded.enabled,
+		       only_complete   = excluded.only_complete,
+		       modes           = excluded.modes,
+		       exclude_contest = excluded.exclude_contest
+		RETURNING id`
+
+	var id int64
+	err = t.tx.QueryRowContext(t.ctx, upsert, rule.LogbookID, service, rule.Enabled, rule.OnlyComplete,
+		string(modesJSON), rule.ExcludeContest).Scan(&id)
+	if err != nil {
+		return 0, errors.New(op).Err(err).Msgf("Failed to set forwarding rule for logbook %d.", rule.LogbookID)
+	}
+
+	return id, nil
+}
+
+// DeleteForwardingRule removes the logbook's rule for the service. Uploads already queued are kept.
+func (t *Tx) DeleteForwardingRule(logbookID int64, service upload.OnlineService) error {
+	const op errors.Op = "sqlite.Tx.DeleteForwardingRule"
+
+	if logbookID < 1 {
+		return errors.New(op).Msg(errMsgInvalidId)
+	}
+
+	n, err := models.ForwardingRules(
+		models.ForwardingRuleWhere.LogbookID.EQ(logbookID),
+		models.ForwardingRuleWhere.Service.EQ(service.String()),
+	).DeleteAll(t.ctx, t.tx)
+	if err != nil {
+		return errors.New(op).Err(err).Msg("Failed to delete forwarding rule.")
+	}
+	if n == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+// fetchForwardingRules returns the logbook's rules ordered by service, optionally only the enabled ones.
+func fetchForwardingRules(ctx context.Context, exec boil.ContextExecutor, logbookID int64, enabledOnly bool) ([]ForwardingRule, error) {
+	mods := []qm.QueryMod{
+		models.ForwardingRuleWhere.LogbookID.EQ(logbookID),
+		qm.OrderBy(models.ForwardingRuleColumns.Service),
+	}
+	if enabledOnly {
+		mods = append(mods, models.ForwardingRuleWhere.Enabled.EQ(true))
+	}
+
+	slice, err := models.ForwardingRules(mods...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]ForwardingRule, 0, len(slice))
+	for _, m := range slice {
+		rule, err := forwardingRuleFromModel(m)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// forwardQso queues the uploads the logbook's forwarding rules call for after the QSO was inserted or updated.
+//
+// Queued actions are coalesced: an update while the insert is still waiting needs nothing queued, as the insert
+// will send the QSO as it is by then. An update of a QSO a service has never been sent (for example because the
+// rule was added later, or the QSO only now passes it) queues an insert instead.
+func (t *Tx) forwardQso(model *models.Qso, act action.Action) error {
+	const op errors.Op = "sqlite.Tx.forwardQso"
+
+	if model.DeletedAt.Valid {
+		return nil
+	}
+
+	rules, err := fetchForwardingRules(t.ctx, t.tx, model.LogbookID, true)
+	if err != nil {
+		return errors.New(op).Err(err).Msg("Failed to fetch forwarding rules.")
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	var fields forwardingFields
+	if len(model.AdditionalData) > 0 {
+		if err = json.Unmarshal(model.AdditionalData, &fields); err != nil {
+			return errors.New(op).Err(err)
+		}
+	}
+
+	for _, rule := range rules {
+		if !rule.matches(model, fields) {
+			continue
+		}
+
+		queue := act
+		if act == action.Update {
+			insertStatus, er := qsoUploadStatus(t.ctx, t.tx, model.ID, action.Insert, rule.Service.String())
+			if er != nil {
+				return errors.New(op).Err(er)
+			}
+			switch insertStatus {
+			case status.Pending, status.Failed, UploadStatusDead:
+				continue // The insert, when it goes, carries this update; a dead one waits to be requeued
+			case emptyString:
+				queue = action.Insert
+			}
+		}
+
+		uploadID, queued, er := requeueQsoUpload(t.ctx, t.tx, model.ID, queue, rule.Service.String())
+		if er != nil {
+			return errors.New(op).Err(er)
+		}
+		if queued {
+			t.publish(uploadQueued(uploadID, model.ID, rule.Service.String()))
+		}
+	}
+
+	return nil
+}
+
+// qsoUploadStatus returns the status of the QSO's upload for the service and action, or "" if there is none.
+func qsoUploadStatus(ctx context.Context, exec boil.ContextExecutor, qsoID int64, act action.Action, service string) (status.Status, error) {
+	var row struct {
+		Status string `boil:"status"`
+	}
+	err := queries.Raw(`SELECT status FROM qso_upload WHERE qso_id = ? AND service = ? AND action = ?`,
+		qsoID, service, act.String()).Bind(ctx, exec, &row)
+	if err != nil {
+		if stderr.Is(err, sql.ErrNoRows) {
+			return emptyString, nil
+		}
+		return emptyString, err
+	}
+	return status.Status(row.Status), nil
+}
@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/enums/upload"
+	"github.com/Station-Manager/enums/upload/action"
+	"github.com/Station-Manager/enums/upload/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForwardingRuleMatches(t *testing.T) {
+	cw := &models.Qso{Mode: "CW"}
+	ssb := &models.Qso{Mode: "SSB"}
+
+	all := ForwardingRule{}
+	assert.True(t, all.matches(ssb, forwardingFields{ContestID: "CQ-WW-SSB", QsoComplete: "N"}))
+
+	modes := ForwardingRule{Modes: []string{"CW", "FT8"}}
+	assert.True(t, modes.matches(cw, forwardingFields{}))
+	assert.False(t, modes.matches(ssb, forwardingFields{}))
+
+	complete := ForwardingRule{OnlyComplete: true}
+	assert.True(t, complete.matches(cw, forwardingFields{}))
+	assert.True(t, complete.matches(cw, forwardingFields{QsoComplete: "y"}))
+	assert.False(t, complete.matches(cw, forwardingFields{QsoComplete: "NIL"}))
+	assert.False(t, complete.matches(cw, forwardingFields{QsoComplete: "?"}))
+
+	noContest := ForwardingRule{ExcludeContest: true}
+	assert.True(t, noContest.matches(cw, forwardingFields{ContestID: " "}))
+	assert.False(t, noContest.matches(cw, forwardingFields{ContestID: "CQ-WW-CW"}))
+}
+
+func TestForwardQsoRequeuesInProgressUpload(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	_, err := s.SetForwardingRule(ForwardingRule{LogbookID: lb, Service: upload.OnlineServiceQRZ, Enabled: true})
+	require.NoError(t, err)
+
+	id, err := s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1430"))
+	require.NoError(t, err)
+	uploads, err := s.FetchPendingUploadsForService(upload.OnlineServiceQRZ, 10)
+	require.NoError(t, err)
+	require.Len(t, uploads, 1)
+	require.NoError(t, s.UpdateQsoUploadStatus(uploads[0].ID, status.Uploaded, emptyString, 1, emptyString))
+
+	update := func(rst string) {
+		qso, er := s.FetchQsoById(id)
+		require.NoError(t, er)
+		qso.RstSent = rst
+		require.NoError(t, s.UpdateQso(qso))
+	}
+
+	update("57")
+	uploads, err = s.FetchPendingUploadsForService(upload.OnlineServiceQRZ, 10)
+	require.NoError(t, err)
+	require.Len(t, uploads, 1)
+	require.Equal(t, action.Update.String(), uploads[0].Action)
+	uploadID := uploads[0].ID
+
+	// A change while the update is being sent leaves the reservation alone...
+	update("55")
+	model, err := models.FindQsoUpload(context.Background(), s.handle, uploadID)
+	require.NoError(t, err)
+	assert.Equal(t, status.InProgress.String(), model.Status)
+	assert.True(t, model.Requeued)
+	assert.True(t, model.LeaseOwner.Valid)
+
+	// ...and sends the QSO again once the attempt has been reported.
+	require.NoError(t, s.UpdateQsoUploadStatus(uploadID, status.Uploaded, emptyString, 1, emptyString))
+	model, err = models.FindQsoUpload(context.Background(), s.handle, uploadID)
+	require.NoError(t, err)
+	assert.Equal(t, status.Pending.String(), model.Status)
+	assert.False(t, model.Requeued)
+	assert.Zero(t, model.Attempts)
+
+	uploads, err = s.FetchPendingUploadsForService(upload.OnlineServiceQRZ, 10)
+	require.NoError(t, err)
+	require.Len(t, uploads, 1)
+	assert.Equal(t, uploadID, uploads[0].ID)
+	assert.Equal(t, "55", uploads[0].Qso.RstSent)
+
+	attempts, err := s.FetchUploadAttemptsByQsoId(id)
+	require.NoError(t, err)
+	assert.Len(t, attempts, 2)
+}
@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	stderr "errors"
 	"fmt"
 	"net/url"
 	"os"
@@ -11,8 +12,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Station-Manager/enums/upload/action"
+	"github.com/Station-Manager/enums/upload/status"
 	"github.com/Station-Manager/errors"
 	"github.com/Station-Manager/utils"
+	"github.com/aarondl/sqlboiler/v4/boil"
 )
 
 func (s *Service) getOpenHandle(op errors.Op) (*sql.DB, error) {
@@ -194,3 +198,39 @@ func isTransientPingError(err error) bool {
 	}
 	return false
 }
+
+// requeueQsoUpload inserts a pending qso_upload row for the given QSO, service and action. If a row already exists
+// for that combination (the table is unique on qso_id/service/action), it is reset to pending so it is picked up
+// again on the next poll. A row that is in_progress is left to its forwarder and only flagged as requeued; it is
+// made pending once the attempt has been reported (see Tx.updateQsoUploadStatus), and queued is false.
+func requeueQsoUpload(ctx context.Context, exec boil.ContextExecutor, qsoID int64, act action.Action, service string) (id int64, queued bool, err error) {
+	const op errors.Op = "sqlite.requeueQsoUpload"
+
+	const flag = `
+		UPDATE qso_upload
+		   SET requeued = 1
+		 WHERE qso_id = ? AND service = ? AND action = ? AND status = ?
+		RETURNING id`
+
+	const upsert = `
+		INSERT INTO qso_upload (qso_id, service, action, status)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (qso_id, service, action) DO UPDATE
+		   SET status = excluded.status, attempts = 0, last_error = NULL, last_attempt_at = NULL,
+		       next_attempt_at = NULL, lease_owner = NULL, lease_expires_at = NULL, requeued = 0
+		RETURNING id`
+
+	err = exec.QueryRowContext(ctx, flag, qsoID, service, act.String(), status.InProgress.String()).Scan(&id)
+	if err == nil {
+		return id, false, nil
+	}
+	if !stderr.Is(err, sql.ErrNoRows) {
+		return 0, false, errors.New(op).Err(err).Msgf("Failed to queue %s upload for QSO %d.", act, qsoID)
+	}
+
+	if err = exec.QueryRowContext(ctx, upsert, qsoID, service, act.String(), status.Pending.String()).Scan(&id); err != nil {
+		return 0, false, errors.New(op).Err(err).Msgf("Failed to queue %s upload for QSO %d.", act, qsoID)
+	}
+
+	return id, true, nil
+}
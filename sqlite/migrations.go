@@ -37,27 +37,15 @@ func (s *Service) doMigrations() error {
 		return errors.New(op).Errorf("Unsupported database driver: %s (expected %q)", s.DatabaseConfig.Driver, SqliteDriver)
 	}
 
-	srcDriver, dbDriver, err := GetMigrationDrivers(s.handle)
-	if err != nil {
-		return errors.New(op).Err(err)
-	}
-	defer func() { _ = srcDriver.Close() }()
-
-	m, err := migrate.NewWithInstance("iofs", srcDriver, s.DatabaseConfig.Driver, dbDriver)
-	if err != nil {
-		return errors.New(op).Errorf("migrate.NewWithInstance: %w", err)
-	}
-
 	if s.LoggerService != nil {
 		s.LoggerService.InfoWith().Str("driver", s.DatabaseConfig.Driver).Msg("starting migrations")
 	}
 
-	upErr := m.Up()
-	if upErr != nil && !stderr.Is(upErr, migrate.ErrNoChange) {
+	if upErr := runMigrations(s.handle); upErr != nil {
 		if s.LoggerService != nil {
 			s.LoggerService.ErrorWith().Err(upErr).Msg("m.Up failed")
 		}
-		return errors.New(op).Errorf("m.Up: %w", upErr)
+		return errors.New(op).Err(upErr)
 	}
 	if s.LoggerService != nil {
 		s.LoggerService.InfoWith().Msg("m.Up completed or no change")
@@ -79,3 +67,25 @@ func (s *Service) doMigrations() error {
 
 	return errors.New(op).Errorf("schema missing after migrations: %v", missing)
 }
+
+// runMigrations brings the database behind handle up to the latest schema version.
+func runMigrations(handle *sql.DB) error {
+	const op errors.Op = "sqlite.runMigrations"
+
+	srcDriver, dbDriver, err := GetMigrationDrivers(handle)
+	if err != nil {
+		return errors.New(op).Err(err)
+	}
+	defer func() { _ = srcDriver.Close() }()
+
+	m, err := migrate.NewWithInstance("iofs", srcDriver, SqliteDriver, dbDriver)
+	if err != nil {
+		return errors.New(op).Errorf("migrate.NewWithInstance: %w", err)
+	}
+
+	if err = m.Up(); err != nil && !stderr.Is(err, migrate.ErrNoChange) {
+		return errors.New(op).Errorf("m.Up: %w", err)
+	}
+
+	return nil
+}
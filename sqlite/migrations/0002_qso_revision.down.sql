@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_qso_revision_qso_id;
+DROP TABLE IF EXISTS qso_revision;
@@ -0,0 +1,32 @@
+-- Previous versions of QSOs. A row is written before every update or delete and holds the QSO exactly as it was
+-- before the change.
+CREATE TABLE IF NOT EXISTS qso_revision
+(
+    id              INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at      DATETIME NOT NULL DEFAULT (datetime('now', 'localtime')),
+    qso_id          INTEGER  NOT NULL,
+    action          TEXT     NOT NULL CHECK (action IN ('update', 'delete')),
+    reason          TEXT,
+
+    -- Snapshot of the qso row
+    qso_created_at  DATETIME NOT NULL,
+    qso_modified_at DATETIME,
+    qso_deleted_at  DATETIME,
+    call            TEXT     NOT NULL,
+    band            TEXT     NOT NULL,
+    mode            TEXT     NOT NULL,
+    freq            INTEGER  NOT NULL,
+    qso_date        TEXT     NOT NULL,
+    time_on         TEXT     NOT NULL,
+    time_off        TEXT     NOT NULL,
+    rst_sent        TEXT     NOT NULL,
+    rst_rcvd        TEXT     NOT NULL,
+    country         TEXT     NOT NULL,
+    additional_data JSON     NOT NULL CHECK (json_valid(additional_data)),
+    logbook_id      INTEGER  NOT NULL,
+    session_id      INTEGER  NOT NULL,
+
+    CONSTRAINT fk_qso_revision_qso FOREIGN KEY (qso_id) REFERENCES qso (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_qso_revision_qso_id ON qso_revision (qso_id, id);
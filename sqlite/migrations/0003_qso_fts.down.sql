@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trg_qso_fts_delete;
+DROP TRIGGER IF EXISTS trg_qso_fts_update;
+DROP TRIGGER IF EXISTS trg_qso_fts_insert;
+DROP TABLE IF EXISTS qso_fts;
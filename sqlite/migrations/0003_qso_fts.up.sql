@@ -0,0 +1,59 @@
+-- Full-text index over the free-text fields kept in qso.additional_data. The rowid is the qso id. Soft-deleted
+-- QSOs stay indexed and are filtered out at query time.
+CREATE VIRTUAL TABLE IF NOT EXISTS qso_fts USING fts5
+(
+    notes,
+    comment,
+    qth,
+    name,
+    address,
+    tokenize = 'unicode61 remove_diacritics 2',
+    prefix = '2 3'
+);
+
+CREATE TRIGGER IF NOT EXISTS trg_qso_fts_insert
+    AFTER INSERT
+    ON qso
+    FOR EACH ROW
+BEGIN
+    INSERT INTO qso_fts (rowid, notes, comment, qth, name, address)
+    VALUES (NEW.id,
+            json_extract(NEW.additional_data, '$.notes'),
+            json_extract(NEW.additional_data, '$.comment'),
+            json_extract(NEW.additional_data, '$.qth'),
+            json_extract(NEW.additional_data, '$.name'),
+            json_extract(NEW.additional_data, '$.address'));
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_qso_fts_update
+    AFTER UPDATE OF additional_data
+    ON qso
+    FOR EACH ROW
+BEGIN
+    DELETE FROM qso_fts WHERE rowid = OLD.id;
+    INSERT INTO qso_fts (rowid, notes, comment, qth, name, address)
+    VALUES (NEW.id,
+            json_extract(NEW.additional_data, '$.notes'),
+            json_extract(NEW.additional_data, '$.comment'),
+            json_extract(NEW.additional_data, '$.qth'),
+            json_extract(NEW.additional_data, '$.name'),
+            json_extract(NEW.additional_data, '$.address'));
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_qso_fts_delete
+    AFTER DELETE
+    ON qso
+    FOR EACH ROW
+BEGIN
+    DELETE FROM qso_fts WHERE rowid = OLD.id;
+END;
+
+-- Index the QSOs logged before this migration
+INSERT INTO qso_fts (rowid, notes, comment, qth, name, address)
+SELECT id,
+       json_extract(additional_data, '$.notes'),
+       json_extract(additional_data, '$.comment'),
+       json_extract(additional_data, '$.qth'),
+       json_extract(additional_data, '$.name'),
+       json_extract(additional_data, '$.address')
+  FROM qso;
@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_qso_sm_qso_upload_status;
+DROP INDEX IF EXISTS idx_qso_qrzcom_qso_upload_status;
+DROP INDEX IF EXISTS idx_qso_sig_info;
+DROP INDEX IF EXISTS idx_qso_operator;
+DROP INDEX IF EXISTS idx_qso_station_callsign;
+DROP INDEX IF EXISTS idx_qso_contest_id;
+DROP INDEX IF EXISTS idx_qso_gridsquare;
+
+ALTER TABLE qso DROP COLUMN sm_qso_upload_status;
+ALTER TABLE qso DROP COLUMN qrzcom_qso_upload_status;
+ALTER TABLE qso DROP COLUMN sig_info;
+ALTER TABLE qso DROP COLUMN operator;
+ALTER TABLE qso DROP COLUMN station_callsign;
+ALTER TABLE qso DROP COLUMN contest_id;
+ALTER TABLE qso DROP COLUMN gridsquare;
@@ -0,0 +1,24 @@
+-- Expose the most-queried additional_data fields as indexed virtual columns. They are computed on read and cannot
+-- be written; additional_data stays the only source of truth.
+ALTER TABLE qso ADD COLUMN gridsquare TEXT COLLATE NOCASE
+    GENERATED ALWAYS AS (json_extract(additional_data, '$.gridsquare')) VIRTUAL;
+ALTER TABLE qso ADD COLUMN contest_id TEXT
+    GENERATED ALWAYS AS (json_extract(additional_data, '$.contest_id')) VIRTUAL;
+ALTER TABLE qso ADD COLUMN station_callsign TEXT
+    GENERATED ALWAYS AS (json_extract(additional_data, '$.station_callsign')) VIRTUAL;
+ALTER TABLE qso ADD COLUMN operator TEXT
+    GENERATED ALWAYS AS (json_extract(additional_data, '$.operator')) VIRTUAL;
+ALTER TABLE qso ADD COLUMN sig_info TEXT
+    GENERATED ALWAYS AS (json_extract(additional_data, '$.sig_info')) VIRTUAL;
+ALTER TABLE qso ADD COLUMN qrzcom_qso_upload_status TEXT
+    GENERATED ALWAYS AS (json_extract(additional_data, '$.qrzcom_qso_upload_status')) VIRTUAL;
+ALTER TABLE qso ADD COLUMN sm_qso_upload_status TEXT
+    GENERATED ALWAYS AS (json_extract(additional_data, '$.sm_qso_upload_status')) VIRTUAL;
+
+CREATE INDEX IF NOT EXISTS idx_qso_gridsquare ON qso (gridsquare);
+CREATE INDEX IF NOT EXISTS idx_qso_contest_id ON qso (contest_id);
+CREATE INDEX IF NOT EXISTS idx_qso_station_callsign ON qso (station_callsign);
+CREATE INDEX IF NOT EXISTS idx_qso_operator ON qso (operator);
+CREATE INDEX IF NOT EXISTS idx_qso_sig_info ON qso (sig_info);
+CREATE INDEX IF NOT EXISTS idx_qso_qrzcom_qso_upload_status ON qso (qrzcom_qso_upload_status);
+CREATE INDEX IF NOT EXISTS idx_qso_sm_qso_upload_status ON qso (sm_qso_upload_status);
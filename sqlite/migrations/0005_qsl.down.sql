@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_qsl_sent_not_confirmed;
+DROP INDEX IF EXISTS idx_qsl_requested_not_sent;
+DROP TRIGGER IF EXISTS trg_qsl_set_modified_at;
+DROP TABLE IF EXISTS qsl;
@@ -0,0 +1,39 @@
+-- QSL state per QSO and channel. Status values follow ADIF QSL_SENT / QSL_RCVD:
+--   sent: Y = sent, N = not sent, R = requested (card wanted), Q = queued for sending, I = ignore
+--   rcvd: Y = received, N = not received, R = requested (we asked for one), I = ignore, V = verified
+-- Dates use the qso_date format (YYYYMMDD).
+CREATE TABLE IF NOT EXISTS qsl
+(
+    id          INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at  DATETIME NOT NULL DEFAULT (datetime('now', 'localtime')),
+    modified_at DATETIME,
+    qso_id      INTEGER  NOT NULL,
+    channel     TEXT     NOT NULL CHECK (channel IN ('bureau', 'direct', 'lotw', 'eqsl', 'qrz')),
+    sent_status TEXT     NOT NULL DEFAULT 'N' CHECK (sent_status IN ('Y', 'N', 'R', 'Q', 'I')),
+    sent_date   TEXT CHECK (sent_date IS NULL OR length(sent_date) = 8),
+    rcvd_status TEXT     NOT NULL DEFAULT 'N' CHECK (rcvd_status IN ('Y', 'N', 'R', 'I', 'V')),
+    rcvd_date   TEXT CHECK (rcvd_date IS NULL OR length(rcvd_date) = 8),
+    CONSTRAINT uq_qsl_qso_channel UNIQUE (qso_id, channel),
+    CONSTRAINT fk_qsl_qso FOREIGN KEY (qso_id) REFERENCES qso (id) ON DELETE CASCADE
+);
+
+-- Keep modified_at fresh on updates
+CREATE TRIGGER IF NOT EXISTS trg_qsl_set_modified_at
+    AFTER UPDATE
+    ON qsl
+    FOR EACH ROW
+BEGIN
+    UPDATE qsl
+    SET modified_at = datetime('now', 'localtime')
+    WHERE id = OLD.id;
+END;
+
+-- Cards that still have to go out
+CREATE INDEX IF NOT EXISTS idx_qsl_requested_not_sent
+    ON qsl (channel)
+    WHERE sent_status IN ('R', 'Q');
+
+-- Cards sent that have not been answered yet
+CREATE INDEX IF NOT EXISTS idx_qsl_sent_not_confirmed
+    ON qsl (channel)
+    WHERE sent_status = 'Y' AND rcvd_status NOT IN ('Y', 'V');
@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS qso_upload_old
+(
+    id              INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at      DATETIME NOT NULL DEFAULT (datetime('now', 'localtime')),
+    modified_at     DATETIME,
+    qso_id          INTEGER  NOT NULL,
+    service         TEXT     NOT NULL,
+    action          TEXT     NOT NULL DEFAULT 'insert' CHECK (action IN ('insert', 'update', 'delete')),
+    status          TEXT     NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'in_progress', 'uploaded', 'failed')),
+    attempts        INTEGER  NOT NULL DEFAULT 0,
+    last_attempt_at INTEGER, -- Unix time
+    last_error      TEXT,
+    CONSTRAINT uq_qso_service UNIQUE (qso_id, service, action),
+    CONSTRAINT fk_qso_upload_qso FOREIGN KEY (qso_id) REFERENCES qso (id) ON DELETE CASCADE
+);
+
+-- Dead uploads become failed ones again.
+INSERT INTO qso_upload_old (id, created_at, modified_at, qso_id, service, action, status, attempts, last_attempt_at,
+                            last_error)
+SELECT id, created_at, modified_at, qso_id, service, action,
+       CASE status WHEN 'dead' THEN 'failed' ELSE status END,
+       attempts, last_attempt_at, last_error
+  FROM qso_upload;
+
+DROP TABLE qso_upload;
+ALTER TABLE qso_upload_old RENAME TO qso_upload;
+
+CREATE TRIGGER IF NOT EXISTS trg_qso_upload_set_updated_at
+    AFTER UPDATE
+    ON qso_upload
+    FOR EACH ROW
+BEGIN
+    UPDATE qso_upload
+    SET modified_at = datetime('now', 'localtime')
+    WHERE id = OLD.id;
+END;
+
+CREATE INDEX IF NOT EXISTS idx_qso_upload_pending
+    ON qso_upload (service)
+    WHERE status IN ('pending', 'in_progress');
+
+CREATE INDEX IF NOT EXISTS idx_qso_upload_uploaded
+    ON qso_upload (service, modified_at)
+    WHERE status = 'uploaded';
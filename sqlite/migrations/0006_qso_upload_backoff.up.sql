@@ -0,0 +1,53 @@
+-- Retry scheduling for qso_upload. A failed upload is retried once next_attempt_at (Unix time) has passed; after too
+-- many attempts it is moved to 'dead' and left alone until requeued. SQLite cannot alter a CHECK constraint, so the
+-- table is rebuilt.
+CREATE TABLE IF NOT EXISTS qso_upload_new
+(
+    id              INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at      DATETIME NOT NULL DEFAULT (datetime('now', 'localtime')),
+    modified_at     DATETIME,
+    qso_id          INTEGER  NOT NULL,
+    service         TEXT     NOT NULL,
+    action          TEXT     NOT NULL DEFAULT 'insert' CHECK (action IN ('insert', 'update', 'delete')),
+    status          TEXT     NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'in_progress', 'uploaded', 'failed', 'dead')),
+    attempts        INTEGER  NOT NULL DEFAULT 0,
+    last_attempt_at INTEGER, -- Unix time
+    next_attempt_at INTEGER, -- Unix time; NULL means as soon as possible
+    last_error      TEXT,
+    CONSTRAINT uq_qso_service UNIQUE (qso_id, service, action),
+    CONSTRAINT fk_qso_upload_qso FOREIGN KEY (qso_id) REFERENCES qso (id) ON DELETE CASCADE
+);
+
+INSERT INTO qso_upload_new (id, created_at, modified_at, qso_id, service, action, status, attempts, last_attempt_at,
+                            last_error)
+SELECT id, created_at, modified_at, qso_id, service, action, status, attempts, last_attempt_at, last_error
+  FROM qso_upload;
+
+DROP TABLE qso_upload;
+ALTER TABLE qso_upload_new RENAME TO qso_upload;
+
+-- Keep modified_at fresh on updates
+CREATE TRIGGER IF NOT EXISTS trg_qso_upload_set_updated_at
+    AFTER UPDATE
+    ON qso_upload
+    FOR EACH ROW
+BEGIN
+    UPDATE qso_upload
+    SET modified_at = datetime('now', 'localtime')
+    WHERE id = OLD.id;
+END;
+
+-- Pending work per service, ordered by next_attempt_at
+CREATE INDEX IF NOT EXISTS idx_qso_upload_pending
+    ON qso_upload (service, next_attempt_at)
+    WHERE status IN ('pending', 'in_progress');
+
+-- Fast lookup of uploaded rows per service (optional)
+CREATE INDEX IF NOT EXISTS idx_qso_upload_uploaded
+    ON qso_upload (service, modified_at)
+    WHERE status = 'uploaded';
+
+-- Dead-lettered uploads, for review and requeueing
+CREATE INDEX IF NOT EXISTS idx_qso_upload_dead
+    ON qso_upload (service)
+    WHERE status = 'dead';
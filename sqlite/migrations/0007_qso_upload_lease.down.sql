@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_qso_upload_lease;
+ALTER TABLE qso_upload DROP COLUMN lease_expires_at;
+ALTER TABLE qso_upload DROP COLUMN lease_owner;
@@ -0,0 +1,8 @@
+-- Reservations of qso_upload rows are leases. A row in 'in_progress' whose lease has expired was abandoned (the
+-- forwarder stopped before reporting back) and is handed out again.
+ALTER TABLE qso_upload ADD COLUMN lease_owner TEXT;
+ALTER TABLE qso_upload ADD COLUMN lease_expires_at INTEGER; -- Unix time
+
+CREATE INDEX IF NOT EXISTS idx_qso_upload_lease
+    ON qso_upload (lease_expires_at)
+    WHERE status = 'in_progress';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_qso_upload_pending;
+
+CREATE INDEX IF NOT EXISTS idx_qso_upload_pending
+    ON qso_upload (service, next_attempt_at)
+    WHERE status IN ('pending', 'in_progress');
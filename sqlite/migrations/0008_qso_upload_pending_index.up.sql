@@ -0,0 +1,9 @@
+-- Reservations take the work of one service in the order it was queued. Rebuild the pending index to cover every
+-- status a reservation can pick (pending, failed awaiting retry, in_progress with a lapsed lease). created_at is not
+-- written in a single text format (the column default and the models differ), so it cannot order rows; the
+-- AUTOINCREMENT id can. An index on service alone keeps its entries in rowid (id) order.
+DROP INDEX IF EXISTS idx_qso_upload_pending;
+
+CREATE INDEX IF NOT EXISTS idx_qso_upload_pending
+    ON qso_upload (service)
+    WHERE status IN ('pending', 'failed', 'in_progress');
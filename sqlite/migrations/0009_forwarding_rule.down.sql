@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS trg_forwarding_rule_set_modified_at;
+DROP TABLE IF EXISTS forwarding_rule;
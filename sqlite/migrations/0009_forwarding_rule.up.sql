@@ -0,0 +1,30 @@
+-- Which online services a logbook's QSOs are forwarded to. QSO inserts and updates queue a qso_upload row for every
+-- enabled rule the QSO passes:
+--   only_complete   skip QSOs whose qso_complete is set to anything but 'Y'
+--   modes           JSON array of modes to forward; empty forwards every mode
+--   exclude_contest skip QSOs that have a contest_id
+CREATE TABLE IF NOT EXISTS forwarding_rule
+(
+    id              INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at      DATETIME NOT NULL DEFAULT (datetime('now', 'localtime')),
+    modified_at     DATETIME,
+    logbook_id      INTEGER  NOT NULL,
+    service         TEXT     NOT NULL CHECK (length(trim(service)) BETWEEN 1 AND 32),
+    enabled         BOOLEAN  NOT NULL DEFAULT 1 CHECK (enabled IN (0, 1)),
+    only_complete   BOOLEAN  NOT NULL DEFAULT 0 CHECK (only_complete IN (0, 1)),
+    modes           JSON     NOT NULL DEFAULT ('[]') CHECK (json_valid(modes) AND json_type(modes) = 'array'),
+    exclude_contest BOOLEAN  NOT NULL DEFAULT 0 CHECK (exclude_contest IN (0, 1)),
+    CONSTRAINT uq_forwarding_rule_logbook_service UNIQUE (logbook_id, service),
+    CONSTRAINT fk_forwarding_rule_logbook FOREIGN KEY (logbook_id) REFERENCES logbook (id) ON DELETE CASCADE
+);
+
+-- Keep modified_at fresh on updates
+CREATE TRIGGER IF NOT EXISTS trg_forwarding_rule_set_modified_at
+    AFTER UPDATE
+    ON forwarding_rule
+    FOR EACH ROW
+BEGIN
+    UPDATE forwarding_rule
+    SET modified_at = datetime('now', 'localtime')
+    WHERE id = OLD.id;
+END;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_qso_upload_attempt_upload;
+DROP INDEX IF EXISTS idx_qso_upload_attempt_service;
+DROP INDEX IF EXISTS idx_qso_upload_attempt_qso;
+DROP TABLE IF EXISTS qso_upload_attempt;
@@ -0,0 +1,30 @@
+-- One row per upload attempt reported back by the forwarder, kept after the qso_upload row moves on (or is purged),
+-- so earlier failures and service response times can be looked at. The QSO, service and action are copied from the
+-- upload for that reason.
+CREATE TABLE IF NOT EXISTS qso_upload_attempt
+(
+    id               INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at       DATETIME NOT NULL DEFAULT (datetime('now', 'localtime')),
+    qso_upload_id    INTEGER,
+    qso_id           INTEGER  NOT NULL,
+    service          TEXT     NOT NULL,
+    action           TEXT     NOT NULL CHECK (action IN ('insert', 'update', 'delete')),
+    attempted_at     INTEGER  NOT NULL,           -- Unix time
+    duration_ms      INTEGER  NOT NULL DEFAULT 0 CHECK (duration_ms >= 0),
+    outcome          TEXT     NOT NULL CHECK (outcome IN ('uploaded', 'failed')),
+    response_code    INTEGER,                     -- e.g. the HTTP status
+    response_message TEXT,
+    remote_id        TEXT,                        -- The service's ID for the record
+    error            TEXT,
+    CONSTRAINT fk_qso_upload_attempt_upload FOREIGN KEY (qso_upload_id) REFERENCES qso_upload (id) ON DELETE SET NULL,
+    CONSTRAINT fk_qso_upload_attempt_qso FOREIGN KEY (qso_id) REFERENCES qso (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_qso_upload_attempt_qso
+    ON qso_upload_attempt (qso_id, attempted_at);
+
+CREATE INDEX IF NOT EXISTS idx_qso_upload_attempt_service
+    ON qso_upload_attempt (service, attempted_at);
+
+CREATE INDEX IF NOT EXISTS idx_qso_upload_attempt_upload
+    ON qso_upload_attempt (qso_upload_id);
@@ -0,0 +1,2 @@
+ALTER TABLE contacted_station DROP COLUMN last_qso_at;
+ALTER TABLE contacted_station DROP COLUMN user_fields;
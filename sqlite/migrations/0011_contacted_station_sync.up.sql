@@ -0,0 +1,6 @@
+-- Contacted stations can be kept up to date from logged QSOs. user_fields lists the fields (by their JSON name) the
+-- user set or edited, which QSOs never overwrite; last_qso_at is the start (qso_date || time_on) of the newest QSO
+-- merged into the station.
+ALTER TABLE contacted_station ADD COLUMN user_fields JSON NOT NULL DEFAULT '[]'
+    CHECK (json_valid(user_fields) AND json_type(user_fields) = 'array');
+ALTER TABLE contacted_station ADD COLUMN last_qso_at TEXT;
@@ -0,0 +1,28 @@
+-- Exact callsign rows have no place in the old table and are dropped.
+CREATE TABLE IF NOT EXISTS country_old
+(
+    id          INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at  DATETIME NOT NULL DEFAULT (datetime('now', 'localtime')),
+    modified_at DATETIME,
+    deleted_at  DATETIME,
+    name        TEXT     NOT NULL,
+    cq_zone     TEXT     NOT NULL,
+    itu_zone    TEXT     NOT NULL,
+    continent   TEXT     NOT NULL,
+    prefix      TEXT     NOT NULL UNIQUE CHECK (length(trim(prefix)) <= 20),
+    ccode       TEXT     NOT NULL,
+    dxcc_prefix TEXT     NOT NULL,
+    time_offset TEXT     NOT NULL
+);
+
+INSERT INTO country_old (id, created_at, modified_at, deleted_at, name, cq_zone, itu_zone, continent, prefix, ccode,
+                         dxcc_prefix, time_offset)
+SELECT id, created_at, modified_at, deleted_at, name, cq_zone, itu_zone, continent, prefix, ccode, dxcc_prefix,
+       time_offset
+  FROM country
+ WHERE NOT exact;
+
+DROP TABLE country;
+ALTER TABLE country_old RENAME TO country;
+
+CREATE INDEX IF NOT EXISTS idx_country_name ON country (name);
@@ -0,0 +1,32 @@
+-- Countries imported from cty.dat / cty.csv. Besides prefixes, the files list exact callsigns ('=' entries) that
+-- belong to a different country (or zone) than their prefix suggests; exact marks those rows, which only match the
+-- whole callsign. A callsign and a prefix can be spelled the same, so prefix is unique per kind. SQLite cannot drop
+-- a UNIQUE constraint, so the table is rebuilt.
+CREATE TABLE IF NOT EXISTS country_new
+(
+    id          INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at  DATETIME NOT NULL DEFAULT (datetime('now', 'localtime')),
+    modified_at DATETIME,
+    deleted_at  DATETIME,
+    name        TEXT     NOT NULL,
+    cq_zone     TEXT     NOT NULL,
+    itu_zone    TEXT     NOT NULL,
+    continent   TEXT     NOT NULL,
+    prefix      TEXT     NOT NULL CHECK (length(trim(prefix)) <= 20),
+    ccode       TEXT     NOT NULL,
+    dxcc_prefix TEXT     NOT NULL,
+    time_offset TEXT     NOT NULL,
+    exact       BOOLEAN  NOT NULL DEFAULT 0 CHECK (exact IN (0, 1)),
+    CONSTRAINT uq_country_prefix UNIQUE (prefix, exact)
+);
+
+INSERT INTO country_new (id, created_at, modified_at, deleted_at, name, cq_zone, itu_zone, continent, prefix, ccode,
+                         dxcc_prefix, time_offset)
+SELECT id, created_at, modified_at, deleted_at, name, cq_zone, itu_zone, continent, prefix, ccode, dxcc_prefix,
+       time_offset
+  FROM country;
+
+DROP TABLE country;
+ALTER TABLE country_new RENAME TO country;
+
+CREATE INDEX IF NOT EXISTS idx_country_name ON country (name);
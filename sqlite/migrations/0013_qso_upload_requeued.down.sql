@@ -0,0 +1 @@
+ALTER TABLE qso_upload DROP COLUMN requeued;
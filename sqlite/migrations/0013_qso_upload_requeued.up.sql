@@ -0,0 +1,4 @@
+-- A QSO changed while its upload is in_progress cannot have the upload reset under the forwarder's feet: the report
+-- of the attempt would overwrite it and the change would never be sent. The upload is flagged instead, and made
+-- pending again once the attempt has been reported.
+ALTER TABLE qso_upload ADD COLUMN requeued BOOLEAN NOT NULL DEFAULT 0 CHECK (requeued IN (0, 1));
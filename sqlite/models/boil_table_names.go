@@ -6,15 +6,23 @@ package models
 var TableNames = struct {
 	ContactedStation string
 	Country          string
+	ForwardingRule   string
 	Logbook          string
+	QSL              string
 	Qso              string
+	QsoRevision      string
 	QsoUpload        string
+	QsoUploadAttempt string
 	Session          string
 }{
 	ContactedStation: "contacted_station",
 	Country:          "country",
+	ForwardingRule:   "forwarding_rule",
 	Logbook:          "logbook",
+	QSL:              "qsl",
 	Qso:              "qso",
+	QsoRevision:      "qso_revision",
 	QsoUpload:        "qso_upload",
+	QsoUploadAttempt: "qso_upload_attempt",
 	Session:          "session",
 }
@@ -25,14 +25,16 @@ import (
 
 // ContactedStation is an object representing the database table.
 type ContactedStation struct {
-	ID             int64      `boil:"id" json:"id" toml:"id" yaml:"id"`
-	CreatedAt      time.Time  `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	ModifiedAt     null.Time  `boil:"modified_at" json:"modified_at,omitempty" toml:"modified_at" yaml:"modified_at,omitempty"`
-	DeletedAt      null.Time  `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
-	Name           string     `boil:"name" json:"name" toml:"name" yaml:"name"`
-	Call           string     `boil:"call" json:"call" toml:"call" yaml:"call"`
-	Country        string     `boil:"country" json:"country" toml:"country" yaml:"country"`
-	AdditionalData types.JSON `boil:"additional_data" json:"additional_data" toml:"additional_data" yaml:"additional_data"`
+	ID             int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt      time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ModifiedAt     null.Time   `boil:"modified_at" json:"modified_at,omitempty" toml:"modified_at" yaml:"modified_at,omitempty"`
+	DeletedAt      null.Time   `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	Name           string      `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Call           string      `boil:"call" json:"call" toml:"call" yaml:"call"`
+	Country        string      `boil:"country" json:"country" toml:"country" yaml:"country"`
+	AdditionalData types.JSON  `boil:"additional_data" json:"additional_data" toml:"additional_data" yaml:"additional_data"`
+	UserFields     types.JSON  `boil:"user_fields" json:"user_fields" toml:"user_fields" yaml:"user_fields"`
+	LastQsoAt      null.String `boil:"last_qso_at" json:"last_qso_at,omitempty" toml:"last_qso_at" yaml:"last_qso_at,omitempty"`
 
 	R *contactedStationR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L contactedStationL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -47,6 +49,8 @@ var ContactedStationColumns = struct {
 	Call           string
 	Country        string
 	AdditionalData string
+	UserFields     string
+	LastQsoAt      string
 }{
 	ID:             "id",
 	CreatedAt:      "created_at",
@@ -56,6 +60,8 @@ var ContactedStationColumns = struct {
 	Call:           "call",
 	Country:        "country",
 	AdditionalData: "additional_data",
+	UserFields:     "user_fields",
+	LastQsoAt:      "last_qso_at",
 }
 
 var ContactedStationTableColumns = struct {
@@ -67,6 +73,8 @@ var ContactedStationTableColumns = struct {
 	Call           string
 	Country        string
 	AdditionalData string
+	UserFields     string
+	LastQsoAt      string
 }{
 	ID:             "contacted_station.id",
 	CreatedAt:      "contacted_station.created_at",
@@ -76,6 +84,8 @@ var ContactedStationTableColumns = struct {
 	Call:           "contacted_station.call",
 	Country:        "contacted_station.country",
 	AdditionalData: "contacted_station.additional_data",
+	UserFields:     "contacted_station.user_fields",
+	LastQsoAt:      "contacted_station.last_qso_at",
 }
 
 // Generated where
@@ -194,6 +204,50 @@ func (w whereHelpertypes_JSON) GTE(x types.JSON) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
+type whereHelpernull_String struct{ field string }
+
+func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_String) LIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" LIKE ?", x)
+}
+func (w whereHelpernull_String) NLIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" NOT LIKE ?", x)
+}
+func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var ContactedStationWhere = struct {
 	ID             whereHelperint64
 	CreatedAt      whereHelpertime_Time
@@ -203,6 +257,8 @@ var ContactedStationWhere = struct {
 	Call           whereHelperstring
 	Country        whereHelperstring
 	AdditionalData whereHelpertypes_JSON
+	UserFields     whereHelpertypes_JSON
+	LastQsoAt      whereHelpernull_String
 }{
 	ID:             whereHelperint64{field: "\"contacted_station\".\"id\""},
 	CreatedAt:      whereHelpertime_Time{field: "\"contacted_station\".\"created_at\""},
@@ -212,6 +268,8 @@ var ContactedStationWhere = struct {
 	Call:           whereHelperstring{field: "\"contacted_station\".\"call\""},
 	Country:        whereHelperstring{field: "\"contacted_station\".\"country\""},
 	AdditionalData: whereHelpertypes_JSON{field: "\"contacted_station\".\"additional_data\""},
+	UserFields:     whereHelpertypes_JSON{field: "\"contacted_station\".\"user_fields\""},
+	LastQsoAt:      whereHelpernull_String{field: "\"contacted_station\".\"last_qso_at\""},
 }
 
 // ContactedStationRels is where relationship names are stored.
@@ -231,9 +289,9 @@ func (*contactedStationR) NewStruct() *contactedStationR {
 type contactedStationL struct{}
 
 var (
-	contactedStationAllColumns            = []string{"id", "created_at", "modified_at", "deleted_at", "name", "call", "country", "additional_data"}
+	contactedStationAllColumns            = []string{"id", "created_at", "modified_at", "deleted_at", "name", "call", "country", "additional_data", "user_fields", "last_qso_at"}
 	contactedStationColumnsWithoutDefault = []string{"name", "call", "country"}
-	contactedStationColumnsWithDefault    = []string{"id", "created_at", "modified_at", "deleted_at", "additional_data"}
+	contactedStationColumnsWithDefault    = []string{"id", "created_at", "modified_at", "deleted_at", "additional_data", "user_fields", "last_qso_at"}
 	contactedStationPrimaryKeyColumns     = []string{"id"}
 	contactedStationGeneratedColumns      = []string{"id"}
 )
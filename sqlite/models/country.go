@@ -36,6 +36,7 @@ type Country struct {
 	Ccode      string    `boil:"ccode" json:"ccode" toml:"ccode" yaml:"ccode"`
 	DXCCPrefix string    `boil:"dxcc_prefix" json:"dxcc_prefix" toml:"dxcc_prefix" yaml:"dxcc_prefix"`
 	TimeOffset string    `boil:"time_offset" json:"time_offset" toml:"time_offset" yaml:"time_offset"`
+	Exact      bool      `boil:"exact" json:"exact" toml:"exact" yaml:"exact"`
 
 	R *countryR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L countryL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -54,6 +55,7 @@ var CountryColumns = struct {
 	Ccode      string
 	DXCCPrefix string
 	TimeOffset string
+	Exact      string
 }{
 	ID:         "id",
 	CreatedAt:  "created_at",
@@ -67,6 +69,7 @@ var CountryColumns = struct {
 	Ccode:      "ccode",
 	DXCCPrefix: "dxcc_prefix",
 	TimeOffset: "time_offset",
+	Exact:      "exact",
 }
 
 var CountryTableColumns = struct {
@@ -82,6 +85,7 @@ var CountryTableColumns = struct {
 	Ccode      string
 	DXCCPrefix string
 	TimeOffset string
+	Exact      string
 }{
 	ID:         "country.id",
 	CreatedAt:  "country.created_at",
@@ -95,10 +99,20 @@ var CountryTableColumns = struct {
 	Ccode:      "country.ccode",
 	DXCCPrefix: "country.dxcc_prefix",
 	TimeOffset: "country.time_offset",
+	Exact:      "country.exact",
 }
 
 // Generated where
 
+type whereHelperbool struct{ field string }
+
+func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
 var CountryWhere = struct {
 	ID         whereHelperint64
 	CreatedAt  whereHelpertime_Time
@@ -112,6 +126,7 @@ var CountryWhere = struct {
 	Ccode      whereHelperstring
 	DXCCPrefix whereHelperstring
 	TimeOffset whereHelperstring
+	Exact      whereHelperbool
 }{
 	ID:         whereHelperint64{field: "\"country\".\"id\""},
 	CreatedAt:  whereHelpertime_Time{field: "\"country\".\"created_at\""},
@@ -125,6 +140,7 @@ var CountryWhere = struct {
 	Ccode:      whereHelperstring{field: "\"country\".\"ccode\""},
 	DXCCPrefix: whereHelperstring{field: "\"country\".\"dxcc_prefix\""},
 	TimeOffset: whereHelperstring{field: "\"country\".\"time_offset\""},
+	Exact:      whereHelperbool{field: "\"country\".\"exact\""},
 }
 
 // CountryRels is where relationship names are stored.
@@ -144,9 +160,9 @@ func (*countryR) NewStruct() *countryR {
 type countryL struct{}
 
 var (
-	countryAllColumns            = []string{"id", "created_at", "modified_at", "deleted_at", "name", "cq_zone", "itu_zone", "continent", "prefix", "ccode", "dxcc_prefix", "time_offset"}
+	countryAllColumns            = []string{"id", "created_at", "modified_at", "deleted_at", "name", "cq_zone", "itu_zone", "continent", "prefix", "ccode", "dxcc_prefix", "time_offset", "exact"}
 	countryColumnsWithoutDefault = []string{"name", "cq_zone", "itu_zone", "continent", "prefix", "ccode", "dxcc_prefix", "time_offset"}
-	countryColumnsWithDefault    = []string{"id", "created_at", "modified_at", "deleted_at"}
+	countryColumnsWithDefault    = []string{"id", "created_at", "modified_at", "deleted_at", "exact"}
 	countryPrimaryKeyColumns     = []string{"id"}
 	countryGeneratedColumns      = []string{"id"}
 )
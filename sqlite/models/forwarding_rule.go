@@ -0,0 +1,928 @@
+// Code generated by SQLBoiler 4.19.5 (https://github.com/aarondl/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/aarondl/sqlboiler/v4/queries/qmhelper"
+	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/aarondl/strmangle"
+	"github.com/friendsofgo/errors"
+)
+
+// ForwardingRule is an object representing the database table.
+type ForwardingRule struct {
+	ID             int64      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt      time.Time  `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ModifiedAt     null.Time  `boil:"modified_at" json:"modified_at,omitempty" toml:"modified_at" yaml:"modified_at,omitempty"`
+	LogbookID      int64      `boil:"logbook_id" json:"logbook_id" toml:"logbook_id" yaml:"logbook_id"`
+	Service        string     `boil:"service" json:"service" toml:"service" yaml:"service"`
+	Enabled        bool       `boil:"enabled" json:"enabled" toml:"enabled" yaml:"enabled"`
+	OnlyComplete   bool       `boil:"only_complete" json:"only_complete" toml:"only_complete" yaml:"only_complete"`
+	Modes          types.JSON `boil:"modes" json:"modes" toml:"modes" yaml:"modes"`
+	ExcludeContest bool       `boil:"exclude_contest" json:"exclude_contest" toml:"exclude_contest" yaml:"exclude_contest"`
+
+	R *forwardingRuleR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L forwardingRuleL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ForwardingRuleColumns = struct {
+	ID             string
+	CreatedAt      string
+	ModifiedAt     string
+	LogbookID      string
+	Service        string
+	Enabled        string
+	OnlyComplete   string
+	Modes          string
+	ExcludeContest string
+}{
+	ID:             "id",
+	CreatedAt:      "created_at",
+	ModifiedAt:     "modified_at",
+	LogbookID:      "logbook_id",
+	Service:        "service",
+	Enabled:        "enabled",
+	OnlyComplete:   "only_complete",
+	Modes:          "modes",
+	ExcludeContest: "exclude_contest",
+}
+
+var ForwardingRuleTableColumns = struct {
+	ID             string
+	CreatedAt      string
+	ModifiedAt     string
+	LogbookID      string
+	Service        string
+	Enabled        string
+	OnlyComplete   string
+	Modes          string
+	ExcludeContest string
+}{
+	ID:             "forwarding_rule.id",
+	CreatedAt:      "forwarding_rule.created_at",
+	ModifiedAt:     "forwarding_rule.modified_at",
+	LogbookID:      "forwarding_rule.logbook_id",
+	Service:        "forwarding_rule.service",
+	Enabled:        "forwarding_rule.enabled",
+	OnlyComplete:   "forwarding_rule.only_complete",
+	Modes:          "forwarding_rule.modes",
+	ExcludeContest: "forwarding_rule.exclude_contest",
+}
+
+// Generated where
+
+var ForwardingRuleWhere = struct {
+	ID             whereHelperint64
+	CreatedAt      whereHelpertime_Time
+	ModifiedAt     whereHelpernull_Time
+	LogbookID      whereHelperint64
+	Service        whereHelperstring
+	Enabled        whereHelperbool
+	OnlyComplete   whereHelperbool
+	Modes          whereHelpertypes_JSON
+	ExcludeContest whereHelperbool
+}{
+	ID:             whereHelperint64{field: "\"forwarding_rule\".\"id\""},
+	CreatedAt:      whereHelpertime_Time{field: "\"forwarding_rule\".\"created_at\""},
+	ModifiedAt:     whereHelpernull_Time{field: "\"forwarding_rule\".\"modified_at\""},
+	LogbookID:      whereHelperint64{field: "\"forwarding_rule\".\"logbook_id\""},
+	Service:        whereHelperstring{field: "\"forwarding_rule\".\"service\""},
+	Enabled:        whereHelperbool{field: "\"forwarding_rule\".\"enabled\""},
+	OnlyComplete:   whereHelperbool{field: "\"forwarding_rule\".\"only_complete\""},
+	Modes:          whereHelpertypes_JSON{field: "\"forwarding_rule\".\"modes\""},
+	ExcludeContest: whereHelperbool{field: "\"forwarding_rule\".\"exclude_contest\""},
+}
+
+// ForwardingRuleRels is where relationship names are stored.
+var ForwardingRuleRels = struct {
+	Logbook string
+}{
+	Logbook: "Logbook",
+}
+
+// forwardingRuleR is where relationships are stored.
+type forwardingRuleR struct {
+	Logbook *Logbook `boil:"Logbook" json:"Logbook" toml:"Logbook" yaml:"Logbook"`
+}
+
+// NewStruct creates a new relationship struct
+func (*forwardingRuleR) NewStruct() *forwardingRuleR {
+	return &forwardingRuleR{}
+}
+
+func (o *ForwardingRule) GetLogbook() *Logbook {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetLogbook()
+}
+
+func (r *forwardingRuleR) GetLogbook() *Logbook {
+	if r == nil {
+		return nil
+	}
+
+	return r.Logbook
+}
+
+// forwardingRuleL is where Load methods for each relationship are stored.
+type forwardingRuleL struct{}
+
+var (
+	forwardingRuleAllColumns            = []string{"id", "created_at", "modified_at", "logbook_id", "service", "enabled", "only_complete", "modes", "exclude_contest"}
+	forwardingRuleColumnsWithoutDefault = []string{"logbook_id", "service"}
+	forwardingRuleColumnsWithDefault    = []string{"id", "created_at", "modified_at", "enabled", "only_complete", "modes", "exclude_contest"}
+	forwardingRulePrimaryKeyColumns     = []string{"id"}
+	forwardingRuleGeneratedColumns      = []string{"id"}
+)
+
+type (
+	// ForwardingRuleSlice is an alias for a slice of pointers to ForwardingRule.
+	// This should almost always be used instead of []ForwardingRule.
+	ForwardingRuleSlice []*ForwardingRule
+
+	forwardingRuleQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	forwardingRuleType                 = reflect.TypeOf(&ForwardingRule{})
+	forwardingRuleMapping              = queries.MakeStructMapping(forwardingRuleType)
+	forwardingRulePrimaryKeyMapping, _ = queries.BindMapping(forwardingRuleType, forwardingRuleMapping, forwardingRulePrimaryKeyColumns)
+	forwardingRuleInsertCacheMut       sync.RWMutex
+	forwardingRuleInsertCache          = make(map[string]insertCache)
+	forwardingRuleUpdateCacheMut       sync.RWMutex
+	forwardingRuleUpdateCache          = make(map[string]updateCache)
+	forwardingRuleUpsertCacheMut       sync.RWMutex
+	forwardingRuleUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single forwardingRule record from the query.
+func (q forwardingRuleQuery) One(ctx context.Context, exec boil.ContextExecutor) (*ForwardingRule, error) {
+	o := &ForwardingRule{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for forwarding_rule")
+	}
+
+	return o, nil
+}
+
+// All returns all ForwardingRule records from the query.
+func (q forwardingRuleQuery) All(ctx context.Context, exec boil.ContextExecutor) (ForwardingRuleSlice, error) {
+	var o []*ForwardingRule
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to ForwardingRule slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all ForwardingRule records in the query.
+func (q forwardingRuleQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count forwarding_rule rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q forwardingRuleQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if forwarding_rule exists")
+	}
+
+	return count > 0, nil
+}
+
+// Logbook pointed to by the foreign key.
+func (o *ForwardingRule) Logbook(mods ...qm.QueryMod) logbookQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.LogbookID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Logbooks(queryMods...)
+}
+
+// LoadLogbook allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (forwardingRuleL) LoadLogbook(ctx context.Context, e boil.ContextExecutor, singular bool, maybeForwardingRule interface{}, mods queries.Applicator) error {
+	var slice []*ForwardingRule
+	var object *ForwardingRule
+
+	if singular {
+		var ok bool
+		object, ok = maybeForwardingRule.(*ForwardingRule)
+		if !ok {
+			object = new(ForwardingRule)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeForwardingRule)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeForwardingRule))
+			}
+		}
+	} else {
+		s, ok := maybeForwardingRule.(*[]*ForwardingRule)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeForwardingRule)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeForwardingRule))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &forwardingRuleR{}
+		}
+		args[object.LogbookID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &forwardingRuleR{}
+			}
+
+			args[obj.LogbookID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`logbook`),
+		qm.WhereIn(`logbook.id in ?`, argsSlice...),
+		qmhelper.WhereIsNull(`logbook.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Logbook")
+	}
+
+	var resultSlice []*Logbook
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Logbook")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for logbook")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for logbook")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Logbook = foreign
+		if foreign.R == nil {
+			foreign.R = &logbookR{}
+		}
+		foreign.R.ForwardingRules = append(foreign.R.ForwardingRules, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.LogbookID == foreign.ID {
+				local.R.Logbook = foreign
+				if foreign.R == nil {
+					foreign.R = &logbookR{}
+				}
+				foreign.R.ForwardingRules = append(foreign.R.ForwardingRules, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetLogbook of the forwardingRule to the related item.
+// Sets o.R.Logbook to related.
+// Adds o to related.R.ForwardingRules.
+func (o *ForwardingRule) SetLogbook(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Logbook) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"forwarding_rule\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, []string{"logbook_id"}),
+		strmangle.WhereClause("\"", "\"", 0, forwardingRulePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.LogbookID = related.ID
+	if o.R == nil {
+		o.R = &forwardingRuleR{
+			Logbook: related,
+		}
+	} else {
+		o.R.Logbook = related
+	}
+
+	if related.R == nil {
+		related.R = &logbookR{
+			ForwardingRules: ForwardingRuleSlice{o},
+		}
+	} else {
+		related.R.ForwardingRules = append(related.R.ForwardingRules, o)
+	}
+
+	return nil
+}
+
+// ForwardingRules retrieves all the records using an executor.
+func ForwardingRules(mods ...qm.QueryMod) forwardingRuleQuery {
+	mods = append(mods, qm.From("\"forwarding_rule\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"forwarding_rule\".*"})
+	}
+
+	return forwardingRuleQuery{q}
+}
+
+// FindForwardingRule retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindForwardingRule(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*ForwardingRule, error) {
+	forwardingRuleObj := &ForwardingRule{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"forwarding_rule\" where \"id\"=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, forwardingRuleObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from forwarding_rule")
+	}
+
+	return forwardingRuleObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *ForwardingRule) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no forwarding_rule provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(forwardingRuleColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	forwardingRuleInsertCacheMut.RLock()
+	cache, cached := forwardingRuleInsertCache[key]
+	forwardingRuleInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			forwardingRuleAllColumns,
+			forwardingRuleColumnsWithDefault,
+			forwardingRuleColumnsWithoutDefault,
+			nzDefaults,
+		)
+		wl = strmangle.SetComplement(wl, forwardingRuleGeneratedColumns)
+
+		cache.valueMapping, err = queries.BindMapping(forwardingRuleType, forwardingRuleMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(forwardingRuleType, forwardingRuleMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"forwarding_rule\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"forwarding_rule\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into forwarding_rule")
+	}
+
+	if !cached {
+		forwardingRuleInsertCacheMut.Lock()
+		forwardingRuleInsertCache[key] = cache
+		forwardingRuleInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the ForwardingRule.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *ForwardingRule) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	key := makeCacheKey(columns, nil)
+	forwardingRuleUpdateCacheMut.RLock()
+	cache, cached := forwardingRuleUpdateCache[key]
+	forwardingRuleUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			forwardingRuleAllColumns,
+			forwardingRulePrimaryKeyColumns,
+		)
+		wl = strmangle.SetComplement(wl, forwardingRuleGeneratedColumns)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update forwarding_rule, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"forwarding_rule\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, forwardingRulePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(forwardingRuleType, forwardingRuleMapping, append(wl, forwardingRulePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update forwarding_rule row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for forwarding_rule")
+	}
+
+	if !cached {
+		forwardingRuleUpdateCacheMut.Lock()
+		forwardingRuleUpdateCache[key] = cache
+		forwardingRuleUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q forwardingRuleQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for forwarding_rule")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for forwarding_rule")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ForwardingRuleSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), forwardingRulePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"forwarding_rule\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, forwardingRulePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in forwardingRule slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all forwardingRule")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *ForwardingRule) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no forwarding_rule provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(forwardingRuleColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	forwardingRuleUpsertCacheMut.RLock()
+	cache, cached := forwardingRuleUpsertCache[key]
+	forwardingRuleUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			forwardingRuleAllColumns,
+			forwardingRuleColumnsWithDefault,
+			forwardingRuleColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			forwardingRuleAllColumns,
+			forwardingRulePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert forwarding_rule, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(forwardingRuleAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(forwardingRulePrimaryKeyColumns))
+			copy(conflict, forwardingRulePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQuerySQLite(dialect, "\"forwarding_rule\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(forwardingRuleType, forwardingRuleMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(forwardingRuleType, forwardingRuleMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert forwarding_rule")
+	}
+
+	if !cached {
+		forwardingRuleUpsertCacheMut.Lock()
+		forwardingRuleUpsertCache[key] = cache
+		forwardingRuleUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single ForwardingRule record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *ForwardingRule) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no ForwardingRule provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), forwardingRulePrimaryKeyMapping)
+	sql := "DELETE FROM \"forwarding_rule\" WHERE \"id\"=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from forwarding_rule")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for forwarding_rule")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q forwardingRuleQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no forwardingRuleQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from forwarding_rule")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for forwarding_rule")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ForwardingRuleSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), forwardingRulePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"forwarding_rule\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, forwardingRulePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from forwardingRule slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for forwarding_rule")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *ForwardingRule) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindForwardingRule(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ForwardingRuleSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ForwardingRuleSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), forwardingRulePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"forwarding_rule\".* FROM \"forwarding_rule\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, forwardingRulePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ForwardingRuleSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ForwardingRuleExists checks if the ForwardingRule row exists.
+func ForwardingRuleExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"forwarding_rule\" where \"id\"=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if forwarding_rule exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the ForwardingRule row exists.
+func (o *ForwardingRule) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return ForwardingRuleExists(ctx, exec, o.ID)
+}
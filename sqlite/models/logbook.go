@@ -79,50 +79,6 @@ var LogbookTableColumns = struct {
 
 // Generated where
 
-type whereHelpernull_String struct{ field string }
-
-func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-func (w whereHelpernull_String) LIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" LIKE ?", x)
-}
-func (w whereHelpernull_String) NLIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT LIKE ?", x)
-}
-func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
 var LogbookWhere = struct {
 	ID          whereHelperint64
 	CreatedAt   whereHelpertime_Time
@@ -145,14 +101,17 @@ var LogbookWhere = struct {
 
 // LogbookRels is where relationship names are stored.
 var LogbookRels = struct {
-	Qsos string
+	ForwardingRules string
+	Qsos            string
 }{
-	Qsos: "Qsos",
+	ForwardingRules: "ForwardingRules",
+	Qsos:            "Qsos",
 }
 
 // logbookR is where relationships are stored.
 type logbookR struct {
-	Qsos QsoSlice `boil:"Qsos" json:"Qsos" toml:"Qsos" yaml:"Qsos"`
+	ForwardingRules ForwardingRuleSlice `boil:"ForwardingRules" json:"ForwardingRules" toml:"ForwardingRules" yaml:"ForwardingRules"`
+	Qsos            QsoSlice            `boil:"Qsos" json:"Qsos" toml:"Qsos" yaml:"Qsos"`
 }
 
 // NewStruct creates a new relationship struct
@@ -160,6 +119,22 @@ func (*logbookR) NewStruct() *logbookR {
 	return &logbookR{}
 }
 
+func (o *Logbook) GetForwardingRules() ForwardingRuleSlice {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetForwardingRules()
+}
+
+func (r *logbookR) GetForwardingRules() ForwardingRuleSlice {
+	if r == nil {
+		return nil
+	}
+
+	return r.ForwardingRules
+}
+
 func (o *Logbook) GetQsos() QsoSlice {
 	if o == nil {
 		return nil
@@ -278,6 +253,20 @@ func (q logbookQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bo
 	return count > 0, nil
 }
 
+// ForwardingRules retrieves all the forwarding_rule's ForwardingRules with an executor.
+func (o *Logbook) ForwardingRules(mods ...qm.QueryMod) forwardingRuleQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"forwarding_rule\".\"logbook_id\"=?", o.ID),
+	)
+
+	return ForwardingRules(queryMods...)
+}
+
 // Qsos retrieves all the qso's Qsos with an executor.
 func (o *Logbook) Qsos(mods ...qm.QueryMod) qsoQuery {
 	var queryMods []qm.QueryMod
@@ -292,6 +281,112 @@ func (o *Logbook) Qsos(mods ...qm.QueryMod) qsoQuery {
 	return Qsos(queryMods...)
 }
 
+// LoadForwardingRules allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (logbookL) LoadForwardingRules(ctx context.Context, e boil.ContextExecutor, singular bool, maybeLogbook interface{}, mods queries.Applicator) error {
+	var slice []*Logbook
+	var object *Logbook
+
+	if singular {
+		var ok bool
+		object, ok = maybeLogbook.(*Logbook)
+		if !ok {
+			object = new(Logbook)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeLogbook)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeLogbook))
+			}
+		}
+	} else {
+		s, ok := maybeLogbook.(*[]*Logbook)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeLogbook)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeLogbook))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &logbookR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &logbookR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`forwarding_rule`),
+		qm.WhereIn(`forwarding_rule.logbook_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load forwarding_rule")
+	}
+
+	var resultSlice []*ForwardingRule
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice forwarding_rule")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on forwarding_rule")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for forwarding_rule")
+	}
+
+	if singular {
+		object.R.ForwardingRules = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &forwardingRuleR{}
+			}
+			foreign.R.Logbook = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.LogbookID {
+				local.R.ForwardingRules = append(local.R.ForwardingRules, foreign)
+				if foreign.R == nil {
+					foreign.R = &forwardingRuleR{}
+				}
+				foreign.R.Logbook = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadQsos allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (logbookL) LoadQsos(ctx context.Context, e boil.ContextExecutor, singular bool, maybeLogbook interface{}, mods queries.Applicator) error {
@@ -399,6 +494,59 @@ func (logbookL) LoadQsos(ctx context.Context, e boil.ContextExecutor, singular b
 	return nil
 }
 
+// AddForwardingRules adds the given related objects to the existing relationships
+// of the logbook, optionally inserting them as new records.
+// Appends related to o.R.ForwardingRules.
+// Sets related.R.Logbook appropriately.
+func (o *Logbook) AddForwardingRules(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*ForwardingRule) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.LogbookID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"forwarding_rule\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 0, []string{"logbook_id"}),
+				strmangle.WhereClause("\"", "\"", 0, forwardingRulePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.LogbookID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &logbookR{
+			ForwardingRules: related,
+		}
+	} else {
+		o.R.ForwardingRules = append(o.R.ForwardingRules, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &forwardingRuleR{
+				Logbook: o,
+			}
+		} else {
+			rel.R.Logbook = o
+		}
+	}
+	return nil
+}
+
 // AddQsos adds the given related objects to the existing relationships
 // of the logbook, optionally inserting them as new records.
 // Appends related to o.R.Qsos.
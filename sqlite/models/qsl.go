@@ -0,0 +1,927 @@
+// Code generated by SQLBoiler 4.19.5 (https://github.com/aarondl/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/aarondl/sqlboiler/v4/queries/qmhelper"
+	"github.com/aarondl/strmangle"
+	"github.com/friendsofgo/errors"
+)
+
+// Qsl is an object representing the database table.
+type Qsl struct {
+	ID         int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt  time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ModifiedAt null.Time   `boil:"modified_at" json:"modified_at,omitempty" toml:"modified_at" yaml:"modified_at,omitempty"`
+	QsoID      int64       `boil:"qso_id" json:"qso_id" toml:"qso_id" yaml:"qso_id"`
+	Channel    string      `boil:"channel" json:"channel" toml:"channel" yaml:"channel"`
+	SentStatus string      `boil:"sent_status" json:"sent_status" toml:"sent_status" yaml:"sent_status"`
+	SentDate   null.String `boil:"sent_date" json:"sent_date,omitempty" toml:"sent_date" yaml:"sent_date,omitempty"`
+	RcvdStatus string      `boil:"rcvd_status" json:"rcvd_status" toml:"rcvd_status" yaml:"rcvd_status"`
+	RcvdDate   null.String `boil:"rcvd_date" json:"rcvd_date,omitempty" toml:"rcvd_date" yaml:"rcvd_date,omitempty"`
+
+	R *qslR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L qslL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var QslColumns = struct {
+	ID         string
+	CreatedAt  string
+	ModifiedAt string
+	QsoID      string
+	Channel    string
+	SentStatus string
+	SentDate   string
+	RcvdStatus string
+	RcvdDate   string
+}{
+	ID:         "id",
+	CreatedAt:  "created_at",
+	ModifiedAt: "modified_at",
+	QsoID:      "qso_id",
+	Channel:    "channel",
+	SentStatus: "sent_status",
+	SentDate:   "sent_date",
+	RcvdStatus: "rcvd_status",
+	RcvdDate:   "rcvd_date",
+}
+
+var QslTableColumns = struct {
+	ID         string
+	CreatedAt  string
+	ModifiedAt string
+	QsoID      string
+	Channel    string
+	SentStatus string
+	SentDate   string
+	RcvdStatus string
+	RcvdDate   string
+}{
+	ID:         "qsl.id",
+	CreatedAt:  "qsl.created_at",
+	ModifiedAt: "qsl.modified_at",
+	QsoID:      "qsl.qso_id",
+	Channel:    "qsl.channel",
+	SentStatus: "qsl.sent_status",
+	SentDate:   "qsl.sent_date",
+	RcvdStatus: "qsl.rcvd_status",
+	RcvdDate:   "qsl.rcvd_date",
+}
+
+// Generated where
+
+var QslWhere = struct {
+	ID         whereHelperint64
+	CreatedAt  whereHelpertime_Time
+	ModifiedAt whereHelpernull_Time
+	QsoID      whereHelperint64
+	Channel    whereHelperstring
+	SentStatus whereHelperstring
+	SentDate   whereHelpernull_String
+	RcvdStatus whereHelperstring
+	RcvdDate   whereHelpernull_String
+}{
+	ID:         whereHelperint64{field: "\"qsl\".\"id\""},
+	CreatedAt:  whereHelpertime_Time{field: "\"qsl\".\"created_at\""},
+	ModifiedAt: whereHelpernull_Time{field: "\"qsl\".\"modified_at\""},
+	QsoID:      whereHelperint64{field: "\"qsl\".\"qso_id\""},
+	Channel:    whereHelperstring{field: "\"qsl\".\"channel\""},
+	SentStatus: whereHelperstring{field: "\"qsl\".\"sent_status\""},
+	SentDate:   whereHelpernull_String{field: "\"qsl\".\"sent_date\""},
+	RcvdStatus: whereHelperstring{field: "\"qsl\".\"rcvd_status\""},
+	RcvdDate:   whereHelpernull_String{field: "\"qsl\".\"rcvd_date\""},
+}
+
+// QslRels is where relationship names are stored.
+var QslRels = struct {
+	Qso string
+}{
+	Qso: "Qso",
+}
+
+// qslR is where relationships are stored.
+type qslR struct {
+	Qso *Qso `boil:"Qso" json:"Qso" toml:"Qso" yaml:"Qso"`
+}
+
+// NewStruct creates a new relationship struct
+func (*qslR) NewStruct() *qslR {
+	return &qslR{}
+}
+
+func (o *Qsl) GetQso() *Qso {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetQso()
+}
+
+func (r *qslR) GetQso() *Qso {
+	if r == nil {
+		return nil
+	}
+
+	return r.Qso
+}
+
+// qslL is where Load methods for each relationship are stored.
+type qslL struct{}
+
+var (
+	qslAllColumns            = []string{"id", "created_at", "modified_at", "qso_id", "channel", "sent_status", "sent_date", "rcvd_status", "rcvd_date"}
+	qslColumnsWithoutDefault = []string{"qso_id", "channel"}
+	qslColumnsWithDefault    = []string{"id", "created_at", "modified_at", "sent_status", "sent_date", "rcvd_status", "rcvd_date"}
+	qslPrimaryKeyColumns     = []string{"id"}
+	qslGeneratedColumns      = []string{"id"}
+)
+
+type (
+	// QslSlice is an alias for a slice of pointers to Qsl.
+	// This should almost always be used instead of []Qsl.
+	QslSlice []*Qsl
+
+	qslQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	qslType                 = reflect.TypeOf(&Qsl{})
+	qslMapping              = queries.MakeStructMapping(qslType)
+	qslPrimaryKeyMapping, _ = queries.BindMapping(qslType, qslMapping, qslPrimaryKeyColumns)
+	qslInsertCacheMut       sync.RWMutex
+	qslInsertCache          = make(map[string]insertCache)
+	qslUpdateCacheMut       sync.RWMutex
+	qslUpdateCache          = make(map[string]updateCache)
+	qslUpsertCacheMut       sync.RWMutex
+	qslUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single qsl record from the query.
+func (q qslQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Qsl, error) {
+	o := &Qsl{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for qsl")
+	}
+
+	return o, nil
+}
+
+// All returns all Qsl records from the query.
+func (q qslQuery) All(ctx context.Context, exec boil.ContextExecutor) (QslSlice, error) {
+	var o []*Qsl
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Qsl slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Qsl records in the query.
+func (q qslQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count qsl rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q qslQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if qsl exists")
+	}
+
+	return count > 0, nil
+}
+
+// Qso pointed to by the foreign key.
+func (o *Qsl) Qso(mods ...qm.QueryMod) qsoQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.QsoID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Qsos(queryMods...)
+}
+
+// LoadQso allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (qslL) LoadQso(ctx context.Context, e boil.ContextExecutor, singular bool, maybeQsl interface{}, mods queries.Applicator) error {
+	var slice []*Qsl
+	var object *Qsl
+
+	if singular {
+		var ok bool
+		object, ok = maybeQsl.(*Qsl)
+		if !ok {
+			object = new(Qsl)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeQsl)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeQsl))
+			}
+		}
+	} else {
+		s, ok := maybeQsl.(*[]*Qsl)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeQsl)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeQsl))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &qslR{}
+		}
+		args[object.QsoID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &qslR{}
+			}
+
+			args[obj.QsoID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`qso`),
+		qm.WhereIn(`qso.id in ?`, argsSlice...),
+		qmhelper.WhereIsNull(`qso.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Qso")
+	}
+
+	var resultSlice []*Qso
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Qso")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for qso")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for qso")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Qso = foreign
+		if foreign.R == nil {
+			foreign.R = &qsoR{}
+		}
+		foreign.R.QSLS = append(foreign.R.QSLS, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.QsoID == foreign.ID {
+				local.R.Qso = foreign
+				if foreign.R == nil {
+					foreign.R = &qsoR{}
+				}
+				foreign.R.QSLS = append(foreign.R.QSLS, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetQso of the qsl to the related item.
+// Sets o.R.Qso to related.
+// Adds o to related.R.QSLS.
+func (o *Qsl) SetQso(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Qso) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"qsl\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, []string{"qso_id"}),
+		strmangle.WhereClause("\"", "\"", 0, qslPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.QsoID = related.ID
+	if o.R == nil {
+		o.R = &qslR{
+			Qso: related,
+		}
+	} else {
+		o.R.Qso = related
+	}
+
+	if related.R == nil {
+		related.R = &qsoR{
+			QSLS: QslSlice{o},
+		}
+	} else {
+		related.R.QSLS = append(related.R.QSLS, o)
+	}
+
+	return nil
+}
+
+// Qsls retrieves all the records using an executor.
+func Qsls(mods ...qm.QueryMod) qslQuery {
+	mods = append(mods, qm.From("\"qsl\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"qsl\".*"})
+	}
+
+	return qslQuery{q}
+}
+
+// FindQsl retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindQsl(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*Qsl, error) {
+	qslObj := &Qsl{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"qsl\" where \"id\"=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, qslObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from qsl")
+	}
+
+	return qslObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Qsl) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no qsl provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(qslColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	qslInsertCacheMut.RLock()
+	cache, cached := qslInsertCache[key]
+	qslInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			qslAllColumns,
+			qslColumnsWithDefault,
+			qslColumnsWithoutDefault,
+			nzDefaults,
+		)
+		wl = strmangle.SetComplement(wl, qslGeneratedColumns)
+
+		cache.valueMapping, err = queries.BindMapping(qslType, qslMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(qslType, qslMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"qsl\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"qsl\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into qsl")
+	}
+
+	if !cached {
+		qslInsertCacheMut.Lock()
+		qslInsertCache[key] = cache
+		qslInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the Qsl.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Qsl) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	key := makeCacheKey(columns, nil)
+	qslUpdateCacheMut.RLock()
+	cache, cached := qslUpdateCache[key]
+	qslUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			qslAllColumns,
+			qslPrimaryKeyColumns,
+		)
+		wl = strmangle.SetComplement(wl, qslGeneratedColumns)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update qsl, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"qsl\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, qslPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(qslType, qslMapping, append(wl, qslPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update qsl row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for qsl")
+	}
+
+	if !cached {
+		qslUpdateCacheMut.Lock()
+		qslUpdateCache[key] = cache
+		qslUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q qslQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for qsl")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for qsl")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o QslSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), qslPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"qsl\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, qslPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in qsl slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all qsl")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Qsl) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no qsl provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(qslColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	qslUpsertCacheMut.RLock()
+	cache, cached := qslUpsertCache[key]
+	qslUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			qslAllColumns,
+			qslColumnsWithDefault,
+			qslColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			qslAllColumns,
+			qslPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert qsl, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(qslAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(qslPrimaryKeyColumns))
+			copy(conflict, qslPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQuerySQLite(dialect, "\"qsl\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(qslType, qslMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(qslType, qslMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert qsl")
+	}
+
+	if !cached {
+		qslUpsertCacheMut.Lock()
+		qslUpsertCache[key] = cache
+		qslUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single Qsl record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Qsl) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Qsl provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), qslPrimaryKeyMapping)
+	sql := "DELETE FROM \"qsl\" WHERE \"id\"=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from qsl")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for qsl")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q qslQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no qslQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from qsl")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for qsl")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o QslSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), qslPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"qsl\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, qslPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from qsl slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for qsl")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Qsl) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindQsl(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *QslSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := QslSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), qslPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"qsl\".* FROM \"qsl\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, qslPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in QslSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// QslExists checks if the Qsl row exists.
+func QslExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"qsl\" where \"id\"=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if qsl exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Qsl row exists.
+func (o *Qsl) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return QslExists(ctx, exec, o.ID)
+}
@@ -25,160 +25,218 @@ import (
 
 // Qso is an object representing the database table.
 type Qso struct {
-	ID             int64      `boil:"id" json:"id" toml:"id" yaml:"id"`
-	CreatedAt      time.Time  `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	ModifiedAt     null.Time  `boil:"modified_at" json:"modified_at,omitempty" toml:"modified_at" yaml:"modified_at,omitempty"`
-	DeletedAt      null.Time  `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
-	Call           string     `boil:"call" json:"call" toml:"call" yaml:"call"`
-	Band           string     `boil:"band" json:"band" toml:"band" yaml:"band"`
-	Mode           string     `boil:"mode" json:"mode" toml:"mode" yaml:"mode"`
-	Freq           int64      `boil:"freq" json:"freq" toml:"freq" yaml:"freq"`
-	QsoDate        string     `boil:"qso_date" json:"qso_date" toml:"qso_date" yaml:"qso_date"`
-	TimeOn         string     `boil:"time_on" json:"time_on" toml:"time_on" yaml:"time_on"`
-	TimeOff        string     `boil:"time_off" json:"time_off" toml:"time_off" yaml:"time_off"`
-	RstSent        string     `boil:"rst_sent" json:"rst_sent" toml:"rst_sent" yaml:"rst_sent"`
-	RstRcvd        string     `boil:"rst_rcvd" json:"rst_rcvd" toml:"rst_rcvd" yaml:"rst_rcvd"`
-	Country        string     `boil:"country" json:"country" toml:"country" yaml:"country"`
-	AdditionalData types.JSON `boil:"additional_data" json:"additional_data" toml:"additional_data" yaml:"additional_data"`
-	LogbookID      int64      `boil:"logbook_id" json:"logbook_id" toml:"logbook_id" yaml:"logbook_id"`
-	SessionID      int64      `boil:"session_id" json:"session_id" toml:"session_id" yaml:"session_id"`
+	ID                 int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt          time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ModifiedAt         null.Time   `boil:"modified_at" json:"modified_at,omitempty" toml:"modified_at" yaml:"modified_at,omitempty"`
+	DeletedAt          null.Time   `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	Call               string      `boil:"call" json:"call" toml:"call" yaml:"call"`
+	Band               string      `boil:"band" json:"band" toml:"band" yaml:"band"`
+	Mode               string      `boil:"mode" json:"mode" toml:"mode" yaml:"mode"`
+	Freq               int64       `boil:"freq" json:"freq" toml:"freq" yaml:"freq"`
+	QsoDate            string      `boil:"qso_date" json:"qso_date" toml:"qso_date" yaml:"qso_date"`
+	TimeOn             string      `boil:"time_on" json:"time_on" toml:"time_on" yaml:"time_on"`
+	TimeOff            string      `boil:"time_off" json:"time_off" toml:"time_off" yaml:"time_off"`
+	RstSent            string      `boil:"rst_sent" json:"rst_sent" toml:"rst_sent" yaml:"rst_sent"`
+	RstRcvd            string      `boil:"rst_rcvd" json:"rst_rcvd" toml:"rst_rcvd" yaml:"rst_rcvd"`
+	Country            string      `boil:"country" json:"country" toml:"country" yaml:"country"`
+	AdditionalData     types.JSON  `boil:"additional_data" json:"additional_data" toml:"additional_data" yaml:"additional_data"`
+	LogbookID          int64       `boil:"logbook_id" json:"logbook_id" toml:"logbook_id" yaml:"logbook_id"`
+	SessionID          int64       `boil:"session_id" json:"session_id" toml:"session_id" yaml:"session_id"`
+	Gridsquare         null.String `boil:"gridsquare" json:"gridsquare,omitempty" toml:"gridsquare" yaml:"gridsquare,omitempty"`
+	ContestID          null.String `boil:"contest_id" json:"contest_id,omitempty" toml:"contest_id" yaml:"contest_id,omitempty"`
+	StationCallsign    null.String `boil:"station_callsign" json:"station_callsign,omitempty" toml:"station_callsign" yaml:"station_callsign,omitempty"`
+	Operator           null.String `boil:"operator" json:"operator,omitempty" toml:"operator" yaml:"operator,omitempty"`
+	SigInfo            null.String `boil:"sig_info" json:"sig_info,omitempty" toml:"sig_info" yaml:"sig_info,omitempty"`
+	QrzComUploadStatus null.String `boil:"qrzcom_qso_upload_status" json:"qrzcom_qso_upload_status,omitempty" toml:"qrzcom_qso_upload_status" yaml:"qrzcom_qso_upload_status,omitempty"`
+	SmQsoUploadStatus  null.String `boil:"sm_qso_upload_status" json:"sm_qso_upload_status,omitempty" toml:"sm_qso_upload_status" yaml:"sm_qso_upload_status,omitempty"`
 
 	R *qsoR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L qsoL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var QsoColumns = struct {
-	ID             string
-	CreatedAt      string
-	ModifiedAt     string
-	DeletedAt      string
-	Call           string
-	Band           string
-	Mode           string
-	Freq           string
-	QsoDate        string
-	TimeOn         string
-	TimeOff        string
-	RstSent        string
-	RstRcvd        string
-	Country        string
-	AdditionalData string
-	LogbookID      string
-	SessionID      string
+	ID                 string
+	CreatedAt          string
+	ModifiedAt         string
+	DeletedAt          string
+	Call               string
+	Band               string
+	Mode               string
+	Freq               string
+	QsoDate            string
+	TimeOn             string
+	TimeOff            string
+	RstSent            string
+	RstRcvd            string
+	Country            string
+	AdditionalData     string
+	LogbookID          string
+	SessionID          string
+	Gridsquare         string
+	ContestID          string
+	StationCallsign    string
+	Operator           string
+	SigInfo            string
+	QrzComUploadStatus string
+	SmQsoUploadStatus  string
 }{
-	ID:             "id",
-	CreatedAt:      "created_at",
-	ModifiedAt:     "modified_at",
-	DeletedAt:      "deleted_at",
-	Call:           "call",
-	Band:           "band",
-	Mode:           "mode",
-	Freq:           "freq",
-	QsoDate:        "qso_date",
-	TimeOn:         "time_on",
-	TimeOff:        "time_off",
-	RstSent:        "rst_sent",
-	RstRcvd:        "rst_rcvd",
-	Country:        "country",
-	AdditionalData: "additional_data",
-	LogbookID:      "logbook_id",
-	SessionID:      "session_id",
+	ID:                 "id",
+	CreatedAt:          "created_at",
+	ModifiedAt:         "modified_at",
+	DeletedAt:          "deleted_at",
+	Call:               "call",
+	Band:               "band",
+	Mode:               "mode",
+	Freq:               "freq",
+	QsoDate:            "qso_date",
+	TimeOn:             "time_on",
+	TimeOff:            "time_off",
+	RstSent:            "rst_sent",
+	RstRcvd:            "rst_rcvd",
+	Country:            "country",
+	AdditionalData:     "additional_data",
+	LogbookID:          "logbook_id",
+	SessionID:          "session_id",
+	Gridsquare:         "gridsquare",
+	ContestID:          "contest_id",
+	StationCallsign:    "station_callsign",
+	Operator:           "operator",
+	SigInfo:            "sig_info",
+	QrzComUploadStatus: "qrzcom_qso_upload_status",
+	SmQsoUploadStatus:  "sm_qso_upload_status",
 }
 
 var QsoTableColumns = struct {
-	ID             string
-	CreatedAt      string
-	ModifiedAt     string
-	DeletedAt      string
-	Call           string
-	Band           string
-	Mode           string
-	Freq           string
-	QsoDate        string
-	TimeOn         string
-	TimeOff        string
-	RstSent        string
-	RstRcvd        string
-	Country        string
-	AdditionalData string
-	LogbookID      string
-	SessionID      string
+	ID                 string
+	CreatedAt          string
+	ModifiedAt         string
+	DeletedAt          string
+	Call               string
+	Band               string
+	Mode               string
+	Freq               string
+	QsoDate            string
+	TimeOn             string
+	TimeOff            string
+	RstSent            string
+	RstRcvd            string
+	Country            string
+	AdditionalData     string
+	LogbookID          string
+	SessionID          string
+	Gridsquare         string
+	ContestID          string
+	StationCallsign    string
+	Operator           string
+	SigInfo            string
+	QrzComUploadStatus string
+	SmQsoUploadStatus  string
 }{
-	ID:             "qso.id",
-	CreatedAt:      "qso.created_at",
-	ModifiedAt:     "qso.modified_at",
-	DeletedAt:      "qso.deleted_at",
-	Call:           "qso.call",
-	Band:           "qso.band",
-	Mode:           "qso.mode",
-	Freq:           "qso.freq",
-	QsoDate:        "qso.qso_date",
-	TimeOn:         "qso.time_on",
-	TimeOff:        "qso.time_off",
-	RstSent:        "qso.rst_sent",
-	RstRcvd:        "qso.rst_rcvd",
-	Country:        "qso.country",
-	AdditionalData: "qso.additional_data",
-	LogbookID:      "qso.logbook_id",
-	SessionID:      "qso.session_id",
+	ID:                 "qso.id",
+	CreatedAt:          "qso.created_at",
+	ModifiedAt:         "qso.modified_at",
+	DeletedAt:          "qso.deleted_at",
+	Call:               "qso.call",
+	Band:               "qso.band",
+	Mode:               "qso.mode",
+	Freq:               "qso.freq",
+	QsoDate:            "qso.qso_date",
+	TimeOn:             "qso.time_on",
+	TimeOff:            "qso.time_off",
+	RstSent:            "qso.rst_sent",
+	RstRcvd:            "qso.rst_rcvd",
+	Country:            "qso.country",
+	AdditionalData:     "qso.additional_data",
+	LogbookID:          "qso.logbook_id",
+	SessionID:          "qso.session_id",
+	Gridsquare:         "qso.gridsquare",
+	ContestID:          "qso.contest_id",
+	StationCallsign:    "qso.station_callsign",
+	Operator:           "qso.operator",
+	SigInfo:            "qso.sig_info",
+	QrzComUploadStatus: "qso.qrzcom_qso_upload_status",
+	SmQsoUploadStatus:  "qso.sm_qso_upload_status",
 }
 
 // Generated where
 
 var QsoWhere = struct {
-	ID             whereHelperint64
-	CreatedAt      whereHelpertime_Time
-	ModifiedAt     whereHelpernull_Time
-	DeletedAt      whereHelpernull_Time
-	Call           whereHelperstring
-	Band           whereHelperstring
-	Mode           whereHelperstring
-	Freq           whereHelperint64
-	QsoDate        whereHelperstring
-	TimeOn         whereHelperstring
-	TimeOff        whereHelperstring
-	RstSent        whereHelperstring
-	RstRcvd        whereHelperstring
-	Country        whereHelperstring
-	AdditionalData whereHelpertypes_JSON
-	LogbookID      whereHelperint64
-	SessionID      whereHelperint64
+	ID                 whereHelperint64
+	CreatedAt          whereHelpertime_Time
+	ModifiedAt         whereHelpernull_Time
+	DeletedAt          whereHelpernull_Time
+	Call               whereHelperstring
+	Band               whereHelperstring
+	Mode               whereHelperstring
+	Freq               whereHelperint64
+	QsoDate            whereHelperstring
+	TimeOn             whereHelperstring
+	TimeOff            whereHelperstring
+	RstSent            whereHelperstring
+	RstRcvd            whereHelperstring
+	Country            whereHelperstring
+	AdditionalData     whereHelpertypes_JSON
+	LogbookID          whereHelperint64
+	SessionID          whereHelperint64
+	Gridsquare         whereHelpernull_String
+	ContestID          whereHelpernull_String
+	StationCallsign    whereHelpernull_String
+	Operator           whereHelpernull_String
+	SigInfo            whereHelpernull_String
+	QrzComUploadStatus whereHelpernull_String
+	SmQsoUploadStatus  whereHelpernull_String
 }{
-	ID:             whereHelperint64{field: "\"qso\".\"id\""},
-	CreatedAt:      whereHelpertime_Time{field: "\"qso\".\"created_at\""},
-	ModifiedAt:     whereHelpernull_Time{field: "\"qso\".\"modified_at\""},
-	DeletedAt:      whereHelpernull_Time{field: "\"qso\".\"deleted_at\""},
-	Call:           whereHelperstring{field: "\"qso\".\"call\""},
-	Band:           whereHelperstring{field: "\"qso\".\"band\""},
-	Mode:           whereHelperstring{field: "\"qso\".\"mode\""},
-	Freq:           whereHelperint64{field: "\"qso\".\"freq\""},
-	QsoDate:        whereHelperstring{field: "\"qso\".\"qso_date\""},
-	TimeOn:         whereHelperstring{field: "\"qso\".\"time_on\""},
-	TimeOff:        whereHelperstring{field: "\"qso\".\"time_off\""},
-	RstSent:        whereHelperstring{field: "\"qso\".\"rst_sent\""},
-	RstRcvd:        whereHelperstring{field: "\"qso\".\"rst_rcvd\""},
-	Country:        whereHelperstring{field: "\"qso\".\"country\""},
-	AdditionalData: whereHelpertypes_JSON{field: "\"qso\".\"additional_data\""},
-	LogbookID:      whereHelperint64{field: "\"qso\".\"logbook_id\""},
-	SessionID:      whereHelperint64{field: "\"qso\".\"session_id\""},
+	ID:                 whereHelperint64{field: "\"qso\".\"id\""},
+	CreatedAt:          whereHelpertime_Time{field: "\"qso\".\"created_at\""},
+	ModifiedAt:         whereHelpernull_Time{field: "\"qso\".\"modified_at\""},
+	DeletedAt:          whereHelpernull_Time{field: "\"qso\".\"deleted_at\""},
+	Call:               whereHelperstring{field: "\"qso\".\"call\""},
+	Band:               whereHelperstring{field: "\"qso\".\"band\""},
+	Mode:               whereHelperstring{field: "\"qso\".\"mode\""},
+	Freq:               whereHelperint64{field: "\"qso\".\"freq\""},
+	QsoDate:            whereHelperstring{field: "\"qso\".\"qso_date\""},
+	TimeOn:             whereHelperstring{field: "\"qso\".\"time_on\""},
+	TimeOff:            whereHelperstring{field: "\"qso\".\"time_off\""},
+	RstSent:            whereHelperstring{field: "\"qso\".\"rst_sent\""},
+	RstRcvd:            whereHelperstring{field: "\"qso\".\"rst_rcvd\""},
+	Country:            whereHelperstring{field: "\"qso\".\"country\""},
+	AdditionalData:     whereHelpertypes_JSON{field: "\"qso\".\"additional_data\""},
+	LogbookID:          whereHelperint64{field: "\"qso\".\"logbook_id\""},
+	SessionID:          whereHelperint64{field: "\"qso\".\"session_id\""},
+	Gridsquare:         whereHelpernull_String{field: "\"qso\".\"gridsquare\""},
+	ContestID:          whereHelpernull_String{field: "\"qso\".\"contest_id\""},
+	StationCallsign:    whereHelpernull_String{field: "\"qso\".\"station_callsign\""},
+	Operator:           whereHelpernull_String{field: "\"qso\".\"operator\""},
+	SigInfo:            whereHelpernull_String{field: "\"qso\".\"sig_info\""},
+	QrzComUploadStatus: whereHelpernull_String{field: "\"qso\".\"qrzcom_qso_upload_status\""},
+	SmQsoUploadStatus:  whereHelpernull_String{field: "\"qso\".\"sm_qso_upload_status\""},
 }
 
 // QsoRels is where relationship names are stored.
 var QsoRels = struct {
-	Session    string
-	Logbook    string
-	QsoUploads string
+	Session           string
+	Logbook           string
+	QSLS              string
+	QsoRevisions      string
+	QsoUploads        string
+	QsoUploadAttempts string
 }{
-	Session:    "Session",
-	Logbook:    "Logbook",
-	QsoUploads: "QsoUploads",
+	Session:           "Session",
+	Logbook:           "Logbook",
+	QSLS:              "QSLS",
+	QsoRevisions:      "QsoRevisions",
+	QsoUploads:        "QsoUploads",
+	QsoUploadAttempts: "QsoUploadAttempts",
 }
 
 // qsoR is where relationships are stored.
 type qsoR struct {
-	Session    *Session       `boil:"Session" json:"Session" toml:"Session" yaml:"Session"`
-	Logbook    *Logbook       `boil:"Logbook" json:"Logbook" toml:"Logbook" yaml:"Logbook"`
-	QsoUploads QsoUploadSlice `boil:"QsoUploads" json:"QsoUploads" toml:"QsoUploads" yaml:"QsoUploads"`
+	Session           *Session              `boil:"Session" json:"Session" toml:"Session" yaml:"Session"`
+	Logbook           *Logbook              `boil:"Logbook" json:"Logbook" toml:"Logbook" yaml:"Logbook"`
+	QSLS              QslSlice              `boil:"QSLS" json:"QSLS" toml:"QSLS" yaml:"QSLS"`
+	QsoRevisions      QsoRevisionSlice      `boil:"QsoRevisions" json:"QsoRevisions" toml:"QsoRevisions" yaml:"QsoRevisions"`
+	QsoUploads        QsoUploadSlice        `boil:"QsoUploads" json:"QsoUploads" toml:"QsoUploads" yaml:"QsoUploads"`
+	QsoUploadAttempts QsoUploadAttemptSlice `boil:"QsoUploadAttempts" json:"QsoUploadAttempts" toml:"QsoUploadAttempts" yaml:"QsoUploadAttempts"`
 }
 
 // NewStruct creates a new relationship struct
@@ -218,6 +276,38 @@ func (r *qsoR) GetLogbook() *Logbook {
 	return r.Logbook
 }
 
+func (o *Qso) GetQSLS() QslSlice {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetQSLS()
+}
+
+func (r *qsoR) GetQSLS() QslSlice {
+	if r == nil {
+		return nil
+	}
+
+	return r.QSLS
+}
+
+func (o *Qso) GetQsoRevisions() QsoRevisionSlice {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetQsoRevisions()
+}
+
+func (r *qsoR) GetQsoRevisions() QsoRevisionSlice {
+	if r == nil {
+		return nil
+	}
+
+	return r.QsoRevisions
+}
+
 func (o *Qso) GetQsoUploads() QsoUploadSlice {
 	if o == nil {
 		return nil
@@ -234,15 +324,31 @@ func (r *qsoR) GetQsoUploads() QsoUploadSlice {
 	return r.QsoUploads
 }
 
+func (o *Qso) GetQsoUploadAttempts() QsoUploadAttemptSlice {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetQsoUploadAttempts()
+}
+
+func (r *qsoR) GetQsoUploadAttempts() QsoUploadAttemptSlice {
+	if r == nil {
+		return nil
+	}
+
+	return r.QsoUploadAttempts
+}
+
 // qsoL is where Load methods for each relationship are stored.
 type qsoL struct{}
 
 var (
-	qsoAllColumns            = []string{"id", "created_at", "modified_at", "deleted_at", "call", "band", "mode", "freq", "qso_date", "time_on", "time_off", "rst_sent", "rst_rcvd", "country", "additional_data", "logbook_id", "session_id"}
+	qsoAllColumns            = []string{"id", "created_at", "modified_at", "deleted_at", "call", "band", "mode", "freq", "qso_date", "time_on", "time_off", "rst_sent", "rst_rcvd", "country", "additional_data", "logbook_id", "session_id", "gridsquare", "contest_id", "station_callsign", "operator", "sig_info", "qrzcom_qso_upload_status", "sm_qso_upload_status"}
 	qsoColumnsWithoutDefault = []string{"call", "band", "mode", "freq", "qso_date", "time_on", "time_off", "rst_sent", "rst_rcvd", "country", "logbook_id", "session_id"}
-	qsoColumnsWithDefault    = []string{"id", "created_at", "modified_at", "deleted_at", "additional_data"}
+	qsoColumnsWithDefault    = []string{"id", "created_at", "modified_at", "deleted_at", "additional_data", "gridsquare", "contest_id", "station_callsign", "operator", "sig_info", "qrzcom_qso_upload_status", "sm_qso_upload_status"}
 	qsoPrimaryKeyColumns     = []string{"id"}
-	qsoGeneratedColumns      = []string{"id"}
+	qsoGeneratedColumns      = []string{"id", "gridsquare", "contest_id", "station_callsign", "operator", "sig_info", "qrzcom_qso_upload_status", "sm_qso_upload_status"}
 )
 
 type (
@@ -358,6 +464,34 @@ func (o *Qso) Logbook(mods ...qm.QueryMod) logbookQuery {
 	return Logbooks(queryMods...)
 }
 
+// QSLS retrieves all the qsl's Qsls with an executor via qso_id column.
+func (o *Qso) QSLS(mods ...qm.QueryMod) qslQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"qsl\".\"qso_id\"=?", o.ID),
+	)
+
+	return Qsls(queryMods...)
+}
+
+// QsoRevisions retrieves all the qso_revision's QsoRevisions with an executor.
+func (o *Qso) QsoRevisions(mods ...qm.QueryMod) qsoRevisionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"qso_revision\".\"qso_id\"=?", o.ID),
+	)
+
+	return QsoRevisions(queryMods...)
+}
+
 // QsoUploads retrieves all the qso_upload's QsoUploads with an executor.
 func (o *Qso) QsoUploads(mods ...qm.QueryMod) qsoUploadQuery {
 	var queryMods []qm.QueryMod
@@ -372,6 +506,20 @@ func (o *Qso) QsoUploads(mods ...qm.QueryMod) qsoUploadQuery {
 	return QsoUploads(queryMods...)
 }
 
+// QsoUploadAttempts retrieves all the qso_upload_attempt's QsoUploadAttempts with an executor.
+func (o *Qso) QsoUploadAttempts(mods ...qm.QueryMod) qsoUploadAttemptQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"qso_upload_attempt\".\"qso_id\"=?", o.ID),
+	)
+
+	return QsoUploadAttempts(queryMods...)
+}
+
 // LoadSession allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (qsoL) LoadSession(ctx context.Context, e boil.ContextExecutor, singular bool, maybeQso interface{}, mods queries.Applicator) error {
@@ -598,6 +746,218 @@ func (qsoL) LoadLogbook(ctx context.Context, e boil.ContextExecutor, singular bo
 	return nil
 }
 
+// LoadQSLS allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (qsoL) LoadQSLS(ctx context.Context, e boil.ContextExecutor, singular bool, maybeQso interface{}, mods queries.Applicator) error {
+	var slice []*Qso
+	var object *Qso
+
+	if singular {
+		var ok bool
+		object, ok = maybeQso.(*Qso)
+		if !ok {
+			object = new(Qso)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeQso)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeQso))
+			}
+		}
+	} else {
+		s, ok := maybeQso.(*[]*Qso)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeQso)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeQso))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &qsoR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &qsoR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`qsl`),
+		qm.WhereIn(`qsl.qso_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load qsl")
+	}
+
+	var resultSlice []*Qsl
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice qsl")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on qsl")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for qsl")
+	}
+
+	if singular {
+		object.R.QSLS = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &qslR{}
+			}
+			foreign.R.Qso = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.QsoID {
+				local.R.QSLS = append(local.R.QSLS, foreign)
+				if foreign.R == nil {
+					foreign.R = &qslR{}
+				}
+				foreign.R.Qso = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadQsoRevisions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (qsoL) LoadQsoRevisions(ctx context.Context, e boil.ContextExecutor, singular bool, maybeQso interface{}, mods queries.Applicator) error {
+	var slice []*Qso
+	var object *Qso
+
+	if singular {
+		var ok bool
+		object, ok = maybeQso.(*Qso)
+		if !ok {
+			object = new(Qso)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeQso)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeQso))
+			}
+		}
+	} else {
+		s, ok := maybeQso.(*[]*Qso)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeQso)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeQso))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &qsoR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &qsoR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`qso_revision`),
+		qm.WhereIn(`qso_revision.qso_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load qso_revision")
+	}
+
+	var resultSlice []*QsoRevision
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice qso_revision")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on qso_revision")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for qso_revision")
+	}
+
+	if singular {
+		object.R.QsoRevisions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &qsoRevisionR{}
+			}
+			foreign.R.Qso = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.QsoID {
+				local.R.QsoRevisions = append(local.R.QsoRevisions, foreign)
+				if foreign.R == nil {
+					foreign.R = &qsoRevisionR{}
+				}
+				foreign.R.Qso = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadQsoUploads allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (qsoL) LoadQsoUploads(ctx context.Context, e boil.ContextExecutor, singular bool, maybeQso interface{}, mods queries.Applicator) error {
@@ -704,6 +1064,112 @@ func (qsoL) LoadQsoUploads(ctx context.Context, e boil.ContextExecutor, singular
 	return nil
 }
 
+// LoadQsoUploadAttempts allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (qsoL) LoadQsoUploadAttempts(ctx context.Context, e boil.ContextExecutor, singular bool, maybeQso interface{}, mods queries.Applicator) error {
+	var slice []*Qso
+	var object *Qso
+
+	if singular {
+		var ok bool
+		object, ok = maybeQso.(*Qso)
+		if !ok {
+			object = new(Qso)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeQso)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeQso))
+			}
+		}
+	} else {
+		s, ok := maybeQso.(*[]*Qso)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeQso)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeQso))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &qsoR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &qsoR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`qso_upload_attempt`),
+		qm.WhereIn(`qso_upload_attempt.qso_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load qso_upload_attempt")
+	}
+
+	var resultSlice []*QsoUploadAttempt
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice qso_upload_attempt")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on qso_upload_attempt")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for qso_upload_attempt")
+	}
+
+	if singular {
+		object.R.QsoUploadAttempts = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &qsoUploadAttemptR{}
+			}
+			foreign.R.Qso = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.QsoID {
+				local.R.QsoUploadAttempts = append(local.R.QsoUploadAttempts, foreign)
+				if foreign.R == nil {
+					foreign.R = &qsoUploadAttemptR{}
+				}
+				foreign.R.Qso = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // SetSession of the qso to the related item.
 // Sets o.R.Session to related.
 // Adds o to related.R.Qsos.
@@ -798,6 +1264,112 @@ func (o *Qso) SetLogbook(ctx context.Context, exec boil.ContextExecutor, insert
 	return nil
 }
 
+// AddQSLS adds the given related objects to the existing relationships
+// of the qso, optionally inserting them as new records.
+// Appends related to o.R.QSLS.
+// Sets related.R.Qso appropriately.
+func (o *Qso) AddQSLS(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Qsl) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.QsoID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"qsl\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 0, []string{"qso_id"}),
+				strmangle.WhereClause("\"", "\"", 0, qslPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.QsoID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &qsoR{
+			QSLS: related,
+		}
+	} else {
+		o.R.QSLS = append(o.R.QSLS, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &qslR{
+				Qso: o,
+			}
+		} else {
+			rel.R.Qso = o
+		}
+	}
+	return nil
+}
+
+// AddQsoRevisions adds the given related objects to the existing relationships
+// of the qso, optionally inserting them as new records.
+// Appends related to o.R.QsoRevisions.
+// Sets related.R.Qso appropriately.
+func (o *Qso) AddQsoRevisions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*QsoRevision) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.QsoID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"qso_revision\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 0, []string{"qso_id"}),
+				strmangle.WhereClause("\"", "\"", 0, qsoRevisionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.QsoID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &qsoR{
+			QsoRevisions: related,
+		}
+	} else {
+		o.R.QsoRevisions = append(o.R.QsoRevisions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &qsoRevisionR{
+				Qso: o,
+			}
+		} else {
+			rel.R.Qso = o
+		}
+	}
+	return nil
+}
+
 // AddQsoUploads adds the given related objects to the existing relationships
 // of the qso, optionally inserting them as new records.
 // Appends related to o.R.QsoUploads.
@@ -851,6 +1423,59 @@ func (o *Qso) AddQsoUploads(ctx context.Context, exec boil.ContextExecutor, inse
 	return nil
 }
 
+// AddQsoUploadAttempts adds the given related objects to the existing relationships
+// of the qso, optionally inserting them as new records.
+// Appends related to o.R.QsoUploadAttempts.
+// Sets related.R.Qso appropriately.
+func (o *Qso) AddQsoUploadAttempts(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*QsoUploadAttempt) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.QsoID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"qso_upload_attempt\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 0, []string{"qso_id"}),
+				strmangle.WhereClause("\"", "\"", 0, qsoUploadAttemptPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.QsoID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &qsoR{
+			QsoUploadAttempts: related,
+		}
+	} else {
+		o.R.QsoUploadAttempts = append(o.R.QsoUploadAttempts, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &qsoUploadAttemptR{
+				Qso: o,
+			}
+		} else {
+			rel.R.Qso = o
+		}
+	}
+	return nil
+}
+
 // Qsos retrieves all the records using an executor.
 func Qsos(mods ...qm.QueryMod) qsoQuery {
 	mods = append(mods, qm.From("\"qso\""), qmhelper.WhereIsNull("\"qso\".\"deleted_at\""))
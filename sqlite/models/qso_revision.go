@@ -0,0 +1,1012 @@
+// Code generated by SQLBoiler 4.19.5 (https://github.com/aarondl/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/aarondl/sqlboiler/v4/queries/qmhelper"
+	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/aarondl/strmangle"
+	"github.com/friendsofgo/errors"
+)
+
+// QsoRevision is an object representing the database table.
+type QsoRevision struct {
+	ID             int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt      time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	QsoID          int64       `boil:"qso_id" json:"qso_id" toml:"qso_id" yaml:"qso_id"`
+	Action         string      `boil:"action" json:"action" toml:"action" yaml:"action"`
+	Reason         null.String `boil:"reason" json:"reason,omitempty" toml:"reason" yaml:"reason,omitempty"`
+	QsoCreatedAt   time.Time   `boil:"qso_created_at" json:"qso_created_at" toml:"qso_created_at" yaml:"qso_created_at"`
+	QsoModifiedAt  null.Time   `boil:"qso_modified_at" json:"qso_modified_at,omitempty" toml:"qso_modified_at" yaml:"qso_modified_at,omitempty"`
+	QsoDeletedAt   null.Time   `boil:"qso_deleted_at" json:"qso_deleted_at,omitempty" toml:"qso_deleted_at" yaml:"qso_deleted_at,omitempty"`
+	Call           string      `boil:"call" json:"call" toml:"call" yaml:"call"`
+	Band           string      `boil:"band" json:"band" toml:"band" yaml:"band"`
+	Mode           string      `boil:"mode" json:"mode" toml:"mode" yaml:"mode"`
+	Freq           int64       `boil:"freq" json:"freq" toml:"freq" yaml:"freq"`
+	QsoDate        string      `boil:"qso_date" json:"qso_date" toml:"qso_date" yaml:"qso_date"`
+	TimeOn         string      `boil:"time_on" json:"time_on" toml:"time_on" yaml:"time_on"`
+	TimeOff        string      `boil:"time_off" json:"time_off" toml:"time_off" yaml:"time_off"`
+	RstSent        string      `boil:"rst_sent" json:"rst_sent" toml:"rst_sent" yaml:"rst_sent"`
+	RstRcvd        string      `boil:"rst_rcvd" json:"rst_rcvd" toml:"rst_rcvd" yaml:"rst_rcvd"`
+	Country        string      `boil:"country" json:"country" toml:"country" yaml:"country"`
+	AdditionalData types.JSON  `boil:"additional_data" json:"additional_data" toml:"additional_data" yaml:"additional_data"`
+	LogbookID      int64       `boil:"logbook_id" json:"logbook_id" toml:"logbook_id" yaml:"logbook_id"`
+	SessionID      int64       `boil:"session_id" json:"session_id" toml:"session_id" yaml:"session_id"`
+
+	R *qsoRevisionR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L qsoRevisionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var QsoRevisionColumns = struct {
+	ID             string
+	CreatedAt      string
+	QsoID          string
+	Action         string
+	Reason         string
+	QsoCreatedAt   string
+	QsoModifiedAt  string
+	QsoDeletedAt   string
+	Call           string
+	Band           string
+	Mode           string
+	Freq           string
+	QsoDate        string
+	TimeOn         string
+	TimeOff        string
+	RstSent        string
+	RstRcvd        string
+	Country        string
+	AdditionalData string
+	LogbookID      string
+	SessionID      string
+}{
+	ID:             "id",
+	CreatedAt:      "created_at",
+	QsoID:          "qso_id",
+	Action:         "action",
+	Reason:         "reason",
+	QsoCreatedAt:   "qso_created_at",
+	QsoModifiedAt:  "qso_modified_at",
+	QsoDeletedAt:   "qso_deleted_at",
+	Call:           "call",
+	Band:           "band",
+	Mode:           "mode",
+	Freq:           "freq",
+	QsoDate:        "qso_date",
+	TimeOn:         "time_on",
+	TimeOff:        "time_off",
+	RstSent:        "rst_sent",
+	RstRcvd:        "rst_rcvd",
+	Country:        "country",
+	AdditionalData: "additional_data",
+	LogbookID:      "logbook_id",
+	SessionID:      "session_id",
+}
+
+var QsoRevisionTableColumns = struct {
+	ID             string
+	CreatedAt      string
+	QsoID          string
+	Action         string
+	Reason         string
+	QsoCreatedAt   string
+	QsoModifiedAt  string
+	QsoDeletedAt   string
+	Call           string
+	Band           string
+	Mode           string
+	Freq           string
+	QsoDate        string
+	TimeOn         string
+	TimeOff        string
+	RstSent        string
+	RstRcvd        string
+	Country        string
+	AdditionalData string
+	LogbookID      string
+	SessionID      string
+}{
+	ID:             "qso_revision.id",
+	CreatedAt:      "qso_revision.created_at",
+	QsoID:          "qso_revision.qso_id",
+	Action:         "qso_revision.action",
+	Reason:         "qso_revision.reason",
+	QsoCreatedAt:   "qso_revision.qso_created_at",
+	QsoModifiedAt:  "qso_revision.qso_modified_at",
+	QsoDeletedAt:   "qso_revision.qso_deleted_at",
+	Call:           "qso_revision.call",
+	Band:           "qso_revision.band",
+	Mode:           "qso_revision.mode",
+	Freq:           "qso_revision.freq",
+	QsoDate:        "qso_revision.qso_date",
+	TimeOn:         "qso_revision.time_on",
+	TimeOff:        "qso_revision.time_off",
+	RstSent:        "qso_revision.rst_sent",
+	RstRcvd:        "qso_revision.rst_rcvd",
+	Country:        "qso_revision.country",
+	AdditionalData: "qso_revision.additional_data",
+	LogbookID:      "qso_revision.logbook_id",
+	SessionID:      "qso_revision.session_id",
+}
+
+// Generated where
+
+var QsoRevisionWhere = struct {
+	ID             whereHelperint64
+	CreatedAt      whereHelpertime_Time
+	QsoID          whereHelperint64
+	Action         whereHelperstring
+	Reason         whereHelpernull_String
+	QsoCreatedAt   whereHelpertime_Time
+	QsoModifiedAt  whereHelpernull_Time
+	QsoDeletedAt   whereHelpernull_Time
+	Call           whereHelperstring
+	Band           whereHelperstring
+	Mode           whereHelperstring
+	Freq           whereHelperint64
+	QsoDate        whereHelperstring
+	TimeOn         whereHelperstring
+	TimeOff        whereHelperstring
+	RstSent        whereHelperstring
+	RstRcvd        whereHelperstring
+	Country        whereHelperstring
+	AdditionalData whereHelpertypes_JSON
+	LogbookID      whereHelperint64
+	SessionID      whereHelperint64
+}{
+	ID:             whereHelperint64{field: "\"qso_revision\".\"id\""},
+	CreatedAt:      whereHelpertime_Time{field: "\"qso_revision\".\"created_at\""},
+	QsoID:          whereHelperint64{field: "\"qso_revision\".\"qso_id\""},
+	Action:         whereHelperstring{field: "\"qso_revision\".\"action\""},
+	Reason:         whereHelpernull_String{field: "\"qso_revision\".\"reason\""},
+	QsoCreatedAt:   whereHelpertime_Time{field: "\"qso_revision\".\"qso_created_at\""},
+	QsoModifiedAt:  whereHelpernull_Time{field: "\"qso_revision\".\"qso_modified_at\""},
+	QsoDeletedAt:   whereHelpernull_Time{field: "\"qso_revision\".\"qso_deleted_at\""},
+	Call:           whereHelperstring{field: "\"qso_revision\".\"call\""},
+	Band:           whereHelperstring{field: "\"qso_revision\".\"band\""},
+	Mode:           whereHelperstring{field: "\"qso_revision\".\"mode\""},
+	Freq:           whereHelperint64{field: "\"qso_revision\".\"freq\""},
+	QsoDate:        whereHelperstring{field: "\"qso_revision\".\"qso_date\""},
+	TimeOn:         whereHelperstring{field: "\"qso_revision\".\"time_on\""},
+	TimeOff:        whereHelperstring{field: "\"qso_revision\".\"time_off\""},
+	RstSent:        whereHelperstring{field: "\"qso_revision\".\"rst_sent\""},
+	RstRcvd:        whereHelperstring{field: "\"qso_revision\".\"rst_rcvd\""},
+	Country:        whereHelperstring{field: "\"qso_revision\".\"country\""},
+	AdditionalData: whereHelpertypes_JSON{field: "\"qso_revision\".\"additional_data\""},
+	LogbookID:      whereHelperint64{field: "\"qso_revision\".\"logbook_id\""},
+	SessionID:      whereHelperint64{field: "\"qso_revision\".\"session_id\""},
+}
+
+// QsoRevisionRels is where relationship names are stored.
+var QsoRevisionRels = struct {
+	Qso string
+}{
+	Qso: "Qso",
+}
+
+// qsoRevisionR is where relationships are stored.
+type qsoRevisionR struct {
+	Qso *Qso `boil:"Qso" json:"Qso" toml:"Qso" yaml:"Qso"`
+}
+
+// NewStruct creates a new relationship struct
+func (*qsoRevisionR) NewStruct() *qsoRevisionR {
+	return &qsoRevisionR{}
+}
+
+func (o *QsoRevision) GetQso() *Qso {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetQso()
+}
+
+func (r *qsoRevisionR) GetQso() *Qso {
+	if r == nil {
+		return nil
+	}
+
+	return r.Qso
+}
+
+// qsoRevisionL is where Load methods for each relationship are stored.
+type qsoRevisionL struct{}
+
+var (
+	qsoRevisionAllColumns            = []string{"id", "created_at", "qso_id", "action", "reason", "qso_created_at", "qso_modified_at", "qso_deleted_at", "call", "band", "mode", "freq", "qso_date", "time_on", "time_off", "rst_sent", "rst_rcvd", "country", "additional_data", "logbook_id", "session_id"}
+	qsoRevisionColumnsWithoutDefault = []string{"qso_id", "action", "qso_created_at", "call", "band", "mode", "freq", "qso_date", "time_on", "time_off", "rst_sent", "rst_rcvd", "country", "additional_data", "logbook_id", "session_id"}
+	qsoRevisionColumnsWithDefault    = []string{"id", "created_at", "reason", "qso_modified_at", "qso_deleted_at"}
+	qsoRevisionPrimaryKeyColumns     = []string{"id"}
+	qsoRevisionGeneratedColumns      = []string{"id"}
+)
+
+type (
+	// QsoRevisionSlice is an alias for a slice of pointers to QsoRevision.
+	// This should almost always be used instead of []QsoRevision.
+	QsoRevisionSlice []*QsoRevision
+
+	qsoRevisionQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	qsoRevisionType                 = reflect.TypeOf(&QsoRevision{})
+	qsoRevisionMapping              = queries.MakeStructMapping(qsoRevisionType)
+	qsoRevisionPrimaryKeyMapping, _ = queries.BindMapping(qsoRevisionType, qsoRevisionMapping, qsoRevisionPrimaryKeyColumns)
+	qsoRevisionInsertCacheMut       sync.RWMutex
+	qsoRevisionInsertCache          = make(map[string]insertCache)
+	qsoRevisionUpdateCacheMut       sync.RWMutex
+	qsoRevisionUpdateCache          = make(map[string]updateCache)
+	qsoRevisionUpsertCacheMut       sync.RWMutex
+	qsoRevisionUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single qsoRevision record from the query.
+func (q qsoRevisionQuery) One(ctx context.Context, exec boil.ContextExecutor) (*QsoRevision, error) {
+	o := &QsoRevision{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for qso_revision")
+	}
+
+	return o, nil
+}
+
+// All returns all QsoRevision records from the query.
+func (q qsoRevisionQuery) All(ctx context.Context, exec boil.ContextExecutor) (QsoRevisionSlice, error) {
+	var o []*QsoRevision
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to QsoRevision slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all QsoRevision records in the query.
+func (q qsoRevisionQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count qso_revision rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q qsoRevisionQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if qso_revision exists")
+	}
+
+	return count > 0, nil
+}
+
+// Qso pointed to by the foreign key.
+func (o *QsoRevision) Qso(mods ...qm.QueryMod) qsoQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.QsoID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Qsos(queryMods...)
+}
+
+// LoadQso allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (qsoRevisionL) LoadQso(ctx context.Context, e boil.ContextExecutor, singular bool, maybeQsoRevision interface{}, mods queries.Applicator) error {
+	var slice []*QsoRevision
+	var object *QsoRevision
+
+	if singular {
+		var ok bool
+		object, ok = maybeQsoRevision.(*QsoRevision)
+		if !ok {
+			object = new(QsoRevision)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeQsoRevision)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeQsoRevision))
+			}
+		}
+	} else {
+		s, ok := maybeQsoRevision.(*[]*QsoRevision)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeQsoRevision)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeQsoRevision))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &qsoRevisionR{}
+		}
+		args[object.QsoID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &qsoRevisionR{}
+			}
+
+			args[obj.QsoID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`qso`),
+		qm.WhereIn(`qso.id in ?`, argsSlice...),
+		qmhelper.WhereIsNull(`qso.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Qso")
+	}
+
+	var resultSlice []*Qso
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Qso")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for qso")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for qso")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Qso = foreign
+		if foreign.R == nil {
+			foreign.R = &qsoR{}
+		}
+		foreign.R.QsoRevisions = append(foreign.R.QsoRevisions, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.QsoID == foreign.ID {
+				local.R.Qso = foreign
+				if foreign.R == nil {
+					foreign.R = &qsoR{}
+				}
+				foreign.R.QsoRevisions = append(foreign.R.QsoRevisions, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetQso of the qsoRevision to the related item.
+// Sets o.R.Qso to related.
+// Adds o to related.R.QsoRevisions.
+func (o *QsoRevision) SetQso(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Qso) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"qso_revision\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, []string{"qso_id"}),
+		strmangle.WhereClause("\"", "\"", 0, qsoRevisionPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.QsoID = related.ID
+	if o.R == nil {
+		o.R = &qsoRevisionR{
+			Qso: related,
+		}
+	} else {
+		o.R.Qso = related
+	}
+
+	if related.R == nil {
+		related.R = &qsoR{
+			QsoRevisions: QsoRevisionSlice{o},
+		}
+	} else {
+		related.R.QsoRevisions = append(related.R.QsoRevisions, o)
+	}
+
+	return nil
+}
+
+// QsoRevisions retrieves all the records using an executor.
+func QsoRevisions(mods ...qm.QueryMod) qsoRevisionQuery {
+	mods = append(mods, qm.From("\"qso_revision\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"qso_revision\".*"})
+	}
+
+	return qsoRevisionQuery{q}
+}
+
+// FindQsoRevision retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindQsoRevision(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*QsoRevision, error) {
+	qsoRevisionObj := &QsoRevision{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"qso_revision\" where \"id\"=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, qsoRevisionObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from qso_revision")
+	}
+
+	return qsoRevisionObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *QsoRevision) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no qso_revision provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(qsoRevisionColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	qsoRevisionInsertCacheMut.RLock()
+	cache, cached := qsoRevisionInsertCache[key]
+	qsoRevisionInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			qsoRevisionAllColumns,
+			qsoRevisionColumnsWithDefault,
+			qsoRevisionColumnsWithoutDefault,
+			nzDefaults,
+		)
+		wl = strmangle.SetComplement(wl, qsoRevisionGeneratedColumns)
+
+		cache.valueMapping, err = queries.BindMapping(qsoRevisionType, qsoRevisionMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(qsoRevisionType, qsoRevisionMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"qso_revision\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"qso_revision\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into qso_revision")
+	}
+
+	if !cached {
+		qsoRevisionInsertCacheMut.Lock()
+		qsoRevisionInsertCache[key] = cache
+		qsoRevisionInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the QsoRevision.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *QsoRevision) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	key := makeCacheKey(columns, nil)
+	qsoRevisionUpdateCacheMut.RLock()
+	cache, cached := qsoRevisionUpdateCache[key]
+	qsoRevisionUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			qsoRevisionAllColumns,
+			qsoRevisionPrimaryKeyColumns,
+		)
+		wl = strmangle.SetComplement(wl, qsoRevisionGeneratedColumns)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update qso_revision, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"qso_revision\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, qsoRevisionPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(qsoRevisionType, qsoRevisionMapping, append(wl, qsoRevisionPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update qso_revision row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for qso_revision")
+	}
+
+	if !cached {
+		qsoRevisionUpdateCacheMut.Lock()
+		qsoRevisionUpdateCache[key] = cache
+		qsoRevisionUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q qsoRevisionQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for qso_revision")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for qso_revision")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o QsoRevisionSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), qsoRevisionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"qso_revision\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, qsoRevisionPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in qsoRevision slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all qsoRevision")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *QsoRevision) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no qso_revision provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(qsoRevisionColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	qsoRevisionUpsertCacheMut.RLock()
+	cache, cached := qsoRevisionUpsertCache[key]
+	qsoRevisionUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			qsoRevisionAllColumns,
+			qsoRevisionColumnsWithDefault,
+			qsoRevisionColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			qsoRevisionAllColumns,
+			qsoRevisionPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert qso_revision, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(qsoRevisionAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(qsoRevisionPrimaryKeyColumns))
+			copy(conflict, qsoRevisionPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQuerySQLite(dialect, "\"qso_revision\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(qsoRevisionType, qsoRevisionMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(qsoRevisionType, qsoRevisionMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert qso_revision")
+	}
+
+	if !cached {
+		qsoRevisionUpsertCacheMut.Lock()
+		qsoRevisionUpsertCache[key] = cache
+		qsoRevisionUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single QsoRevision record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *QsoRevision) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no QsoRevision provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), qsoRevisionPrimaryKeyMapping)
+	sql := "DELETE FROM \"qso_revision\" WHERE \"id\"=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from qso_revision")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for qso_revision")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q qsoRevisionQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no qsoRevisionQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from qso_revision")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for qso_revision")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o QsoRevisionSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), qsoRevisionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"qso_revision\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, qsoRevisionPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from qsoRevision slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for qso_revision")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *QsoRevision) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindQsoRevision(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *QsoRevisionSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := QsoRevisionSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), qsoRevisionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"qso_revision\".* FROM \"qso_revision\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, qsoRevisionPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in QsoRevisionSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// QsoRevisionExists checks if the QsoRevision row exists.
+func QsoRevisionExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"qso_revision\" where \"id\"=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if qso_revision exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the QsoRevision row exists.
+func (o *QsoRevision) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return QsoRevisionExists(ctx, exec, o.ID)
+}
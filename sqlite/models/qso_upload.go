@@ -24,67 +24,87 @@ import (
 
 // QsoUpload is an object representing the database table.
 type QsoUpload struct {
-	ID            int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
-	CreatedAt     time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	ModifiedAt    null.Time   `boil:"modified_at" json:"modified_at,omitempty" toml:"modified_at" yaml:"modified_at,omitempty"`
-	QsoID         int64       `boil:"qso_id" json:"qso_id" toml:"qso_id" yaml:"qso_id"`
-	Service       string      `boil:"service" json:"service" toml:"service" yaml:"service"`
-	Action        string      `boil:"action" json:"action" toml:"action" yaml:"action"`
-	Status        string      `boil:"status" json:"status" toml:"status" yaml:"status"`
-	Attempts      int64       `boil:"attempts" json:"attempts" toml:"attempts" yaml:"attempts"`
-	LastAttemptAt null.Int64  `boil:"last_attempt_at" json:"last_attempt_at,omitempty" toml:"last_attempt_at" yaml:"last_attempt_at,omitempty"`
-	LastError     null.String `boil:"last_error" json:"last_error,omitempty" toml:"last_error" yaml:"last_error,omitempty"`
+	ID             int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt      time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ModifiedAt     null.Time   `boil:"modified_at" json:"modified_at,omitempty" toml:"modified_at" yaml:"modified_at,omitempty"`
+	QsoID          int64       `boil:"qso_id" json:"qso_id" toml:"qso_id" yaml:"qso_id"`
+	Service        string      `boil:"service" json:"service" toml:"service" yaml:"service"`
+	Action         string      `boil:"action" json:"action" toml:"action" yaml:"action"`
+	Status         string      `boil:"status" json:"status" toml:"status" yaml:"status"`
+	Attempts       int64       `boil:"attempts" json:"attempts" toml:"attempts" yaml:"attempts"`
+	LastAttemptAt  null.Int64  `boil:"last_attempt_at" json:"last_attempt_at,omitempty" toml:"last_attempt_at" yaml:"last_attempt_at,omitempty"`
+	NextAttemptAt  null.Int64  `boil:"next_attempt_at" json:"next_attempt_at,omitempty" toml:"next_attempt_at" yaml:"next_attempt_at,omitempty"`
+	LastError      null.String `boil:"last_error" json:"last_error,omitempty" toml:"last_error" yaml:"last_error,omitempty"`
+	LeaseOwner     null.String `boil:"lease_owner" json:"lease_owner,omitempty" toml:"lease_owner" yaml:"lease_owner,omitempty"`
+	LeaseExpiresAt null.Int64  `boil:"lease_expires_at" json:"lease_expires_at,omitempty" toml:"lease_expires_at" yaml:"lease_expires_at,omitempty"`
+	Requeued       bool        `boil:"requeued" json:"requeued" toml:"requeued" yaml:"requeued"`
 
 	R *qsoUploadR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L qsoUploadL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var QsoUploadColumns = struct {
-	ID            string
-	CreatedAt     string
-	ModifiedAt    string
-	QsoID         string
-	Service       string
-	Action        string
-	Status        string
-	Attempts      string
-	LastAttemptAt string
-	LastError     string
+	ID             string
+	CreatedAt      string
+	ModifiedAt     string
+	QsoID          string
+	Service        string
+	Action         string
+	Status         string
+	Attempts       string
+	LastAttemptAt  string
+	NextAttemptAt  string
+	LastError      string
+	LeaseOwner     string
+	LeaseExpiresAt string
+	Requeued       string
 }{
-	ID:            "id",
-	CreatedAt:     "created_at",
-	ModifiedAt:    "modified_at",
-	QsoID:         "qso_id",
-	Service:       "service",
-	Action:        "action",
-	Status:        "status",
-	Attempts:      "attempts",
-	LastAttemptAt: "last_attempt_at",
-	LastError:     "last_error",
+	ID:             "id",
+	CreatedAt:      "created_at",
+	ModifiedAt:     "modified_at",
+	QsoID:          "qso_id",
+	Service:        "service",
+	Action:         "action",
+	Status:         "status",
+	Attempts:       "attempts",
+	LastAttemptAt:  "last_attempt_at",
+	NextAttemptAt:  "next_attempt_at",
+	LastError:      "last_error",
+	LeaseOwner:     "lease_owner",
+	LeaseExpiresAt: "lease_expires_at",
+	Requeued:       "requeued",
 }
 
 var QsoUploadTableColumns = struct {
-	ID            string
-	CreatedAt     string
-	ModifiedAt    string
-	QsoID         string
-	Service       string
-	Action        string
-	Status        string
-	Attempts      string
-	LastAttemptAt string
-	LastError     string
+	ID             string
+	CreatedAt      string
+	ModifiedAt     string
+	QsoID          string
+	Service        string
+	Action         string
+	Status         string
+	Attempts       string
+	LastAttemptAt  string
+	NextAttemptAt  string
+	LastError      string
+	LeaseOwner     string
+	LeaseExpiresAt string
+	Requeued       string
 }{
-	ID:            "qso_upload.id",
-	CreatedAt:     "qso_upload.created_at",
-	ModifiedAt:    "qso_upload.modified_at",
-	QsoID:         "qso_upload.qso_id",
-	Service:       "qso_upload.service",
-	Action:        "qso_upload.action",
-	Status:        "qso_upload.status",
-	Attempts:      "qso_upload.attempts",
-	LastAttemptAt: "qso_upload.last_attempt_at",
-	LastError:     "qso_upload.last_error",
+	ID:             "qso_upload.id",
+	CreatedAt:      "qso_upload.created_at",
+	ModifiedAt:     "qso_upload.modified_at",
+	QsoID:          "qso_upload.qso_id",
+	Service:        "qso_upload.service",
+	Action:         "qso_upload.action",
+	Status:         "qso_upload.status",
+	Attempts:       "qso_upload.attempts",
+	LastAttemptAt:  "qso_upload.last_attempt_at",
+	NextAttemptAt:  "qso_upload.next_attempt_at",
+	LastError:      "qso_upload.last_error",
+	LeaseOwner:     "qso_upload.lease_owner",
+	LeaseExpiresAt: "qso_upload.lease_expires_at",
+	Requeued:       "qso_upload.requeued",
 }
 
 // Generated where
@@ -128,39 +148,50 @@ func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIs
 func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
 var QsoUploadWhere = struct {
-	ID            whereHelperint64
-	CreatedAt     whereHelpertime_Time
-	ModifiedAt    whereHelpernull_Time
-	QsoID         whereHelperint64
-	Service       whereHelperstring
-	Action        whereHelperstring
-	Status        whereHelperstring
-	Attempts      whereHelperint64
-	LastAttemptAt whereHelpernull_Int64
-	LastError     whereHelpernull_String
+	ID             whereHelperint64
+	CreatedAt      whereHelpertime_Time
+	ModifiedAt     whereHelpernull_Time
+	QsoID          whereHelperint64
+	Service        whereHelperstring
+	Action         whereHelperstring
+	Status         whereHelperstring
+	Attempts       whereHelperint64
+	LastAttemptAt  whereHelpernull_Int64
+	NextAttemptAt  whereHelpernull_Int64
+	LastError      whereHelpernull_String
+	LeaseOwner     whereHelpernull_String
+	LeaseExpiresAt whereHelpernull_Int64
+	Requeued       whereHelperbool
 }{
-	ID:            whereHelperint64{field: "\"qso_upload\".\"id\""},
-	CreatedAt:     whereHelpertime_Time{field: "\"qso_upload\".\"created_at\""},
-	ModifiedAt:    whereHelpernull_Time{field: "\"qso_upload\".\"modified_at\""},
-	QsoID:         whereHelperint64{field: "\"qso_upload\".\"qso_id\""},
-	Service:       whereHelperstring{field: "\"qso_upload\".\"service\""},
-	Action:        whereHelperstring{field: "\"qso_upload\".\"action\""},
-	Status:        whereHelperstring{field: "\"qso_upload\".\"status\""},
-	Attempts:      whereHelperint64{field: "\"qso_upload\".\"attempts\""},
-	LastAttemptAt: whereHelpernull_Int64{field: "\"qso_upload\".\"last_attempt_at\""},
-	LastError:     whereHelpernull_String{field: "\"qso_upload\".\"last_error\""},
+	ID:             whereHelperint64{field: "\"qso_upload\".\"id\""},
+	CreatedAt:      whereHelpertime_Time{field: "\"qso_upload\".\"created_at\""},
+	ModifiedAt:     whereHelpernull_Time{field: "\"qso_upload\".\"modified_at\""},
+	QsoID:          whereHelperint64{field: "\"qso_upload\".\"qso_id\""},
+	Service:        whereHelperstring{field: "\"qso_upload\".\"service\""},
+	Action:         whereHelperstring{field: "\"qso_upload\".\"action\""},
+	Status:         whereHelperstring{field: "\"qso_upload\".\"status\""},
+	Attempts:       whereHelperint64{field: "\"qso_upload\".\"attempts\""},
+	LastAttemptAt:  whereHelpernull_Int64{field: "\"qso_upload\".\"last_attempt_at\""},
+	NextAttemptAt:  whereHelpernull_Int64{field: "\"qso_upload\".\"next_attempt_at\""},
+	LastError:      whereHelpernull_String{field: "\"qso_upload\".\"last_error\""},
+	LeaseOwner:     whereHelpernull_String{field: "\"qso_upload\".\"lease_owner\""},
+	LeaseExpiresAt: whereHelpernull_Int64{field: "\"qso_upload\".\"lease_expires_at\""},
+	Requeued:       whereHelperbool{field: "\"qso_upload\".\"requeued\""},
 }
 
 // QsoUploadRels is where relationship names are stored.
 var QsoUploadRels = struct {
-	Qso string
+	Qso               string
+	QsoUploadAttempts string
 }{
-	Qso: "Qso",
+	Qso:               "Qso",
+	QsoUploadAttempts: "QsoUploadAttempts",
 }
 
 // qsoUploadR is where relationships are stored.
 type qsoUploadR struct {
-	Qso *Qso `boil:"Qso" json:"Qso" toml:"Qso" yaml:"Qso"`
+	Qso               *Qso                  `boil:"Qso" json:"Qso" toml:"Qso" yaml:"Qso"`
+	QsoUploadAttempts QsoUploadAttemptSlice `boil:"QsoUploadAttempts" json:"QsoUploadAttempts" toml:"QsoUploadAttempts" yaml:"QsoUploadAttempts"`
 }
 
 // NewStruct creates a new relationship struct
@@ -184,13 +215,29 @@ func (r *qsoUploadR) GetQso() *Qso {
 	return r.Qso
 }
 
+func (o *QsoUpload) GetQsoUploadAttempts() QsoUploadAttemptSlice {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetQsoUploadAttempts()
+}
+
+func (r *qsoUploadR) GetQsoUploadAttempts() QsoUploadAttemptSlice {
+	if r == nil {
+		return nil
+	}
+
+	return r.QsoUploadAttempts
+}
+
 // qsoUploadL is where Load methods for each relationship are stored.
 type qsoUploadL struct{}
 
 var (
-	qsoUploadAllColumns            = []string{"id", "created_at", "modified_at", "qso_id", "service", "action", "status", "attempts", "last_attempt_at", "last_error"}
+	qsoUploadAllColumns            = []string{"id", "created_at", "modified_at", "qso_id", "service", "action", "status", "attempts", "last_attempt_at", "next_attempt_at", "last_error", "lease_owner", "lease_expires_at", "requeued"}
 	qsoUploadColumnsWithoutDefault = []string{"qso_id", "service"}
-	qsoUploadColumnsWithDefault    = []string{"id", "created_at", "modified_at", "action", "status", "attempts", "last_attempt_at", "last_error"}
+	qsoUploadColumnsWithDefault    = []string{"id", "created_at", "modified_at", "action", "status", "attempts", "last_attempt_at", "next_attempt_at", "last_error", "lease_owner", "lease_expires_at", "requeued"}
 	qsoUploadPrimaryKeyColumns     = []string{"id"}
 	qsoUploadGeneratedColumns      = []string{"id"}
 )
@@ -297,6 +344,20 @@ func (o *QsoUpload) Qso(mods ...qm.QueryMod) qsoQuery {
 	return Qsos(queryMods...)
 }
 
+// QsoUploadAttempts retrieves all the qso_upload_attempt's QsoUploadAttempts with an executor.
+func (o *QsoUpload) QsoUploadAttempts(mods ...qm.QueryMod) qsoUploadAttemptQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"qso_upload_attempt\".\"qso_upload_id\"=?", o.ID),
+	)
+
+	return QsoUploadAttempts(queryMods...)
+}
+
 // LoadQso allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (qsoUploadL) LoadQso(ctx context.Context, e boil.ContextExecutor, singular bool, maybeQsoUpload interface{}, mods queries.Applicator) error {
@@ -410,6 +471,112 @@ func (qsoUploadL) LoadQso(ctx context.Context, e boil.ContextExecutor, singular
 	return nil
 }
 
+// LoadQsoUploadAttempts allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (qsoUploadL) LoadQsoUploadAttempts(ctx context.Context, e boil.ContextExecutor, singular bool, maybeQsoUpload interface{}, mods queries.Applicator) error {
+	var slice []*QsoUpload
+	var object *QsoUpload
+
+	if singular {
+		var ok bool
+		object, ok = maybeQsoUpload.(*QsoUpload)
+		if !ok {
+			object = new(QsoUpload)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeQsoUpload)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeQsoUpload))
+			}
+		}
+	} else {
+		s, ok := maybeQsoUpload.(*[]*QsoUpload)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeQsoUpload)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeQsoUpload))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &qsoUploadR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &qsoUploadR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`qso_upload_attempt`),
+		qm.WhereIn(`qso_upload_attempt.qso_upload_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load qso_upload_attempt")
+	}
+
+	var resultSlice []*QsoUploadAttempt
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice qso_upload_attempt")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on qso_upload_attempt")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for qso_upload_attempt")
+	}
+
+	if singular {
+		object.R.QsoUploadAttempts = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &qsoUploadAttemptR{}
+			}
+			foreign.R.QsoUpload = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.QsoUploadID) {
+				local.R.QsoUploadAttempts = append(local.R.QsoUploadAttempts, foreign)
+				if foreign.R == nil {
+					foreign.R = &qsoUploadAttemptR{}
+				}
+				foreign.R.QsoUpload = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // SetQso of the qsoUpload to the related item.
 // Sets o.R.Qso to related.
 // Adds o to related.R.QsoUploads.
@@ -457,6 +624,133 @@ func (o *QsoUpload) SetQso(ctx context.Context, exec boil.ContextExecutor, inser
 	return nil
 }
 
+// AddQsoUploadAttempts adds the given related objects to the existing relationships
+// of the qso_upload, optionally inserting them as new records.
+// Appends related to o.R.QsoUploadAttempts.
+// Sets related.R.QsoUpload appropriately.
+func (o *QsoUpload) AddQsoUploadAttempts(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*QsoUploadAttempt) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.QsoUploadID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"qso_upload_attempt\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 0, []string{"qso_upload_id"}),
+				strmangle.WhereClause("\"", "\"", 0, qsoUploadAttemptPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.QsoUploadID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &qsoUploadR{
+			QsoUploadAttempts: related,
+		}
+	} else {
+		o.R.QsoUploadAttempts = append(o.R.QsoUploadAttempts, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &qsoUploadAttemptR{
+				QsoUpload: o,
+			}
+		} else {
+			rel.R.QsoUpload = o
+		}
+	}
+	return nil
+}
+
+// SetQsoUploadAttempts removes all previously related items of the
+// qso_upload replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.QsoUpload's QsoUploadAttempts accordingly.
+// Replaces o.R.QsoUploadAttempts with related.
+// Sets related.R.QsoUpload's QsoUploadAttempts accordingly.
+func (o *QsoUpload) SetQsoUploadAttempts(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*QsoUploadAttempt) error {
+	query := "update \"qso_upload_attempt\" set \"qso_upload_id\" = null where \"qso_upload_id\" = ?"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.QsoUploadAttempts {
+			queries.SetScanner(&rel.QsoUploadID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.QsoUpload = nil
+		}
+		o.R.QsoUploadAttempts = nil
+	}
+
+	return o.AddQsoUploadAttempts(ctx, exec, insert, related...)
+}
+
+// RemoveQsoUploadAttempts relationships from objects passed in.
+// Removes related items from R.QsoUploadAttempts (uses pointer comparison, removal does not keep order)
+// Sets related.R.QsoUpload.
+func (o *QsoUpload) RemoveQsoUploadAttempts(ctx context.Context, exec boil.ContextExecutor, related ...*QsoUploadAttempt) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.QsoUploadID, nil)
+		if rel.R != nil {
+			rel.R.QsoUpload = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("qso_upload_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.QsoUploadAttempts {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.QsoUploadAttempts)
+			if ln > 1 && i < ln-1 {
+				o.R.QsoUploadAttempts[i] = o.R.QsoUploadAttempts[ln-1]
+			}
+			o.R.QsoUploadAttempts = o.R.QsoUploadAttempts[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
 // QsoUploads retrieves all the records using an executor.
 func QsoUploads(mods ...qm.QueryMod) qsoUploadQuery {
 	mods = append(mods, qm.From("\"qso_upload\""))
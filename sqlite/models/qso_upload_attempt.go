@@ -0,0 +1,1181 @@
+// Code generated by SQLBoiler 4.19.5 (https://github.com/aarondl/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/aarondl/sqlboiler/v4/queries/qmhelper"
+	"github.com/aarondl/strmangle"
+	"github.com/friendsofgo/errors"
+)
+
+// QsoUploadAttempt is an object representing the database table.
+type QsoUploadAttempt struct {
+	ID              int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt       time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	QsoUploadID     null.Int64  `boil:"qso_upload_id" json:"qso_upload_id,omitempty" toml:"qso_upload_id" yaml:"qso_upload_id,omitempty"`
+	QsoID           int64       `boil:"qso_id" json:"qso_id" toml:"qso_id" yaml:"qso_id"`
+	Service         string      `boil:"service" json:"service" toml:"service" yaml:"service"`
+	Action          string      `boil:"action" json:"action" toml:"action" yaml:"action"`
+	AttemptedAt     int64       `boil:"attempted_at" json:"attempted_at" toml:"attempted_at" yaml:"attempted_at"`
+	DurationMS      int64       `boil:"duration_ms" json:"duration_ms" toml:"duration_ms" yaml:"duration_ms"`
+	Outcome         string      `boil:"outcome" json:"outcome" toml:"outcome" yaml:"outcome"`
+	ResponseCode    null.Int64  `boil:"response_code" json:"response_code,omitempty" toml:"response_code" yaml:"response_code,omitempty"`
+	ResponseMessage null.String `boil:"response_message" json:"response_message,omitempty" toml:"response_message" yaml:"response_message,omitempty"`
+	RemoteID        null.String `boil:"remote_id" json:"remote_id,omitempty" toml:"remote_id" yaml:"remote_id,omitempty"`
+	Error           null.String `boil:"error" json:"error,omitempty" toml:"error" yaml:"error,omitempty"`
+
+	R *qsoUploadAttemptR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L qsoUploadAttemptL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var QsoUploadAttemptColumns = struct {
+	ID              string
+	CreatedAt       string
+	QsoUploadID     string
+	QsoID           string
+	Service         string
+	Action          string
+	AttemptedAt     string
+	DurationMS      string
+	Outcome         string
+	ResponseCode    string
+	ResponseMessage string
+	RemoteID        string
+	Error           string
+}{
+	ID:              "id",
+	CreatedAt:       "created_at",
+	QsoUploadID:     "qso_upload_id",
+	QsoID:           "qso_id",
+	Service:         "service",
+	Action:          "action",
+	AttemptedAt:     "attempted_at",
+	DurationMS:      "duration_ms",
+	Outcome:         "outcome",
+	ResponseCode:    "response_code",
+	ResponseMessage: "response_message",
+	RemoteID:        "remote_id",
+	Error:           "error",
+}
+
+var QsoUploadAttemptTableColumns = struct {
+	ID              string
+	CreatedAt       string
+	QsoUploadID     string
+	QsoID           string
+	Service         string
+	Action          string
+	AttemptedAt     string
+	DurationMS      string
+	Outcome         string
+	ResponseCode    string
+	ResponseMessage string
+	RemoteID        string
+	Error           string
+}{
+	ID:              "qso_upload_attempt.id",
+	CreatedAt:       "qso_upload_attempt.created_at",
+	QsoUploadID:     "qso_upload_attempt.qso_upload_id",
+	QsoID:           "qso_upload_attempt.qso_id",
+	Service:         "qso_upload_attempt.service",
+	Action:          "qso_upload_attempt.action",
+	AttemptedAt:     "qso_upload_attempt.attempted_at",
+	DurationMS:      "qso_upload_attempt.duration_ms",
+	Outcome:         "qso_upload_attempt.outcome",
+	ResponseCode:    "qso_upload_attempt.response_code",
+	ResponseMessage: "qso_upload_attempt.response_message",
+	RemoteID:        "qso_upload_attempt.remote_id",
+	Error:           "qso_upload_attempt.error",
+}
+
+// Generated where
+
+var QsoUploadAttemptWhere = struct {
+	ID              whereHelperint64
+	CreatedAt       whereHelpertime_Time
+	QsoUploadID     whereHelpernull_Int64
+	QsoID           whereHelperint64
+	Service         whereHelperstring
+	Action          whereHelperstring
+	AttemptedAt     whereHelperint64
+	DurationMS      whereHelperint64
+	Outcome         whereHelperstring
+	ResponseCode    whereHelpernull_Int64
+	ResponseMessage whereHelpernull_String
+	RemoteID        whereHelpernull_String
+	Error           whereHelpernull_String
+}{
+	ID:              whereHelperint64{field: "\"qso_upload_attempt\".\"id\""},
+	CreatedAt:       whereHelpertime_Time{field: "\"qso_upload_attempt\".\"created_at\""},
+	QsoUploadID:     whereHelpernull_Int64{field: "\"qso_upload_attempt\".\"qso_upload_id\""},
+	QsoID:           whereHelperint64{field: "\"qso_upload_attempt\".\"qso_id\""},
+	Service:         whereHelperstring{field: "\"qso_upload_attempt\".\"service\""},
+	Action:          whereHelperstring{field: "\"qso_upload_attempt\".\"action\""},
+	AttemptedAt:     whereHelperint64{field: "\"qso_upload_attempt\".\"attempted_at\""},
+	DurationMS:      whereHelperint64{field: "\"qso_upload_attempt\".\"duration_ms\""},
+	Outcome:         whereHelperstring{field: "\"qso_upload_attempt\".\"outcome\""},
+	ResponseCode:    whereHelpernull_Int64{field: "\"qso_upload_attempt\".\"response_code\""},
+	ResponseMessage: whereHelpernull_String{field: "\"qso_upload_attempt\".\"response_message\""},
+	RemoteID:        whereHelpernull_String{field: "\"qso_upload_attempt\".\"remote_id\""},
+	Error:           whereHelpernull_String{field: "\"qso_upload_attempt\".\"error\""},
+}
+
+// QsoUploadAttemptRels is where relationship names are stored.
+var QsoUploadAttemptRels = struct {
+	Qso       string
+	QsoUpload string
+}{
+	Qso:       "Qso",
+	QsoUpload: "QsoUpload",
+}
+
+// qsoUploadAttemptR is where relationships are stored.
+type qsoUploadAttemptR struct {
+	Qso       *Qso       `boil:"Qso" json:"Qso" toml:"Qso" yaml:"Qso"`
+	QsoUpload *QsoUpload `boil:"QsoUpload" json:"QsoUpload" toml:"QsoUpload" yaml:"QsoUpload"`
+}
+
+// NewStruct creates a new relationship struct
+func (*qsoUploadAttemptR) NewStruct() *qsoUploadAttemptR {
+	return &qsoUploadAttemptR{}
+}
+
+func (o *QsoUploadAttempt) GetQso() *Qso {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetQso()
+}
+
+func (r *qsoUploadAttemptR) GetQso() *Qso {
+	if r == nil {
+		return nil
+	}
+
+	return r.Qso
+}
+
+func (o *QsoUploadAttempt) GetQsoUpload() *QsoUpload {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetQsoUpload()
+}
+
+func (r *qsoUploadAttemptR) GetQsoUpload() *QsoUpload {
+	if r == nil {
+		return nil
+	}
+
+	return r.QsoUpload
+}
+
+// qsoUploadAttemptL is where Load methods for each relationship are stored.
+type qsoUploadAttemptL struct{}
+
+var (
+	qsoUploadAttemptAllColumns            = []string{"id", "created_at", "qso_upload_id", "qso_id", "service", "action", "attempted_at", "duration_ms", "outcome", "response_code", "response_message", "remote_id", "error"}
+	qsoUploadAttemptColumnsWithoutDefault = []string{"qso_id", "service", "action", "attempted_at", "outcome"}
+	qsoUploadAttemptColumnsWithDefault    = []string{"id", "created_at", "qso_upload_id", "duration_ms", "response_code", "response_message", "remote_id", "error"}
+	qsoUploadAttemptPrimaryKeyColumns     = []string{"id"}
+	qsoUploadAttemptGeneratedColumns      = []string{"id"}
+)
+
+type (
+	// QsoUploadAttemptSlice is an alias for a slice of pointers to QsoUploadAttempt.
+	// This should almost always be used instead of []QsoUploadAttempt.
+	QsoUploadAttemptSlice []*QsoUploadAttempt
+
+	qsoUploadAttemptQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	qsoUploadAttemptType                 = reflect.TypeOf(&QsoUploadAttempt{})
+	qsoUploadAttemptMapping              = queries.MakeStructMapping(qsoUploadAttemptType)
+	qsoUploadAttemptPrimaryKeyMapping, _ = queries.BindMapping(qsoUploadAttemptType, qsoUploadAttemptMapping, qsoUploadAttemptPrimaryKeyColumns)
+	qsoUploadAttemptInsertCacheMut       sync.RWMutex
+	qsoUploadAttemptInsertCache          = make(map[string]insertCache)
+	qsoUploadAttemptUpdateCacheMut       sync.RWMutex
+	qsoUploadAttemptUpdateCache          = make(map[string]updateCache)
+	qsoUploadAttemptUpsertCacheMut       sync.RWMutex
+	qsoUploadAttemptUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single qsoUploadAttempt record from the query.
+func (q qsoUploadAttemptQuery) One(ctx context.Context, exec boil.ContextExecutor) (*QsoUploadAttempt, error) {
+	o := &QsoUploadAttempt{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for qso_upload_attempt")
+	}
+
+	return o, nil
+}
+
+// All returns all QsoUploadAttempt records from the query.
+func (q qsoUploadAttemptQuery) All(ctx context.Context, exec boil.ContextExecutor) (QsoUploadAttemptSlice, error) {
+	var o []*QsoUploadAttempt
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to QsoUploadAttempt slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all QsoUploadAttempt records in the query.
+func (q qsoUploadAttemptQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count qso_upload_attempt rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q qsoUploadAttemptQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if qso_upload_attempt exists")
+	}
+
+	return count > 0, nil
+}
+
+// Qso pointed to by the foreign key.
+func (o *QsoUploadAttempt) Qso(mods ...qm.QueryMod) qsoQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.QsoID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Qsos(queryMods...)
+}
+
+// QsoUpload pointed to by the foreign key.
+func (o *QsoUploadAttempt) QsoUpload(mods ...qm.QueryMod) qsoUploadQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.QsoUploadID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return QsoUploads(queryMods...)
+}
+
+// LoadQso allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (qsoUploadAttemptL) LoadQso(ctx context.Context, e boil.ContextExecutor, singular bool, maybeQsoUploadAttempt interface{}, mods queries.Applicator) error {
+	var slice []*QsoUploadAttempt
+	var object *QsoUploadAttempt
+
+	if singular {
+		var ok bool
+		object, ok = maybeQsoUploadAttempt.(*QsoUploadAttempt)
+		if !ok {
+			object = new(QsoUploadAttempt)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeQsoUploadAttempt)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeQsoUploadAttempt))
+			}
+		}
+	} else {
+		s, ok := maybeQsoUploadAttempt.(*[]*QsoUploadAttempt)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeQsoUploadAttempt)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeQsoUploadAttempt))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &qsoUploadAttemptR{}
+		}
+		args[object.QsoID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &qsoUploadAttemptR{}
+			}
+
+			args[obj.QsoID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`qso`),
+		qm.WhereIn(`qso.id in ?`, argsSlice...),
+		qmhelper.WhereIsNull(`qso.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Qso")
+	}
+
+	var resultSlice []*Qso
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Qso")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for qso")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for qso")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Qso = foreign
+		if foreign.R == nil {
+			foreign.R = &qsoR{}
+		}
+		foreign.R.QsoUploadAttempts = append(foreign.R.QsoUploadAttempts, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.QsoID == foreign.ID {
+				local.R.Qso = foreign
+				if foreign.R == nil {
+					foreign.R = &qsoR{}
+				}
+				foreign.R.QsoUploadAttempts = append(foreign.R.QsoUploadAttempts, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadQsoUpload allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (qsoUploadAttemptL) LoadQsoUpload(ctx context.Context, e boil.ContextExecutor, singular bool, maybeQsoUploadAttempt interface{}, mods queries.Applicator) error {
+	var slice []*QsoUploadAttempt
+	var object *QsoUploadAttempt
+
+	if singular {
+		var ok bool
+		object, ok = maybeQsoUploadAttempt.(*QsoUploadAttempt)
+		if !ok {
+			object = new(QsoUploadAttempt)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeQsoUploadAttempt)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeQsoUploadAttempt))
+			}
+		}
+	} else {
+		s, ok := maybeQsoUploadAttempt.(*[]*QsoUploadAttempt)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeQsoUploadAttempt)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeQsoUploadAttempt))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &qsoUploadAttemptR{}
+		}
+		if !queries.IsNil(object.QsoUploadID) {
+			args[object.QsoUploadID] = struct{}{}
+		}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &qsoUploadAttemptR{}
+			}
+
+			if !queries.IsNil(obj.QsoUploadID) {
+				args[obj.QsoUploadID] = struct{}{}
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`qso_upload`),
+		qm.WhereIn(`qso_upload.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load QsoUpload")
+	}
+
+	var resultSlice []*QsoUpload
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice QsoUpload")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for qso_upload")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for qso_upload")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.QsoUpload = foreign
+		if foreign.R == nil {
+			foreign.R = &qsoUploadR{}
+		}
+		foreign.R.QsoUploadAttempts = append(foreign.R.QsoUploadAttempts, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.QsoUploadID, foreign.ID) {
+				local.R.QsoUpload = foreign
+				if foreign.R == nil {
+					foreign.R = &qsoUploadR{}
+				}
+				foreign.R.QsoUploadAttempts = append(foreign.R.QsoUploadAttempts, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetQso of the qsoUploadAttempt to the related item.
+// Sets o.R.Qso to related.
+// Adds o to related.R.QsoUploadAttempts.
+func (o *QsoUploadAttempt) SetQso(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Qso) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"qso_upload_attempt\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, []string{"qso_id"}),
+		strmangle.WhereClause("\"", "\"", 0, qsoUploadAttemptPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.QsoID = related.ID
+	if o.R == nil {
+		o.R = &qsoUploadAttemptR{
+			Qso: related,
+		}
+	} else {
+		o.R.Qso = related
+	}
+
+	if related.R == nil {
+		related.R = &qsoR{
+			QsoUploadAttempts: QsoUploadAttemptSlice{o},
+		}
+	} else {
+		related.R.QsoUploadAttempts = append(related.R.QsoUploadAttempts, o)
+	}
+
+	return nil
+}
+
+// SetQsoUpload of the qsoUploadAttempt to the related item.
+// Sets o.R.QsoUpload to related.
+// Adds o to related.R.QsoUploadAttempts.
+func (o *QsoUploadAttempt) SetQsoUpload(ctx context.Context, exec boil.ContextExecutor, insert bool, related *QsoUpload) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"qso_upload_attempt\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, []string{"qso_upload_id"}),
+		strmangle.WhereClause("\"", "\"", 0, qsoUploadAttemptPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.QsoUploadID, related.ID)
+	if o.R == nil {
+		o.R = &qsoUploadAttemptR{
+			QsoUpload: related,
+		}
+	} else {
+		o.R.QsoUpload = related
+	}
+
+	if related.R == nil {
+		related.R = &qsoUploadR{
+			QsoUploadAttempts: QsoUploadAttemptSlice{o},
+		}
+	} else {
+		related.R.QsoUploadAttempts = append(related.R.QsoUploadAttempts, o)
+	}
+
+	return nil
+}
+
+// RemoveQsoUpload relationship.
+// Sets o.R.QsoUpload to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *QsoUploadAttempt) RemoveQsoUpload(ctx context.Context, exec boil.ContextExecutor, related *QsoUpload) error {
+	var err error
+
+	queries.SetScanner(&o.QsoUploadID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("qso_upload_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.QsoUpload = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.QsoUploadAttempts {
+		if queries.Equal(o.QsoUploadID, ri.QsoUploadID) {
+			continue
+		}
+
+		ln := len(related.R.QsoUploadAttempts)
+		if ln > 1 && i < ln-1 {
+			related.R.QsoUploadAttempts[i] = related.R.QsoUploadAttempts[ln-1]
+		}
+		related.R.QsoUploadAttempts = related.R.QsoUploadAttempts[:ln-1]
+		break
+	}
+	return nil
+}
+
+// QsoUploadAttempts retrieves all the records using an executor.
+func QsoUploadAttempts(mods ...qm.QueryMod) qsoUploadAttemptQuery {
+	mods = append(mods, qm.From("\"qso_upload_attempt\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"qso_upload_attempt\".*"})
+	}
+
+	return qsoUploadAttemptQuery{q}
+}
+
+// FindQsoUploadAttempt retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindQsoUploadAttempt(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*QsoUploadAttempt, error) {
+	qsoUploadAttemptObj := &QsoUploadAttempt{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"qso_upload_attempt\" where \"id\"=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, qsoUploadAttemptObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from qso_upload_attempt")
+	}
+
+	return qsoUploadAttemptObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *QsoUploadAttempt) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no qso_upload_attempt provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(qsoUploadAttemptColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	qsoUploadAttemptInsertCacheMut.RLock()
+	cache, cached := qsoUploadAttemptInsertCache[key]
+	qsoUploadAttemptInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			qsoUploadAttemptAllColumns,
+			qsoUploadAttemptColumnsWithDefault,
+			qsoUploadAttemptColumnsWithoutDefault,
+			nzDefaults,
+		)
+		wl = strmangle.SetComplement(wl, qsoUploadAttemptGeneratedColumns)
+
+		cache.valueMapping, err = queries.BindMapping(qsoUploadAttemptType, qsoUploadAttemptMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(qsoUploadAttemptType, qsoUploadAttemptMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"qso_upload_attempt\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"qso_upload_attempt\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into qso_upload_attempt")
+	}
+
+	if !cached {
+		qsoUploadAttemptInsertCacheMut.Lock()
+		qsoUploadAttemptInsertCache[key] = cache
+		qsoUploadAttemptInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the QsoUploadAttempt.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *QsoUploadAttempt) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	key := makeCacheKey(columns, nil)
+	qsoUploadAttemptUpdateCacheMut.RLock()
+	cache, cached := qsoUploadAttemptUpdateCache[key]
+	qsoUploadAttemptUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			qsoUploadAttemptAllColumns,
+			qsoUploadAttemptPrimaryKeyColumns,
+		)
+		wl = strmangle.SetComplement(wl, qsoUploadAttemptGeneratedColumns)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update qso_upload_attempt, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"qso_upload_attempt\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, qsoUploadAttemptPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(qsoUploadAttemptType, qsoUploadAttemptMapping, append(wl, qsoUploadAttemptPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update qso_upload_attempt row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for qso_upload_attempt")
+	}
+
+	if !cached {
+		qsoUploadAttemptUpdateCacheMut.Lock()
+		qsoUploadAttemptUpdateCache[key] = cache
+		qsoUploadAttemptUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q qsoUploadAttemptQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for qso_upload_attempt")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for qso_upload_attempt")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o QsoUploadAttemptSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), qsoUploadAttemptPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"qso_upload_attempt\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, qsoUploadAttemptPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in qsoUploadAttempt slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all qsoUploadAttempt")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *QsoUploadAttempt) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no qso_upload_attempt provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(qsoUploadAttemptColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	qsoUploadAttemptUpsertCacheMut.RLock()
+	cache, cached := qsoUploadAttemptUpsertCache[key]
+	qsoUploadAttemptUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			qsoUploadAttemptAllColumns,
+			qsoUploadAttemptColumnsWithDefault,
+			qsoUploadAttemptColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			qsoUploadAttemptAllColumns,
+			qsoUploadAttemptPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert qso_upload_attempt, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(qsoUploadAttemptAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(qsoUploadAttemptPrimaryKeyColumns))
+			copy(conflict, qsoUploadAttemptPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQuerySQLite(dialect, "\"qso_upload_attempt\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(qsoUploadAttemptType, qsoUploadAttemptMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(qsoUploadAttemptType, qsoUploadAttemptMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert qso_upload_attempt")
+	}
+
+	if !cached {
+		qsoUploadAttemptUpsertCacheMut.Lock()
+		qsoUploadAttemptUpsertCache[key] = cache
+		qsoUploadAttemptUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single QsoUploadAttempt record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *QsoUploadAttempt) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no QsoUploadAttempt provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), qsoUploadAttemptPrimaryKeyMapping)
+	sql := "DELETE FROM \"qso_upload_attempt\" WHERE \"id\"=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from qso_upload_attempt")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for qso_upload_attempt")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q qsoUploadAttemptQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no qsoUploadAttemptQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from qso_upload_attempt")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for qso_upload_attempt")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o QsoUploadAttemptSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), qsoUploadAttemptPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"qso_upload_attempt\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, qsoUploadAttemptPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from qsoUploadAttempt slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for qso_upload_attempt")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *QsoUploadAttempt) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindQsoUploadAttempt(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *QsoUploadAttemptSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := QsoUploadAttemptSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), qsoUploadAttemptPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"qso_upload_attempt\".* FROM \"qso_upload_attempt\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, qsoUploadAttemptPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in QsoUploadAttemptSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// QsoUploadAttemptExists checks if the QsoUploadAttempt row exists.
+func QsoUploadAttemptExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"qso_upload_attempt\" where \"id\"=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if qso_upload_attempt exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the QsoUploadAttempt row exists.
+func (o *QsoUploadAttempt) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return QsoUploadAttemptExists(ctx, exec, o.ID)
+}
@@ -0,0 +1,199 @@
+package sqlite
+
+import (
+	"time"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/queries"
+)
+
+// qslDateLayout is the ADIF date format used by qso_date and the qsl date columns.
+const qslDateLayout = "20060102"
+
+// QslStatus is the QSL state of a QSO on one channel. Dates are YYYYMMDD and empty when unknown.
+type QslStatus struct {
+	QsoID      int64         `json:"qso_id"`
+	Channel    QslChannel    `json:"channel"`
+	SentStatus QslSentStatus `json:"sent_status"`
+	SentDate   string        `json:"sent_date"`
+	RcvdStatus QslRcvdStatus `json:"rcvd_status"`
+	RcvdDate   string        `json:"rcvd_date"`
+}
+
+// QsoQsl is a QSO together with its QSL state on one channel.
+type QsoQsl struct {
+	Qso types.Qso `json:"qso"`
+	Qsl QslStatus `json:"qsl"`
+}
+
+// qslRow is a qso row joined with the qsl columns needed to build a QsoQsl.
+type qslRow struct {
+	models.Qso `boil:",bind"`
+	Channel    string      `boil:"qsl_channel"`
+	SentStatus string      `boil:"qsl_sent_status"`
+	SentDate   null.String `boil:"qsl_sent_date"`
+	RcvdStatus string      `boil:"qsl_rcvd_status"`
+	RcvdDate   null.String `boil:"qsl_rcvd_date"`
+}
+
+func (r *qslRow) status() QslStatus {
+	return QslStatus{
+		QsoID:      r.ID,
+		Channel:    QslChannel(r.Channel),
+		SentStatus: QslSentStatus(r.SentStatus),
+		SentDate:   r.SentDate.String,
+		RcvdStatus: QslRcvdStatus(r.RcvdStatus),
+		RcvdDate:   r.RcvdDate.String,
+	}
+}
+
+func qslStatusFromModel(m *models.Qsl) QslStatus {
+	return QslStatus{
+		QsoID:      m.QsoID,
+		Channel:    QslChannel(m.Channel),
+		SentStatus: QslSentStatus(m.SentStatus),
+		SentDate:   m.SentDate.String,
+		RcvdStatus: QslRcvdStatus(m.RcvdStatus),
+		RcvdDate:   m.RcvdDate.String,
+	}
+}
+
+func validQslChannel(c QslChannel) bool {
+	switch c {
+	case QslChannelBureau, QslChannelDirect, QslChannelLotw, QslChannelEqsl, QslChannelQrz:
+		return true
+	}
+	return false
+}
+
+func validQslSentStatus(s QslSentStatus) bool {
+	switch s {
+	case QslSentYes, QslSentNo, QslSentRequested, QslSentQueued, QslSentIgnore:
+		return true
+	}
+	return false
+}
+
+func validQslRcvdStatus(s QslRcvdStatus) bool {
+	switch s {
+	case QslRcvdYes, QslRcvdNo, QslRcvdRequested, QslRcvdIgnore, QslRcvdVerified:
+		return true
+	}
+	return false
+}
+
+// validQslDate accepts an empty date or a valid YYYYMMDD date.
+func validQslDate(date string) bool {
+	if date == emptyString {
+		return true
+	}
+	_, err := time.Parse(qslDateLayout, date)
+	return err == nil
+}
+
+// SetQslStatus stores the complete QSL state of a QSO on one channel, replacing any earlier state.
+func (t *Tx) SetQslStatus(qsl QslStatus) error {
+	const op errors.Op = "sqlite.Tx.SetQslStatus"
+
+	if qsl.QsoID < 1 {
+		return errors.New(op).Msg(errMsgInvalidId)
+	}
+	if !validQslChannel(qsl.Channel) {
+		return errors.New(op).Msgf("Unknown QSL channel: %q", qsl.Channel)
+	}
+	if qsl.SentStatus == emptyString {
+		qsl.SentStatus = QslSentNo
+	}
+	if !validQslSentStatus(qsl.SentStatus) {
+		return errors.New(op).Msgf("Unknown QSL sent status: %q", qsl.SentStatus)
+	}
+	if qsl.RcvdStatus == emptyString {
+		qsl.RcvdStatus = QslRcvdNo
+	}
+	if !validQslRcvdStatus(qsl.RcvdStatus) {
+		return errors.New(op).Msgf("Unknown QSL received status: %q", qsl.RcvdStatus)
+	}
+	if !validQslDate(qsl.SentDate) || !validQslDate(qsl.RcvdDate) {
+		return errors.New(op).Msg("QSL dates must be in YYYYMMDD format.")
+	}
+
+	const upsert = `
+		INSERT INTO qsl (qso_id, channel, sent_status, sent_date, rcvd_status, rcvd_date)
+		VALUES (?, ?, ?, NULLIF(?, ''), ?, NULLIF(?, ''))
+		ON CONFLICT (qso_id, channel) DO UPDATE
+		   SET sent_status = excluded.sent_status,
+		       sent_date   = excluded.sent_date,
+		       rcvd_status = excluded.rcvd_status,
+		       rcvd_date   = excluded.rcvd_date`
+
+	_, err := queries.Raw(upsert, qsl.QsoID, qsl.Channel.String(), qsl.SentStatus.String(), qsl.SentDate,
+		qsl.RcvdStatus.String(), qsl.RcvdDate).ExecContext(t.ctx, t.tx)
+	if err != nil {
+		return errors.New(op).Err(err).Msgf("Failed to set QSL status for QSO %d.", qsl.QsoID)
+	}
+
+	return nil
+}
+
+// MarkQslSent marks the QSOs as sent on the channel. An empty date means today (UTC). The received side of each
+// QSL is left as it is.
+func (t *Tx) MarkQslSent(channel QslChannel, qsoIDs []int64, date string) error {
+	const op errors.Op = "sqlite.Tx.MarkQslSent"
+
+	const upsert = `
+		INSERT INTO qsl (qso_id, channel, sent_status, sent_date)
+		VALUES (?, ?, 'Y', ?)
+		ON CONFLICT (qso_id, channel) DO UPDATE
+		   SET sent_status = excluded.sent_status,
+		       sent_date   = excluded.sent_date`
+
+	return t.markQsl(op, upsert, channel, qsoIDs, date)
+}
+
+// MarkQslReceived marks the QSOs as confirmed on the channel. An empty date means today (UTC). The sent side of
+// each QSL is left as it is.
+func (t *Tx) MarkQslReceived(channel QslChannel, qsoIDs []int64, date string) error {
+	const op errors.Op = "sqlite.Tx.MarkQslReceived"
+
+	const upsert = `
+		INSERT INTO qsl (qso_id, channel, rcvd_status, rcvd_date)
+		VALUES (?, ?, 'Y', ?)
+		ON CONFLICT (qso_id, channel) DO UPDATE
+		   SET rcvd_status = excluded.rcvd_status,
+		       rcvd_date   = excluded.rcvd_date`
+
+	return t.markQsl(op, upsert, channel, qsoIDs, date)
+}
+
+// markQsl runs upsert once per QSO with the arguments (qso_id, channel, date).
+func (t *Tx) markQsl(op errors.Op, upsert string, channel QslChannel, qsoIDs []int64, date string) error {
+	if !validQslChannel(channel) {
+		return errors.New(op).Msgf("Unknown QSL channel: %q", channel)
+	}
+	if date == emptyString {
+		date = time.Now().UTC().Format(qslDateLayout)
+	}
+	if !validQslDate(date) {
+		return errors.New(op).Msg("QSL date must be in YYYYMMDD format.")
+	}
+
+	stmt, err := t.tx.PrepareContext(t.ctx, upsert)
+	if err != nil {
+		return errors.New(op).Err(err).Msg("Failed to prepare QSL statement.")
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for _, id := range qsoIDs {
+		if id < 1 {
+			return errors.New(op).Msgf("QSO ID is invalid: %d", id)
+		}
+		if _, err = stmt.ExecContext(t.ctx, id, channel.String(), date); err != nil {
+			return errors.New(op).Err(err).Msgf("Failed to update QSL for QSO %d.", id)
+		}
+	}
+
+	return nil
+}
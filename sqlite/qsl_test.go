@@ -0,0 +1,103 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidQslDate(t *testing.T) {
+	assert.True(t, validQslDate(""))
+	assert.True(t, validQslDate("20250228"))
+	assert.False(t, validQslDate("20250230"))
+	assert.False(t, validQslDate("2025-02-28"))
+	assert.False(t, validQslDate("250228"))
+}
+
+func TestValidQslChannel(t *testing.T) {
+	for _, c := range QslChannelNames {
+		assert.True(t, validQslChannel(c.Value), c.TSName)
+	}
+	assert.False(t, validQslChannel("card"))
+	assert.False(t, validQslChannel(""))
+}
+
+func TestQslTracking(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	insert := func(call, timeOn string) int64 {
+		t.Helper()
+		id, err := s.InsertQso(testQso(lb, sess, call, "20250107", timeOn))
+		require.NoError(t, err)
+		return id
+	}
+	first := insert("DL1ABC", "1430")
+	second := insert("DL2ABC", "1431")
+	third := insert("DL3ABC", "1432")
+
+	ids := func(list []QsoQsl, err error) []int64 {
+		t.Helper()
+		require.NoError(t, err)
+		out := make([]int64, len(list))
+		for i, q := range list {
+			out[i] = q.Qso.ID
+		}
+		return out
+	}
+
+	// Requested and queued bureau cards; the direct card for the first QSO is not requested.
+	require.NoError(t, s.SetQslStatus(QslStatus{QsoID: second, Channel: QslChannelBureau, SentStatus: QslSentQueued}))
+	require.NoError(t, s.SetQslStatus(QslStatus{QsoID: first, Channel: QslChannelBureau, SentStatus: QslSentRequested}))
+	require.NoError(t, s.SetQslStatus(QslStatus{QsoID: first, Channel: QslChannelDirect}))
+	assert.Equal(t, []int64{first, second}, ids(s.FetchQslRequestedNotSent(lb, QslChannelBureau)), "oldest QSO first")
+	assert.Empty(t, ids(s.FetchQslRequestedNotSent(lb, QslChannelDirect)))
+
+	statuses, err := s.FetchQslStatusByQsoId(first)
+	require.NoError(t, err)
+	assert.Equal(t, []QslStatus{
+		{QsoID: first, Channel: QslChannelBureau, SentStatus: QslSentRequested, RcvdStatus: QslRcvdNo},
+		{QsoID: first, Channel: QslChannelDirect, SentStatus: QslSentNo, RcvdStatus: QslRcvdNo},
+	}, statuses)
+
+	// Marking them sent moves them to the unconfirmed list, across channels.
+	require.NoError(t, s.MarkQslSent(QslChannelBureau, []int64{first, second}, "20250201"))
+	require.NoError(t, s.MarkQslSent(QslChannelLotw, []int64{third}, emptyString))
+	assert.Empty(t, ids(s.FetchQslRequestedNotSent(lb, emptyString)))
+	assert.Equal(t, []int64{first, second, third}, ids(s.FetchQslSentNotConfirmed(lb, emptyString)))
+	assert.Equal(t, []int64{third}, ids(s.FetchQslSentNotConfirmed(lb, QslChannelLotw)))
+	statuses, err = s.FetchQslStatusByQsoId(third)
+	require.NoError(t, err)
+	assert.Equal(t, time.Now().UTC().Format("20060102"), statuses[0].SentDate, "empty date means today")
+
+	// Receiving keeps the sent side.
+	require.NoError(t, s.MarkQslReceived(QslChannelBureau, []int64{first}, "20250301"))
+	statuses, err = s.FetchQslStatusByQsoId(first)
+	require.NoError(t, err)
+	assert.Equal(t, QslStatus{QsoID: first, Channel: QslChannelBureau, SentStatus: QslSentYes, SentDate: "20250201",
+		RcvdStatus: QslRcvdYes, RcvdDate: "20250301"}, statuses[0])
+	assert.Equal(t, []int64{second, third}, ids(s.FetchQslSentNotConfirmed(lb, emptyString)))
+
+	// SetQslStatus replaces the whole state; verified cards count as confirmed.
+	require.NoError(t, s.SetQslStatus(QslStatus{QsoID: second, Channel: QslChannelBureau, SentStatus: QslSentYes,
+		RcvdStatus: QslRcvdVerified}))
+	statuses, err = s.FetchQslStatusByQsoId(second)
+	require.NoError(t, err)
+	assert.Empty(t, statuses[0].SentDate)
+	assert.Equal(t, []int64{third}, ids(s.FetchQslSentNotConfirmed(lb, emptyString)))
+
+	// Deleted QSOs are not listed.
+	require.NoError(t, s.DeleteQso(third))
+	assert.Empty(t, ids(s.FetchQslSentNotConfirmed(lb, emptyString)))
+
+	// A bad ID rolls back the whole mark.
+	assert.Error(t, s.MarkQslReceived(QslChannelDirect, []int64{first, third + 100}, "20250301"))
+	statuses, err = s.FetchQslStatusByQsoId(first)
+	require.NoError(t, err)
+	assert.Equal(t, QslRcvdNo, statuses[1].RcvdStatus)
+
+	assert.Error(t, s.MarkQslSent("pigeon", []int64{first}, emptyString))
+	assert.Error(t, s.MarkQslSent(QslChannelBureau, []int64{first}, "2025-02-01"))
+	assert.Error(t, s.SetQslStatus(QslStatus{QsoID: first, Channel: QslChannelBureau, SentStatus: "X"}))
+}
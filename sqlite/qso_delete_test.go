@@ -0,0 +1,188 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/enums/upload"
+	"github.com/Station-Manager/enums/upload/action"
+	"github.com/Station-Manager/enums/upload/status"
+	"github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// qsoUploads returns the QSO's uploads to the service as action -> status.
+func qsoUploads(t *testing.T, s *Service, qsoID int64, service upload.OnlineService) map[string]string {
+	t.Helper()
+	slice, err := models.QsoUploads(
+		models.QsoUploadWhere.QsoID.EQ(qsoID),
+		models.QsoUploadWhere.Service.EQ(service.String()),
+	).All(context.Background(), s.handle)
+	require.NoError(t, err)
+	out := make(map[string]string, len(slice))
+	for _, up := range slice {
+		out[up.Action] = up.Status
+	}
+	return out
+}
+
+// uploadNext reserves the one pending upload and reports it uploaded.
+func uploadNext(t *testing.T, s *Service) {
+	t.Helper()
+	uploads, err := s.FetchPendingUploads()
+	require.NoError(t, err)
+	require.Len(t, uploads, 1)
+	require.NoError(t, s.UpdateQsoUploadStatus(uploads[0].ID, status.Uploaded, action.Action(uploads[0].Action), 1, emptyString))
+}
+
+func TestDeleteQso(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	id, err := s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1430"))
+	require.NoError(t, err)
+
+	// QRZ holds the QSO and has an update queued; LoTW has not been sent it yet.
+	require.NoError(t, s.InsertQsoUpload(id, action.Insert, upload.OnlineServiceQRZ))
+	uploadNext(t, s)
+	require.NoError(t, s.InsertQsoUpload(id, action.Update, upload.OnlineServiceQRZ))
+	require.NoError(t, s.InsertQsoUpload(id, action.Insert, upload.OnlineServiceLoTW))
+
+	require.NoError(t, s.DeleteQso(id))
+
+	_, err = s.FetchQsoById(id)
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+	deleted, err := s.FetchDeletedQsosByLogbookId(lb)
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, id, deleted[0].ID)
+
+	assert.Equal(t, map[string]string{
+		"insert": status.Uploaded.String(),
+		"delete": status.Pending.String(),
+	}, qsoUploads(t, s, id, upload.OnlineServiceQRZ))
+	assert.Empty(t, qsoUploads(t, s, id, upload.OnlineServiceLoTW))
+
+	assert.ErrorIs(t, s.DeleteQso(id), errors.ErrNotFound)
+}
+
+func TestDeleteQsoWhileUploading(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	_, err := s.SetForwardingRule(ForwardingRule{LogbookID: lb, Service: upload.OnlineServiceQRZ, Enabled: true})
+	require.NoError(t, err)
+
+	reserve := func() types.QsoUpload {
+		t.Helper()
+		uploads, er := s.FetchPendingUploadsForService(upload.OnlineServiceQRZ, 10)
+		require.NoError(t, er)
+		require.Len(t, uploads, 1)
+		return uploads[0]
+	}
+
+	// The insert gets through after the QSO was deleted: the service is sent the delete.
+	id, err := s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1430"))
+	require.NoError(t, err)
+	insert := reserve()
+	require.NoError(t, s.DeleteQso(id))
+	require.NoError(t, s.UpdateQsoUploadStatus(insert.ID, status.Uploaded, emptyString, 1, emptyString))
+	assert.Equal(t, map[string]string{
+		"insert": status.Uploaded.String(),
+		"delete": status.Pending.String(),
+	}, qsoUploads(t, s, id, upload.OnlineServiceQRZ))
+	assert.Equal(t, action.Delete.String(), reserve().Action)
+
+	// The insert fails after the QSO was deleted: there is nothing to delete and nothing left to send.
+	id, err = s.InsertQso(testQso(lb, sess, "DL2ABC", "20250107", "1431"))
+	require.NoError(t, err)
+	insert = reserve()
+	require.NoError(t, s.DeleteQso(id))
+	require.NoError(t, s.UpdateQsoUploadStatus(insert.ID, status.Failed, emptyString, 1, "timeout"))
+	assert.Empty(t, qsoUploads(t, s, id, upload.OnlineServiceQRZ))
+	attempts, err := s.FetchUploadAttemptsByQsoId(id)
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	assert.Equal(t, "timeout", attempts[0].Error)
+}
+
+func TestRestoreQso(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	id, err := s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1430"))
+	require.NoError(t, err)
+	require.NoError(t, s.InsertQsoUpload(id, action.Insert, upload.OnlineServiceQRZ))
+	uploadNext(t, s)
+
+	assert.Error(t, s.RestoreQso(id), "not deleted")
+
+	// Restored before the delete went out: the queued delete is dropped and QRZ keeps its copy.
+	require.NoError(t, s.DeleteQso(id))
+	require.NoError(t, s.RestoreQso(id))
+	_, err = s.FetchQsoById(id)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"insert": status.Uploaded.String()}, qsoUploads(t, s, id, upload.OnlineServiceQRZ))
+
+	// Restored after QRZ processed the delete: the QSO is sent again.
+	require.NoError(t, s.DeleteQso(id))
+	uploadNext(t, s)
+	require.NoError(t, s.RestoreQso(id))
+	assert.Equal(t, map[string]string{
+		"insert": status.Pending.String(),
+		"delete": status.Uploaded.String(),
+	}, qsoUploads(t, s, id, upload.OnlineServiceQRZ))
+
+	// QRZ no longer holds a copy, so deleting again only drops the queued insert.
+	require.NoError(t, s.DeleteQso(id))
+	assert.Equal(t, map[string]string{"delete": status.Uploaded.String()}, qsoUploads(t, s, id, upload.OnlineServiceQRZ))
+
+	assert.ErrorIs(t, s.RestoreQso(id+1), errors.ErrNotFound)
+}
+
+func TestPurgeDeletedQsosOlderThan(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	kept, err := s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1430"))
+	require.NoError(t, err)
+	waiting, err := s.InsertQso(testQso(lb, sess, "DL2ABC", "20250107", "1431"))
+	require.NoError(t, err)
+	purged, err := s.InsertQso(testQso(lb, sess, "DL3ABC", "20250107", "1432"))
+	require.NoError(t, err)
+
+	// The delete queued for QRZ holds back the purge of its QSO.
+	require.NoError(t, s.InsertQsoUpload(waiting, action.Insert, upload.OnlineServiceQRZ))
+	uploadNext(t, s)
+	require.NoError(t, s.DeleteQso(waiting))
+	require.NoError(t, s.DeleteQso(purged))
+
+	_, err = s.PurgeDeletedQsosOlderThan(-time.Second)
+	assert.Error(t, err)
+
+	n, err := s.PurgeDeletedQsosOlderThan(time.Hour)
+	require.NoError(t, err)
+	assert.Zero(t, n, "deleted too recently")
+
+	n, err = s.PurgeDeletedQsosOlderThan(0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+
+	_, err = models.Qsos(models.QsoWhere.ID.EQ(purged)).One(context.Background(), s.handle)
+	assert.Error(t, err, "purged QSOs are gone for good")
+	deleted, err := s.FetchDeletedQsosByLogbookId(lb)
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, waiting, deleted[0].ID)
+	_, err = s.FetchQsoById(kept)
+	assert.NoError(t, err)
+
+	// Once the delete has gone out, the QSO can be purged too.
+	uploadNext(t, s)
+	n, err = s.PurgeDeletedQsosOlderThan(0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+	deleted, err = s.FetchDeletedQsosByLogbookId(lb)
+	require.NoError(t, err)
+	assert.Empty(t, deleted)
+}
@@ -0,0 +1,102 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	stderr "errors"
+	"iter"
+
+	"github.com/Station-Manager/database/sqlite/adapters"
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+)
+
+// qsoChronological orders QSOs oldest first, with the ID as a tie-breaker.
+const qsoChronological = `"qso"."qso_date", "qso"."time_on", "qso"."id"`
+
+// IterateQsos streams the QSOs matching filter, oldest first, adapting each row only when it is reached. A QSO
+// that cannot be adapted is yielded as an error (with a zero types.Qso carrying just its ID) and iteration
+// continues; a database error ends it. Breaking out of the loop closes the rows and releases the connection.
+//
+// The connection is held for the whole loop, so do not call other Service methods from inside it. The context
+// timeout (ContextTimeout when ctx has no deadline) covers the whole iteration.
+func (s *Service) IterateQsos(ctx context.Context, filter QsoFilter) iter.Seq2[types.Qso, error] {
+	const op errors.Op = "sqlite.Service.IterateQsos"
+
+	return func(yield func(types.Qso, error) bool) {
+		if err := checkService(op, s); err != nil {
+			yield(types.Qso{}, err)
+			return
+		}
+
+		mods, err := filter.whereMods()
+		if err != nil {
+			yield(types.Qso{}, errors.New(op).Err(err))
+			return
+		}
+		mods = append(mods, qm.OrderBy(qsoChronological))
+
+		h, err := s.executor(ctx, op)
+		if err != nil {
+			yield(types.Qso{}, err)
+			return
+		}
+
+		ctx, cancel := s.ensureCtxTimeout(ctx)
+		defer cancel()
+
+		for model, err := range iterateQsoModels(ctx, h, mods) {
+			if err != nil {
+				yield(types.Qso{}, errors.New(op).Err(err).Msg("Failed to read QSOs."))
+				return
+			}
+
+			qso, er := adapters.QsoModelToType(model)
+			if er != nil {
+				if !yield(types.Qso{ID: model.ID}, errors.New(op).Err(er).Msgf("Failed to adapt QSO: %d", model.ID)) {
+					return
+				}
+				continue
+			}
+
+			if !yield(qso, nil) {
+				return
+			}
+		}
+	}
+}
+
+// iterateQsoModels yields the qso rows matched by mods one at a time. After an error nothing more is yielded. The
+// rows are closed when the loop ends, including when the caller stops early.
+func iterateQsoModels(ctx context.Context, exec boil.ContextExecutor, mods []qm.QueryMod) iter.Seq2[*models.Qso, error] {
+	return func(yield func(*models.Qso, error) bool) {
+		rows, err := models.Qsos(mods...).QueryContext(ctx, exec)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer func() { _ = rows.Close() }()
+
+		for {
+			model := &models.Qso{}
+			if err = queries.Bind(rows, model); err != nil {
+				if !stderr.Is(err, sql.ErrNoRows) {
+					yield(nil, err)
+					return
+				}
+				break
+			}
+			if !yield(model, nil) {
+				return
+			}
+		}
+
+		if err = rows.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Station-Manager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterateQsos(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+
+	var ids []int64
+	for _, call := range []string{"DL1ABC", "DL2ABC", "DL3ABC"} {
+		id, err := s.InsertQso(testQso(lb, sess, call, "20250107", "1430"))
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	other, err := s.InsertLogbook(types.Logbook{Name: "Other", Callsign: "W1AW"})
+	require.NoError(t, err)
+	_, err = s.InsertQso(testQso(other, sess, "DL9XYZ", "20250101", "0000"))
+	require.NoError(t, err)
+
+	// The second QSO cannot be adapted.
+	_, err = s.handle.Exec(`UPDATE qso SET additional_data = '{"name":5}' WHERE id = ?`, ids[1])
+	require.NoError(t, err)
+
+	filter := QsoFilter{LogbookID: lb}
+
+	t.Run("adapt errors carry the ID and iteration continues", func(t *testing.T) {
+		var calls []string
+		var failed []int64
+		for qso, err := range s.IterateQsos(context.Background(), filter) {
+			if err != nil {
+				assert.ErrorContains(t, err, "Failed to adapt QSO")
+				failed = append(failed, qso.ID)
+				continue
+			}
+			calls = append(calls, qso.ContactedStation.Call)
+		}
+		assert.Equal(t, []string{"DL1ABC", "DL3ABC"}, calls)
+		assert.Equal(t, []int64{ids[1]}, failed)
+	})
+
+	t.Run("rows are adapted lazily and released on break", func(t *testing.T) {
+		// A deadline of its own means IterateQsos adds no timeout whose cancel would close the rows anyway.
+		iterCtx, iterCancel := context.WithTimeout(context.Background(), time.Minute)
+		defer iterCancel()
+
+		var seen int
+		for qso, err := range s.IterateQsos(iterCtx, filter) {
+			// Breaking before the second row means its adapt error is never produced.
+			require.NoError(t, err)
+			assert.Equal(t, ids[0], qso.ID)
+			seen++
+			break
+		}
+		assert.Equal(t, 1, seen)
+
+		// The test service has a single connection, so this only works if the break closed the rows.
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		qso, err := s.FetchQsoByIdWithContext(ctx, ids[2])
+		require.NoError(t, err)
+		assert.Equal(t, "DL3ABC", qso.ContactedStation.Call)
+	})
+
+	t.Run("filter errors are yielded", func(t *testing.T) {
+		var errs int
+		for _, err := range s.IterateQsos(context.Background(), QsoFilter{CallMatch: "sounds-like", Call: "DL"}) {
+			assert.Error(t, err)
+			errs++
+		}
+		assert.Equal(t, 1, errs)
+	})
+}
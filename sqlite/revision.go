@@ -0,0 +1,161 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/enums/upload/action"
+	"github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/goccy/go-json"
+)
+
+// QsoRevision is a previous version of a QSO, captured just before it was updated or deleted.
+type QsoRevision struct {
+	ID        int64         `json:"id"`
+	QsoID     int64         `json:"qso_id"`
+	CreatedAt time.Time     `json:"created_at"`
+	Action    action.Action `json:"action"` // action.Update or action.Delete
+	Reason    string        `json:"reason"`
+	Qso       types.Qso     `json:"qso"`
+}
+
+// QsoFieldChange is a field that differs between two versions of a QSO. Field is the qso column name, or the
+// additional_data key for fields stored there.
+type QsoFieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+type changeReasonCtxKey struct{}
+
+// WithChangeReason returns a context that records reason on the revisions written by QSO updates and deletes made
+// with it.
+func WithChangeReason(ctx context.Context, reason string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, changeReasonCtxKey{}, reason)
+}
+
+func changeReason(ctx context.Context) string {
+	reason, _ := ctx.Value(changeReasonCtxKey{}).(string)
+	return reason
+}
+
+// recordQsoRevision copies the current qso row into qso_revision. It returns errors.ErrNotFound if the QSO does
+// not exist.
+func recordQsoRevision(ctx context.Context, exec boil.ContextExecutor, qsoID int64, act action.Action, reason string) error {
+	const op errors.Op = "sqlite.recordQsoRevision"
+
+	const snapshot = `
+		INSERT INTO qso_revision (qso_id, action, reason, qso_created_at, qso_modified_at, qso_deleted_at, call, band,
+		                          mode, freq, qso_date, time_on, time_off, rst_sent, rst_rcvd, country,
+		                          additional_data, logbook_id, session_id)
+		SELECT id, ?, NULLIF(?, ''), created_at, modified_at, deleted_at, call, band, mode, freq, qso_date, time_on,
+		       time_off, rst_sent, rst_rcvd, country, additional_data, logbook_id, session_id
+		  FROM qso
+		 WHERE id = ?`
+
+	res, err := queries.Raw(snapshot, act.String(), reason, qsoID).ExecContext(ctx, exec)
+	if err != nil {
+		return errors.New(op).Err(err).Msgf("Failed to record revision for QSO %d.", qsoID)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+// qsoModelFromRevision rebuilds the qso row held by a revision.
+func qsoModelFromRevision(rev *models.QsoRevision) *models.Qso {
+	return &models.Qso{
+		ID:             rev.QsoID,
+		CreatedAt:      rev.QsoCreatedAt,
+		ModifiedAt:     rev.QsoModifiedAt,
+		DeletedAt:      rev.QsoDeletedAt,
+		Call:           rev.Call,
+		Band:           rev.Band,
+		Mode:           rev.Mode,
+		Freq:           rev.Freq,
+		QsoDate:        rev.QsoDate,
+		TimeOn:         rev.TimeOn,
+		TimeOff:        rev.TimeOff,
+		RstSent:        rev.RstSent,
+		RstRcvd:        rev.RstRcvd,
+		Country:        rev.Country,
+		AdditionalData: rev.AdditionalData,
+		LogbookID:      rev.LogbookID,
+		SessionID:      rev.SessionID,
+	}
+}
+
+// diffQsoModels lists the fields that differ between two versions of a QSO, core columns first and then
+// additional_data keys in alphabetical order. Timestamps are not compared.
+func diffQsoModels(from, to *models.Qso) ([]QsoFieldChange, error) {
+	const op errors.Op = "sqlite.diffQsoModels"
+
+	var changes []QsoFieldChange
+	add := func(field string, a, b any) {
+		if !reflect.DeepEqual(a, b) {
+			changes = append(changes, QsoFieldChange{Field: field, From: a, To: b})
+		}
+	}
+
+	add(models.QsoColumns.Call, from.Call, to.Call)
+	add(models.QsoColumns.Band, from.Band, to.Band)
+	add(models.QsoColumns.Mode, from.Mode, to.Mode)
+	add(models.QsoColumns.Freq, from.Freq, to.Freq)
+	add(models.QsoColumns.QsoDate, from.QsoDate, to.QsoDate)
+	add(models.QsoColumns.TimeOn, from.TimeOn, to.TimeOn)
+	add(models.QsoColumns.TimeOff, from.TimeOff, to.TimeOff)
+	add(models.QsoColumns.RstSent, from.RstSent, to.RstSent)
+	add(models.QsoColumns.RstRcvd, from.RstRcvd, to.RstRcvd)
+	add(models.QsoColumns.Country, from.Country, to.Country)
+	add(models.QsoColumns.LogbookID, from.LogbookID, to.LogbookID)
+	add(models.QsoColumns.SessionID, from.SessionID, to.SessionID)
+
+	fromData, err := additionalDataMap(from.AdditionalData)
+	if err != nil {
+		return nil, errors.New(op).Err(err)
+	}
+	toData, err := additionalDataMap(to.AdditionalData)
+	if err != nil {
+		return nil, errors.New(op).Err(err)
+	}
+
+	keys := make([]string, 0, len(fromData)+len(toData))
+	for k := range fromData {
+		keys = append(keys, k)
+	}
+	for k := range toData {
+		if _, ok := fromData[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		add(k, fromData[k], toData[k])
+	}
+
+	return changes, nil
+}
+
+func additionalDataMap(data []byte) (map[string]any, error) {
+	m := map[string]any{}
+	if len(data) == 0 {
+		return m, nil
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
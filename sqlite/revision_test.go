@@ -0,0 +1,119 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/enums/upload/action"
+	"github.com/Station-Manager/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffQsoModels(t *testing.T) {
+	from := &models.Qso{Call: "DL1ABC", Band: "20m", Mode: "SSB", Freq: 14250,
+		AdditionalData: []byte(`{"notes":"first","gridsquare":"JO62"}`)}
+	to := &models.Qso{Call: "DL1ABC", Band: "40m", Mode: "SSB", Freq: 7150,
+		AdditionalData: []byte(`{"gridsquare":"JO62","name":"Hans"}`)}
+
+	changes, err := diffQsoModels(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, []QsoFieldChange{
+		{Field: "band", From: "20m", To: "40m"},
+		{Field: "freq", From: int64(14250), To: int64(7150)},
+		{Field: "name", From: nil, To: "Hans"},
+		{Field: "notes", From: "first", To: nil},
+	}, changes)
+}
+
+func TestDiffQsoModels_NoChanges(t *testing.T) {
+	qso := &models.Qso{Call: "K1ABC", AdditionalData: []byte(`{}`)}
+
+	changes, err := diffQsoModels(qso, qso)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestQsoRevisions(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	qso := testQso(lb, sess, "DL1ABC", "20250107", "1430")
+	qso.Notes = "first"
+	id, err := s.InsertQso(qso)
+	require.NoError(t, err)
+
+	update := func(ctx context.Context, rst, notes string) {
+		t.Helper()
+		q, er := s.FetchQsoById(id)
+		require.NoError(t, er)
+		q.RstSent, q.Notes = rst, notes
+		require.NoError(t, s.UpdateQsoWithContext(ctx, q))
+	}
+	update(WithChangeReason(context.Background(), "typo"), "57", "second")
+	update(context.Background(), "55", "second")
+
+	// Each revision holds the QSO as it was before the change; newest first.
+	revisions, err := s.FetchQsoRevisions(id)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	first, second := revisions[1], revisions[0]
+	assert.Equal(t, action.Update, first.Action)
+	assert.Equal(t, "typo", first.Reason)
+	assert.Equal(t, "59", first.Qso.RstSent)
+	assert.Equal(t, "first", first.Qso.Notes)
+	assert.Empty(t, second.Reason)
+	assert.Equal(t, "57", second.Qso.RstSent)
+
+	changes, err := s.DiffQsoRevisions(first.ID, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []QsoFieldChange{
+		{Field: "rst_sent", From: "59", To: "57"},
+		{Field: "notes", From: "first", To: "second"},
+	}, changes)
+	changes, err = s.DiffQsoRevisions(second.ID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []QsoFieldChange{{Field: "rst_sent", From: "57", To: "55"}}, changes, "against the current QSO")
+
+	require.NoError(t, s.DeleteQsoWithContext(WithChangeReason(context.Background(), "not in log"), id))
+	revisions, err = s.FetchQsoRevisions(id)
+	require.NoError(t, err)
+	require.Len(t, revisions, 3)
+	deleted := revisions[0]
+	assert.Equal(t, action.Delete, deleted.Action)
+	assert.Equal(t, "not in log", deleted.Reason)
+	assert.Equal(t, "55", deleted.Qso.RstSent)
+
+	// Reverting to the delete revision brings the QSO back as it was when deleted.
+	require.NoError(t, s.RevertQsoToRevision(deleted.ID))
+	current, err := s.FetchQsoById(id)
+	require.NoError(t, err)
+	assert.Equal(t, "55", current.RstSent)
+
+	require.NoError(t, s.RevertQsoToRevision(first.ID))
+	current, err = s.FetchQsoById(id)
+	require.NoError(t, err)
+	assert.Equal(t, "59", current.RstSent)
+	assert.Equal(t, "first", current.Notes)
+
+	// The replaced version becomes a revision too.
+	revisions, err = s.FetchQsoRevisions(id)
+	require.NoError(t, err)
+	require.Len(t, revisions, 5)
+	assert.Equal(t, fmt.Sprintf("Reverted to revision %d", first.ID), revisions[0].Reason)
+	assert.Equal(t, "55", revisions[0].Qso.RstSent)
+
+	other, err := s.InsertQso(testQso(lb, sess, "DL2ABC", "20250107", "1431"))
+	require.NoError(t, err)
+	q, err := s.FetchQsoById(other)
+	require.NoError(t, err)
+	require.NoError(t, s.UpdateQso(q))
+	otherRevisions, err := s.FetchQsoRevisions(other)
+	require.NoError(t, err)
+	require.Len(t, otherRevisions, 1)
+	_, err = s.DiffQsoRevisions(first.ID, otherRevisions[0].ID)
+	assert.Error(t, err, "revisions of different QSOs")
+
+	assert.ErrorIs(t, s.RevertQsoToRevision(otherRevisions[0].ID+100), errors.ErrNotFound)
+}
@@ -22,7 +22,13 @@ type Service struct {
 	LoggerService  *logging.Service `di.inject:"loggingservice"`
 	DatabaseConfig *types.DatastoreConfig
 
-	requiredCfgs types.RequiredConfigs
+	requiredCfgs   types.RequiredConfigs
+	duplicates     duplicateGuard
+	stationSync    ContactedStationSync
+	uploadBackoffs map[string]UploadBackoff // Keyed by service; "" holds the default
+	lease          uploadLease
+	uploadRound    atomic.Uint64 // Rotates the service served first by FetchPendingUploads
+	changes        changeHub
 
 	handle *sql.DB
 
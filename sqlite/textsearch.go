@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/Station-Manager/types"
+)
+
+const (
+	// defaultTextSearchLimit caps the number of QSOs returned by SearchQsoTextWithContext.
+	defaultTextSearchLimit = 100
+
+	// Markers placed around matched terms in QsoTextMatch.Snippet.
+	snippetMatchOpen  = "["
+	snippetMatchClose = "]"
+	snippetEllipsis   = "…"
+	snippetMaxTokens  = 10
+)
+
+// QsoTextMatch is a QSO found by a full-text search. Lower Rank values are better matches (bm25).
+type QsoTextMatch struct {
+	Qso     types.Qso `json:"qso"`
+	Rank    float64   `json:"rank"`
+	Snippet string    `json:"snippet"`
+}
+
+// ftsMatchQuery turns free text typed by a user into an FTS5 MATCH expression. Every word becomes a quoted term,
+// so FTS5 operators and punctuation in the input are matched literally; a trailing '*' on a word is kept as a
+// prefix search. All terms must match. An empty string is returned if there is nothing to search for.
+func ftsMatchQuery(input string) string {
+	words := strings.FieldsFunc(input, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '"'
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, w := range words {
+		prefix := strings.HasSuffix(w, "*")
+		w = strings.TrimRight(w, "*")
+		if w == emptyString {
+			continue
+		}
+		term := `"` + w + `"`
+		if prefix {
+			term += "*"
+		}
+		terms = append(terms, term)
+	}
+
+	return strings.Join(terms, " ")
+}
@@ -0,0 +1,90 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/Station-Manager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFtsMatchQuery(t *testing.T) {
+	assert.Equal(t, `"strong" "signal"`, ftsMatchQuery("  strong   signal "))
+	assert.Equal(t, `"mun"*`, ftsMatchQuery("mun*"))
+	assert.Equal(t, `"OR" "NEAR(x"`, ftsMatchQuery(`"OR" NEAR(x`))
+	assert.Equal(t, "", ftsMatchQuery(` * "" `))
+}
+
+func TestSearchQsoText(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	other, err := s.InsertLogbook(types.Logbook{Name: "Other", Callsign: "W1AW"})
+	require.NoError(t, err)
+
+	insert := func(logbookID int64, call, notes string) int64 {
+		t.Helper()
+		q := testQso(logbookID, sess, call, "20250107", "1430")
+		q.Notes = notes
+		id, er := s.InsertQso(q)
+		require.NoError(t, er)
+		return id
+	}
+	calls := func(query string) []string {
+		t.Helper()
+		matches, er := s.SearchQsoText(lb, query)
+		require.NoError(t, er)
+		out := make([]string, len(matches))
+		for i, m := range matches {
+			out[i] = m.Qso.Call
+		}
+		return out
+	}
+
+	munich := insert(lb, "DL1ABC", "strong signal from Munich")
+	weak := insert(lb, "DL2ABC", "signal weak")
+	insert(other, "DL3ABC", "strong signal from Munich")
+	require.NoError(t, s.DeleteQso(insert(lb, "DL4ABC", "strong signal from Munich")))
+
+	// Other logbooks and deleted QSOs are left out; every word must match.
+	assert.Equal(t, []string{"DL1ABC"}, calls("strong signal"))
+	assert.Equal(t, []string{"DL1ABC"}, calls("mun*"))
+	assert.Empty(t, calls("strong weak"))
+
+	// The contacted station's name and QTH are indexed as well.
+	q := testQso(lb, sess, "DL5ABC", "20250107", "1430")
+	q.ContactedStation.Name = "Hans"
+	q.ContactedStation.QTH = "Hamburg"
+	_, err = s.InsertQso(q)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"DL5ABC"}, calls("hans hamburg"))
+
+	matches, err := s.SearchQsoText(lb, "munich")
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "strong signal from [Munich]", matches[0].Snippet)
+
+	// Updates re-index the QSO.
+	qso, err := s.FetchQsoById(munich)
+	require.NoError(t, err)
+	qso.Notes = "weak signal, weak copy"
+	require.NoError(t, s.UpdateQso(qso))
+	assert.Empty(t, calls("munich"))
+
+	// Best match first: lower bm25 ranks are better.
+	matches, err = s.SearchQsoText(lb, "weak")
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	assert.Equal(t, munich, matches[0].Qso.ID)
+	assert.Less(t, matches[0].Rank, matches[1].Rank)
+
+	// Purged QSOs leave the index.
+	require.NoError(t, s.DeleteQso(weak))
+	_, err = s.PurgeDeletedQsosOlderThan(0)
+	require.NoError(t, err)
+	var indexed int
+	require.NoError(t, s.handle.QueryRow(`SELECT count(*) FROM qso_fts WHERE rowid = ?`, weak).Scan(&indexed))
+	assert.Zero(t, indexed)
+
+	_, err = s.SearchQsoText(lb, ` "" `)
+	assert.Error(t, err)
+}
@@ -0,0 +1,178 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	stderr "errors"
+	"fmt"
+	"time"
+
+	"github.com/Station-Manager/errors"
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+// Tx is a unit of work bound to a single database transaction. It is only valid inside the function passed to
+// Service.WithTx (or Tx.WithTx) and must not be used from more than one goroutine.
+type Tx struct {
+	service *Service
+	ctx     context.Context
+	tx      *sql.Tx
+
+	// depth is the savepoint nesting level; zero for the outermost transaction.
+	depth int
+
+	// events are published to subscribers once the outermost transaction commits.
+	events []ChangeEvent
+}
+
+type txCtxKey struct{}
+
+// WithTx runs fn inside a transaction. The transaction is committed when fn returns nil and rolled back when it
+// returns an error or panics. If ctx has no deadline, TransactionContextTimeout is applied.
+//
+// Calling WithTx (or any Service method that writes) with the context returned by Tx.Context joins the surrounding
+// transaction through a savepoint instead of starting a new one, so a failure in the inner call only rolls back
+// the inner work. Service methods that only read run their queries on the surrounding transaction.
+func (s *Service) WithTx(ctx context.Context, fn func(tx *Tx) error) error {
+	const op errors.Op = "sqlite.Service.WithTx"
+	if err := checkService(op, s); err != nil {
+		return err
+	}
+
+	if fn == nil {
+		return errors.New(op).Msg("Transaction function cannot be nil.")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if outer, ok := ctx.Value(txCtxKey{}).(*Tx); ok && outer.service == s {
+		return outer.WithTx(fn)
+	}
+
+	h, err := s.getOpenHandle(op)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := s.ensureTxTimeout(ctx)
+	defer cancel()
+
+	sqlTx, err := h.BeginTx(ctx, nil)
+	if err != nil {
+		if stderr.Is(err, context.DeadlineExceeded) {
+			return errors.New(op).Err(err).Msg("Transaction context timed out.")
+		}
+		return errors.New(op).Err(err).Msg("Failed to begin transaction")
+	}
+
+	tx := &Tx{service: s, tx: sqlTx}
+	tx.ctx = context.WithValue(ctx, txCtxKey{}, tx)
+
+	if err = tx.run(fn); err != nil {
+		if er := sqlTx.Rollback(); er != nil && !stderr.Is(er, sql.ErrTxDone) {
+			s.LoggerService.ErrorWith().Err(er).Msg("Failed to roll back transaction.")
+		}
+		return err
+	}
+
+	s.changes.commitMu.Lock()
+	defer s.changes.commitMu.Unlock()
+
+	if err = sqlTx.Commit(); err != nil {
+		return errors.New(op).Err(err).Msg("Failed to commit transaction")
+	}
+
+	s.publishChanges(tx.events)
+
+	return nil
+}
+
+// WithTx runs fn inside a savepoint of the current transaction. The savepoint is released when fn returns nil and
+// rolled back when it returns an error or panics; either way the outer transaction carries on.
+func (t *Tx) WithTx(fn func(tx *Tx) error) error {
+	const op errors.Op = "sqlite.Tx.WithTx"
+
+	if fn == nil {
+		return errors.New(op).Msg("Transaction function cannot be nil.")
+	}
+
+	nested := &Tx{service: t.service, tx: t.tx, depth: t.depth + 1}
+	nested.ctx = context.WithValue(t.ctx, txCtxKey{}, nested)
+
+	name := fmt.Sprintf("sp_%d", nested.depth)
+	if _, err := t.tx.ExecContext(t.ctx, "SAVEPOINT "+name); err != nil {
+		return errors.New(op).Err(err).Msg("Failed to create savepoint")
+	}
+
+	if err := nested.run(fn); err != nil {
+		// ROLLBACK TO leaves the savepoint on the stack, so it still has to be released.
+		if _, er := t.tx.ExecContext(t.ctx, "ROLLBACK TO "+name); er != nil {
+			t.service.LoggerService.ErrorWith().Err(er).Msg("Failed to roll back savepoint.")
+		}
+		_, _ = t.tx.ExecContext(t.ctx, "RELEASE "+name)
+		return err
+	}
+
+	if _, err := t.tx.ExecContext(t.ctx, "RELEASE "+name); err != nil {
+		return errors.New(op).Err(err).Msg("Failed to release savepoint")
+	}
+	t.publish(nested.events...)
+
+	return nil
+}
+
+// Context returns the transaction's context. Service methods given it run on this transaction: reads see its
+// uncommitted writes and writes (which go through WithTx) join it through a savepoint.
+func (t *Tx) Context() context.Context {
+	return t.ctx
+}
+
+// executor returns what a Service method should run its queries on: the transaction carried by ctx (see
+// Tx.Context), or else the connection pool.
+func (s *Service) executor(ctx context.Context, op errors.Op) (boil.ContextExecutor, error) {
+	if ctx != nil {
+		if tx, ok := ctx.Value(txCtxKey{}).(*Tx); ok && tx.service == s {
+			return tx.tx, nil
+		}
+	}
+
+	h, err := s.getOpenHandle(op)
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// run calls fn, converting a panic into a rollback before re-panicking.
+func (t *Tx) run(fn func(tx *Tx) error) (err error) {
+	panicked := true
+	defer func() {
+		if panicked {
+			if t.depth == 0 {
+				_ = t.tx.Rollback()
+			} else {
+				name := fmt.Sprintf("sp_%d", t.depth)
+				_, _ = t.tx.ExecContext(t.ctx, "ROLLBACK TO "+name)
+				_, _ = t.tx.ExecContext(t.ctx, "RELEASE "+name)
+			}
+		}
+	}()
+
+	err = fn(t)
+	panicked = false
+	return err
+}
+
+// ensureTxTimeout is the transaction counterpart of ensureCtxTimeout, using TransactionContextTimeout.
+func (s *Service) ensureTxTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, hasDeadline := ctx.Deadline(); hasDeadline {
+		return ctx, func() {}
+	}
+	timeout := time.Duration(s.DatabaseConfig.TransactionContextTimeout) * time.Second
+	if timeout <= 0 {
+		return s.withDefaultTimeout(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
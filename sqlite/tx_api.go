@@ -0,0 +1,1071 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	stderr "errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Station-Manager/database/sqlite/adapters"
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/enums/upload"
+	"github.com/Station-Manager/enums/upload/action"
+	"github.com/Station-Manager/enums/upload/status"
+	"github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+)
+
+/**********************************************************************************************************************
+ * QSO Methods
+ **********************************************************************************************************************/
+
+// InsertQso inserts the QSO and returns its ID; see InsertQsoChecked.
+func (t *Tx) InsertQso(qso types.Qso) (int64, error) {
+	result, err := t.InsertQsoChecked(qso)
+	return result.ID, err
+}
+
+// InsertQsoChecked inserts the QSO, applying the duplicate policy set with Service.SetDuplicatePolicy. Under
+// DuplicateReject a QSO matching an existing one is not inserted and a *DuplicateQsoError is returned; under
+// DuplicateWarn it is inserted and the result names the QSO it matched.
+func (t *Tx) InsertQsoChecked(qso types.Qso) (InsertQsoResult, error) {
+	const op errors.Op = "sqlite.Tx.InsertQsoChecked"
+
+	model, err := adapters.QsoTypeToModel(qso)
+	if err != nil {
+		return InsertQsoResult{}, errors.New(op).Err(err)
+	}
+
+	var existingID int64
+	if guard := t.service.duplicatePolicy(); guard.policy == DuplicateWarn || guard.policy == DuplicateReject {
+		if existingID, err = findDuplicateQso(t.ctx, t.tx, &model, guard.window); err != nil {
+			return InsertQsoResult{}, errors.New(op).Err(err)
+		}
+		if existingID > 0 {
+			if guard.policy == DuplicateReject {
+				return InsertQsoResult{}, &DuplicateQsoError{ExistingID: existingID}
+			}
+			t.service.LoggerService.WarnWith().Int64("qso.existing_id", existingID).Str("call", model.Call).Msg("Inserting possible duplicate QSO.")
+		}
+	}
+
+	if err = t.insertQsoModel(&model); err != nil {
+		return InsertQsoResult{}, errors.New(op).Err(err)
+	}
+
+	return InsertQsoResult{ID: model.ID, DuplicateOf: existingID}, nil
+}
+
+// insertQsoModel inserts an already adapted QSO without applying the duplicate policy, queues the uploads the
+// logbook's forwarding rules call for and syncs the contacted station.
+func (t *Tx) insertQsoModel(model *models.Qso) error {
+	if err := model.Insert(t.ctx, t.tx, boil.Infer()); err != nil {
+		return err
+	}
+	t.publish(ChangeEvent{Entity: ChangeQso, Action: action.Insert, ID: model.ID, LogbookID: model.LogbookID})
+	if err := t.forwardQso(model, action.Insert); err != nil {
+		return err
+	}
+	return t.syncContactedStation(model)
+}
+
+// InsertQsoBatch inserts the QSOs using one prepared statement. The returned slice holds a result for every QSO
+// that was attempted, in input order. With BatchStopOnFirstError the whole batch is rolled back (to a savepoint,
+// so earlier work in the transaction is kept) on the first failure, no IDs are returned and the error identifies
+// the failing index. With BatchSkipBadRows each QSO gets its own savepoint, so a failure rolls back everything that
+// QSO's insert did, queued uploads and contacted station included.
+func (t *Tx) InsertQsoBatch(qsos []types.Qso, opts BatchOptions) ([]BatchItemResult, error) {
+	const op errors.Op = "sqlite.Tx.InsertQsoBatch"
+
+	policy := opts.ErrorPolicy
+	if policy == emptyString {
+		policy = BatchStopOnFirstError
+	}
+	if policy != BatchStopOnFirstError && policy != BatchSkipBadRows {
+		return nil, errors.New(op).Msgf("Unknown batch error policy: %q", opts.ErrorPolicy)
+	}
+
+	if len(qsos) == 0 {
+		return []BatchItemResult{}, nil
+	}
+
+	results := make([]BatchItemResult, 0, len(qsos))
+
+	err := t.WithTx(func(tx *Tx) error {
+		const insert = `
+			INSERT INTO qso (created_at, call, band, mode, freq, qso_date, time_on, time_off, rst_sent, rst_rcvd,
+			                 country, additional_data, logbook_id, session_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+		stmt, err := tx.tx.PrepareContext(tx.ctx, insert)
+		if err != nil {
+			return errors.New(op).Err(err).Msg("Failed to prepare QSO insert.")
+		}
+		defer func() { _ = stmt.Close() }()
+
+		createdAt := time.Now().In(boil.GetLocation())
+
+		// insertRow inserts one QSO along with its uploads and contacted station.
+		insertRow := func(rt *Tx, qso types.Qso) (int64, error) {
+			model, er := adapters.QsoTypeToModel(qso)
+			if er != nil {
+				return 0, er
+			}
+			res, er := stmt.ExecContext(rt.ctx, createdAt, model.Call, model.Band, model.Mode, model.Freq,
+				model.QsoDate, model.TimeOn, model.TimeOff, model.RstSent, model.RstRcvd, model.Country,
+				model.AdditionalData, model.LogbookID, model.SessionID)
+			if er != nil {
+				return 0, er
+			}
+			if model.ID, er = res.LastInsertId(); er != nil {
+				return 0, er
+			}
+			rt.publish(ChangeEvent{Entity: ChangeQso, Action: action.Insert, ID: model.ID, LogbookID: model.LogbookID})
+			if er = rt.forwardQso(&model, action.Insert); er != nil {
+				return 0, er
+			}
+			if er = rt.syncContactedStation(&model); er != nil {
+				return 0, er
+			}
+			return model.ID, nil
+		}
+
+		for i, qso := range qsos {
+			item := BatchItemResult{Index: i}
+
+			var er error
+			if policy == BatchSkipBadRows {
+				// A row that fails part way must not leave its QSO, uploads or events behind.
+				er = tx.WithTx(func(rt *Tx) error {
+					var e error
+					item.ID, e = insertRow(rt, qso)
+					return e
+				})
+			} else {
+				item.ID, er = insertRow(tx, qso)
+			}
+
+			if er != nil {
+				item.ID = 0
+				item.Err = errors.New(op).Err(er).Msgf("QSO at index %d could not be inserted.", i)
+				results = append(results, item)
+				if policy == BatchStopOnFirstError {
+					return errors.New(op).Err(er).Msgf("Batch aborted: QSO at index %d could not be inserted.", i)
+				}
+				continue
+			}
+
+			results = append(results, item)
+		}
+
+		return nil
+	})
+	if err != nil {
+		for i := range results {
+			results[i].ID = 0
+		}
+		return results, err
+	}
+
+	return results, nil
+}
+
+// UpdateQso overwrites the QSO, keeping the previous version as a revision. The revision's reason is taken from
+// the transaction's context (see WithChangeReason).
+func (t *Tx) UpdateQso(qso types.Qso) error {
+	const op errors.Op = "sqlite.Tx.UpdateQso"
+
+	if qso.ID < 1 {
+		return errors.New(op).Msgf("QSO ID is invalid: %d", qso.ID)
+	}
+
+	model, err := adapters.QsoTypeToModel(qso)
+	if err != nil {
+		return errors.New(op).Err(err)
+	}
+
+	return t.updateQsoModel(&model, changeReason(t.ctx))
+}
+
+func (t *Tx) updateQsoModel(model *models.Qso, reason string) error {
+	const op errors.Op = "sqlite.Tx.updateQsoModel"
+
+	if err := recordQsoRevision(t.ctx, t.tx, model.ID, action.Update, reason); err != nil {
+		if stderr.Is(err, errors.ErrNotFound) {
+			return err
+		}
+		return errors.New(op).Err(err)
+	}
+
+	model.ModifiedAt = null.TimeFrom(time.Now())
+
+	if _, err := model.Update(t.ctx, t.tx, boil.Infer()); err != nil {
+		return errors.New(op).Err(err)
+	}
+	t.publish(ChangeEvent{Entity: ChangeQso, Action: action.Update, ID: model.ID, LogbookID: model.LogbookID})
+
+	if err := t.forwardQso(model, action.Update); err != nil {
+		return err
+	}
+	return t.syncContactedStation(model)
+}
+
+// RevertQsoToRevision makes the QSO look like it did in the given revision. The version being replaced is itself
+// kept as a revision, so a revert can be undone. A deleted QSO is restored first.
+func (t *Tx) RevertQsoToRevision(revisionID int64) error {
+	const op errors.Op = "sqlite.Tx.RevertQsoToRevision"
+
+	if revisionID < 1 {
+		return errors.New(op).Msg(errMsgInvalidId)
+	}
+
+	rev, err := models.FindQsoRevision(t.ctx, t.tx, revisionID)
+	if err != nil {
+		if stderr.Is(err, sql.ErrNoRows) {
+			return errors.ErrNotFound
+		}
+		return errors.New(op).Err(err)
+	}
+
+	current, err := models.Qsos(qm.WithDeleted(), models.QsoWhere.ID.EQ(rev.QsoID)).One(t.ctx, t.tx)
+	if err != nil {
+		if stderr.Is(err, sql.ErrNoRows) {
+			return errors.ErrNotFound
+		}
+		return errors.New(op).Err(err)
+	}
+
+	if current.DeletedAt.Valid {
+		if err = t.RestoreQso(current.ID); err != nil {
+			return errors.New(op).Err(err)
+		}
+	}
+
+	model := qsoModelFromRevision(rev)
+	model.CreatedAt = current.CreatedAt
+	model.DeletedAt = null.Time{}
+
+	reason := changeReason(t.ctx)
+	if reason == emptyString {
+		reason = fmt.Sprintf("Reverted to revision %d", revisionID)
+	}
+
+	return t.updateQsoModel(model, reason)
+}
+
+func (t *Tx) FetchQsoById(id int64) (types.Qso, error) {
+	const op errors.Op = "sqlite.Tx.FetchQsoById"
+	return fetchQsoByID(t.ctx, t.tx, op, id)
+}
+
+// DeleteQso soft deletes the QSO, keeping its last version as a revision. Queued inserts/updates for it are
+// dropped and every service that already holds a copy is queued a delete.
+func (t *Tx) DeleteQso(id int64) error {
+	const op errors.Op = "sqlite.Tx.DeleteQso"
+
+	if id < 1 {
+		return errors.New(op).Msg(errMsgInvalidId)
+	}
+
+	model, err := models.FindQso(t.ctx, t.tx, id)
+	if err != nil {
+		if stderr.Is(err, sql.ErrNoRows) {
+			return errors.ErrNotFound
+		}
+		return errors.New(op).Err(err)
+	}
+
+	if err = recordQsoRevision(t.ctx, t.tx, id, action.Delete, changeReason(t.ctx)); err != nil {
+		return errors.New(op).Err(err)
+	}
+
+	if _, err = model.Delete(t.ctx, t.tx, false); err != nil {
+		return errors.New(op).Err(err).Msgf("Failed to soft delete QSO: %d", id)
+	}
+	t.publish(ChangeEvent{Entity: ChangeQso, Action: action.Delete, ID: id, LogbookID: model.LogbookID})
+
+	// Inserts/updates that have not reached the remote service yet are now pointless.
+	dropped, err := models.QsoUploads(
+		models.QsoUploadWhere.QsoID.EQ(id),
+		models.QsoUploadWhere.Action.NEQ(action.Delete.String()),
+		models.QsoUploadWhere.Status.IN([]string{status.Pending.String(), status.Failed.String()}),
+	).DeleteAll(t.ctx, t.tx)
+	if err != nil {
+		return errors.New(op).Err(err).Msg("Failed to remove obsolete QSO uploads")
+	}
+	if dropped > 0 {
+		t.publish(ChangeEvent{Entity: ChangeUpload, Action: action.Delete, QsoID: id})
+	}
+
+	// Inserts/updates under way finish first; the report then queues the delete if the copy got through (see
+	// settleDeletedQsoUpload).
+	if _, err = models.QsoUploads(
+		models.QsoUploadWhere.QsoID.EQ(id),
+		models.QsoUploadWhere.Action.NEQ(action.Delete.String()),
+		models.QsoUploadWhere.Status.EQ(status.InProgress.String()),
+	).UpdateAll(t.ctx, t.tx, models.M{models.QsoUploadColumns.Requeued: true}); err != nil {
+		return errors.New(op).Err(err).Msg("Failed to flag QSO uploads in progress")
+	}
+
+	// Every service that already holds a copy of the QSO must be told to delete it.
+	uploaded, err := models.QsoUploads(
+		qm.Select("DISTINCT "+models.QsoUploadColumns.Service),
+		models.QsoUploadWhere.QsoID.EQ(id),
+		models.QsoUploadWhere.Action.NEQ(action.Delete.String()),
+		models.QsoUploadWhere.Status.EQ(status.Uploaded.String()),
+	).All(t.ctx, t.tx)
+	if err != nil {
+		return errors.New(op).Err(err).Msg("Failed to fetch uploaded services for QSO")
+	}
+
+	for _, up := range uploaded {
+		uploadID, queued, er := requeueQsoUpload(t.ctx, t.tx, id, action.Delete, up.Service)
+		if er != nil {
+			return errors.New(op).Err(er)
+		}
+		if queued {
+			t.publish(uploadQueued(uploadID, id, up.Service))
+		}
+	}
+
+	return nil
+}
+
+// RestoreQso undoes a soft delete. Queued deletes are dropped and services that already processed the delete are
+// queued a fresh insert.
+func (t *Tx) RestoreQso(id int64) error {
+	const op errors.Op = "sqlite.Tx.RestoreQso"
+
+	if id < 1 {
+		return errors.New(op).Msg(errMsgInvalidId)
+	}
+
+	model, err := models.Qsos(qm.WithDeleted(), models.QsoWhere.ID.EQ(id)).One(t.ctx, t.tx)
+	if err != nil {
+		if stderr.Is(err, sql.ErrNoRows) {
+			return errors.ErrNotFound
+		}
+		return errors.New(op).Err(err)
+	}
+
+	if !model.DeletedAt.Valid {
+		return errors.New(op).Msgf("QSO is not deleted: %d", id)
+	}
+
+	model.DeletedAt = null.Time{}
+	if _, err = model.Update(t.ctx, t.tx, boil.Whitelist(models.QsoColumns.DeletedAt)); err != nil {
+		return errors.New(op).Err(err).Msgf("Failed to restore QSO: %d", id)
+	}
+	t.publish(ChangeEvent{Entity: ChangeQso, Action: action.Update, ID: id, LogbookID: model.LogbookID})
+
+	// Deletes still waiting in the queue can simply be dropped.
+	dropped, err := models.QsoUploads(
+		models.QsoUploadWhere.QsoID.EQ(id),
+		models.QsoUploadWhere.Action.EQ(action.Delete.String()),
+		models.QsoUploadWhere.Status.IN([]string{status.Pending.String(), status.Failed.String()}),
+	).DeleteAll(t.ctx, t.tx)
+	if err != nil {
+		return errors.New(op).Err(err).Msg("Failed to remove pending QSO delete uploads")
+	}
+	if dropped > 0 {
+		t.publish(ChangeEvent{Entity: ChangeUpload, Action: action.Delete, QsoID: id})
+	}
+
+	// Services that already processed the delete need the QSO sent to them again.
+	deleted, err := models.QsoUploads(
+		models.QsoUploadWhere.QsoID.EQ(id),
+		models.QsoUploadWhere.Action.EQ(action.Delete.String()),
+		models.QsoUploadWhere.Status.EQ(status.Uploaded.String()),
+	).All(t.ctx, t.tx)
+	if err != nil {
+		return errors.New(op).Err(err).Msg("Failed to fetch deleted services for QSO")
+	}
+
+	for _, up := range deleted {
+		uploadID, queued, er := requeueQsoUpload(t.ctx, t.tx, id, action.Insert, up.Service)
+		if er != nil {
+			return errors.New(op).Err(er)
+		}
+		if queued {
+			t.publish(uploadQueued(uploadID, id, up.Service))
+		}
+	}
+
+	return nil
+}
+
+/**********************************************************************************************************************
+ * ContactedStation Methods
+ **********************************************************************************************************************/
+
+func (t *Tx) FetchContactedStationByCallsign(callsign string) (types.ContactedStation, error) {
+	const op errors.Op = "sqlite.Tx.FetchContactedStationByCallsign"
+	return fetchContactedStationByCallsign(t.ctx, t.tx, op, callsign)
+}
+
+// InsertContactedStation inserts the station. The fields it sets count as user-edited, so QSO syncing (see
+// Service.SetContactedStationSync) leaves them alone.
+func (t *Tx) InsertContactedStation(station types.ContactedStation) (int64, error) {
+	const op errors.Op = "sqlite.Tx.InsertContactedStation"
+
+	model, err := adapters.ContactedStationTypeToModel(station)
+	if err != nil {
+		return 0, errors.New(op).Err(err)
+	}
+	if model.UserFields, err = encodeUserFields(stationFieldsSet(station)); err != nil {
+		return 0, errors.New(op).Err(err)
+	}
+	if err = model.Insert(t.ctx, t.tx, boil.Infer()); err != nil {
+		return 0, errors.New(op).Err(err).Msg("Inserting new contacted station failed.")
+	}
+	t.publish(ChangeEvent{Entity: ChangeContactedStation, Action: action.Insert, ID: model.ID})
+
+	return model.ID, nil
+}
+
+// UpdateContactedStation overwrites the station. The fields it changes are added to those counting as user-edited,
+// which QSO syncing (see Service.SetContactedStationSync) leaves alone.
+func (t *Tx) UpdateContactedStation(station types.ContactedStation) error {
+	const op errors.Op = "sqlite.Tx.UpdateContactedStation"
+
+	model, err := adapters.ContactedStationTypeToModel(station)
+	if err != nil {
+		return errors.New(op).Err(err)
+	}
+
+	current, err := models.FindContactedStation(t.ctx, t.tx, model.ID)
+	if err != nil {
+		if stderr.Is(err, sql.ErrNoRows) {
+			return errors.ErrNotFound
+		}
+		return errors.New(op).Err(err)
+	}
+	before, err := adapters.ContactedStationModelToType(current)
+	if err != nil {
+		return errors.New(op).Err(err)
+	}
+	userFields, err := decodeUserFields(current.UserFields)
+	if err != nil {
+		return errors.New(op).Err(err)
+	}
+	for _, name := range stationFieldsChanged(before, station) {
+		if !slices.Contains(userFields, name) {
+			userFields = append(userFields, name)
+		}
+	}
+	if model.UserFields, err = encodeUserFields(userFields); err != nil {
+		return errors.New(op).Err(err)
+	}
+	model.CreatedAt = current.CreatedAt
+	model.LastQsoAt = current.LastQsoAt
+	model.ModifiedAt = null.TimeFrom(time.Now())
+
+	if _, err = model.Update(t.ctx, t.tx, boil.Infer()); err != nil {
+		return errors.New(op).Err(err).Msg("Updating contacted station failed.")
+	}
+	t.publish(ChangeEvent{Entity: ChangeContactedStation, Action: action.Update, ID: model.ID})
+
+	return nil
+}
+
+// DeleteContactedStation soft deletes the station.
+func (t *Tx) DeleteContactedStation(id int64) error {
+	const op errors.Op = "sqlite.Tx.DeleteContactedStation"
+
+	if id < 1 {
+		return errors.New(op).Msg(errMsgInvalidId)
+	}
+
+	model, err := models.FindContactedStation(t.ctx, t.tx, id)
+	if err != nil {
+		if stderr.Is(err, sql.ErrNoRows) {
+			return errors.ErrNotFound
+		}
+		return errors.New(op).Err(err)
+	}
+
+	if _, err = model.Delete(t.ctx, t.tx, false); err != nil {
+		return errors.New(op).Err(err).Msgf("Failed to soft delete contacted station: %d", id)
+	}
+	t.publish(ChangeEvent{Entity: ChangeContactedStation, Action: action.Delete, ID: id})
+
+	return nil
+}
+
+// RestoreContactedStation undoes a soft delete. It fails if another active station has the same call.
+func (t *Tx) RestoreContactedStation(id int64) error {
+	const op errors.Op = "sqlite.Tx.RestoreContactedStation"
+
+	if id < 1 {
+		return errors.New(op).Msg(errMsgInvalidId)
+	}
+
+	model, err := models.ContactedStations(qm.WithDeleted(), models.ContactedStationWhere.ID.EQ(id)).One(t.ctx, t.tx)
+	if err != nil {
+		if stderr.Is(err, sql.ErrNoRows) {
+			return errors.ErrNotFound
+		}
+		return errors.New(op).Err(err)
+	}
+
+	if !model.DeletedAt.Valid {
+		return errors.New(op).Msgf("Contacted station is not deleted: %d", id)
+	}
+
+	active, err := models.ContactedStations(models.ContactedStationWhere.Call.EQ(model.Call)).Exists(t.ctx, t.tx)
+	if err != nil {
+		return errors.New(op).Err(err)
+	}
+	if active {
+		return errors.New(op).Msgf("Another contacted station with call %s exists.", model.Call)
+	}
+
+	model.DeletedAt = null.Time{}
+	if _, err = model.Update(t.ctx, t.tx, boil.Whitelist(models.ContactedStationColumns.DeletedAt)); err != nil {
+		return errors.New(op).Err(err).Msgf("Failed to restore contacted station: %d", id)
+	}
+	t.publish(ChangeEvent{Entity: ChangeContactedStation, Action: action.Update, ID: id})
+
+	return nil
+}
+
+/**********************************************************************************************************************
+ * Logbook Methods
+ **********************************************************************************************************************/
+
+func (t *Tx) FetchLogbookByID(id int64) (types.Logbook, error) {
+	const op errors.Op = "sqlite.Tx.FetchLogbookByID"
+	return fetchLogbookByID(t.ctx, t.tx, op, id)
+}
+
+func (t *Tx) InsertLogbook(logbook types.Logbook) (int64, error) {
+	const op errors.Op = "sqlite.Tx.InsertLogbook"
+
+	model, err := adapters.LogbookTypeToModel(logbook)
+	if err != nil {
+		return 0, errors.New(op).Err(err)
+	}
+	if err = model.Insert(t.ctx, t.tx, boil.Infer()); err != nil {
+		return 0, errors.New(op).Err(err).Msg("Inserting new logbook failed.")
+	}
+	t.publish(ChangeEvent{Entity: ChangeLogbook, Action: action.Insert, ID: model.ID, LogbookID: model.ID})
+
+	return model.ID, nil
+}
+
+func (t *Tx) UpsertLogbook(logbook types.Logbook) error {
+	const op errors.Op = "sqlite.Tx.UpsertLogbook"
+
+	if logbook.ID < 1 {
+		return errors.New(op).Msg("Logbook ID must be greater than 0")
+	}
+	//TODO: Other validation
+
+	model := models.Logbook{
+		ID:          logbook.ID,
+		Name:        logbook.Name,
+		Callsign:    logbook.Callsign,
+		Description: null.StringFrom(logbook.Description),
+	}
+
+	exists, err := models.LogbookExists(t.ctx, t.tx, logbook.ID)
+	if err != nil {
+		return errors.New(op).Err(err)
+	}
+
+	if err = model.Upsert(t.ctx, t.tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		return errors.New(op).Err(err).Msg("Upserting logbook failed.")
+	}
+
+	act := action.Insert
+	if exists {
+		act = action.Update
+	}
+	t.publish(ChangeEvent{Entity: ChangeLogbook, Action: act, ID: model.ID, LogbookID: model.ID})
+
+	return nil
+}
+
+func (t *Tx) DeleteLogbookByID(id int64) error {
+	const op errors.Op = "sqlite.Tx.DeleteLogbookByID"
+
+	if id < 1 {
+		return errors.New(op).Msg(errMsgInvalidId)
+	}
+
+	logbook, err := models.FindLogbook(t.ctx, t.tx, id)
+	if err != nil {
+		if stderr.Is(err, sql.ErrNoRows) {
+			return errors.ErrNotFound
+		}
+		return errors.New(op).Err(err)
+	}
+
+	if _, err = logbook.Delete(t.ctx, t.tx, false); err != nil {
+		return errors.New(op).Err(err).Msg("Failed to delete logbook.")
+	}
+	t.publish(ChangeEvent{Entity: ChangeLogbook, Action: action.Delete, ID: id, LogbookID: id})
+
+	return nil
+}
+
+/**********************************************************************************************************************
+ * Session Methods
+ **********************************************************************************************************************/
+
+func (t *Tx) GenerateSession() (int64, error) {
+	const op errors.Op = "sqlite.Tx.GenerateSession"
+
+	session := models.Session{}
+	if err := session.Insert(t.ctx, t.tx, boil.Infer()); err != nil {
+		return 0, errors.New(op).Err(err).Msg("Inserting new session failed.")
+	}
+
+	return session.ID, nil
+}
+
+/**********************************************************************************************************************
+ * Upload Methods
+ **********************************************************************************************************************/
+
+func (t *Tx) InsertQsoUpload(qsoId int64, action action.Action, service upload.OnlineService) error {
+	const op errors.Op = "sqlite.Tx.InsertQsoUpload"
+
+	if qsoId < 1 {
+		return errors.New(op).Msgf("QSO ID is invalid: %d", qsoId)
+	}
+
+	model := models.QsoUpload{
+		QsoID:   qsoId,
+		Service: service.String(),
+		Action:  action.String(),
+		Status:  status.Pending.String(),
+	}
+
+	if err := model.Insert(t.ctx, t.tx, boil.Infer()); err != nil {
+		return errors.New(op).Err(err).Msg("Inserting new QSO upload failed.")
+	}
+	t.publish(uploadQueued(model.ID, model.QsoID, model.Service))
+
+	return nil
+}
+
+// UpdateQsoUploadStatus sets the status of an upload, whatever its current status and lease. Reporting uploaded or
+// failed also records the attempt in its history; use RecordUploadAttempt to include the service's response and to
+// report only while holding the reservation.
+func (t *Tx) UpdateQsoUploadStatus(id int64, status status.Status, action action.Action, attempts int64, lastError string) error {
+	const op errors.Op = "sqlite.Tx.UpdateQsoUploadStatus"
+	return t.updateQsoUploadStatus(op, id, status, action, attempts, UploadAttempt{Error: lastError}, false)
+}
+
+// RecordUploadAttempt reports the outcome of an attempt (uploaded or failed) at sending an upload this process has
+// reserved: it sets the upload's status as UpdateQsoUploadStatus does and records the attempt, with the service's
+// response, in its history. An empty attempt.Action keeps the upload's action. An *UploadLeaseLostError is returned
+// if the reservation is no longer this process's.
+func (t *Tx) RecordUploadAttempt(id int64, attempts int64, attempt UploadAttempt) error {
+	const op errors.Op = "sqlite.Tx.RecordUploadAttempt"
+
+	if attempt.Outcome != status.Uploaded && attempt.Outcome != status.Failed {
+		return errors.New(op).Msgf("Upload attempt outcome must be %s or %s, not %q.", status.Uploaded, status.Failed, attempt.Outcome)
+	}
+
+	return t.updateQsoUploadStatus(op, id, attempt.Outcome, action.Action(attempt.Action), attempts, attempt, true)
+}
+
+// updateQsoUploadStatus sets the upload's status and, for an uploaded or failed outcome, records the attempt. With
+// fenced set, only the holder of the upload's reservation may do so.
+func (t *Tx) updateQsoUploadStatus(op errors.Op, id int64, st status.Status, act action.Action, attempts int64, attempt UploadAttempt, fenced bool) error {
+	if id < 1 {
+		return errors.New(op).Msg(errMsgInvalidId)
+	}
+
+	uploadModel, err := models.FindQsoUpload(t.ctx, t.tx, id)
+	if err != nil {
+		return errors.New(op).Err(err).Msg("Failed to find QSO upload")
+	}
+
+	// A late report from a forwarder whose lease was taken over would overwrite the newer attempt.
+	if fenced && (uploadModel.Status != status.InProgress.String() || uploadModel.LeaseOwner.String != t.service.uploadLease().owner) {
+		return &UploadLeaseLostError{UploadID: id, Status: uploadModel.Status, Owner: uploadModel.LeaseOwner.String}
+	}
+
+	// The reservation stamped last_attempt_at; that is when the attempt started unless told otherwise.
+	now := time.Now()
+	if attempt.AttemptedAt.IsZero() {
+		attempt.AttemptedAt = now
+		if uploadModel.LastAttemptAt.Valid {
+			attempt.AttemptedAt = time.Unix(uploadModel.LastAttemptAt.Int64, 0)
+		}
+	}
+
+	uploadModel.Status = st.String()
+	if act != emptyString {
+		uploadModel.Action = act.String()
+	}
+	uploadModel.Attempts = attempts
+	uploadModel.LastError = null.NewString(attempt.Error, attempt.Error != "")
+	uploadModel.ModifiedAt = null.TimeFrom(now)
+	uploadModel.NextAttemptAt = null.Int64{}
+	if uploadModel.Status != status.InProgress.String() {
+		uploadModel.LeaseOwner = null.String{}
+		uploadModel.LeaseExpiresAt = null.Int64{}
+	}
+
+	// Schedule the retry of a failure, or give up on it once the service's attempts are used up.
+	if uploadModel.Status == status.Failed.String() {
+		backoff := t.service.uploadBackoff(uploadModel.Service)
+		if backoff.exhausted(attempts) {
+			uploadModel.Status = UploadStatusDead.String()
+		} else {
+			uploadModel.NextAttemptAt = null.Int64From(backoff.nextAttempt(now, attempts).Unix())
+		}
+	}
+
+	// The QSO changed while this attempt was under way, so it may have sent a stale copy: send it again, with a fresh
+	// set of attempts. If it was deleted instead, the copy is settled below rather than sent again.
+	var qsoDeleted bool
+	if uploadModel.Requeued && uploadModel.Status != status.InProgress.String() {
+		if uploadModel.Action != action.Delete.String() {
+			qsoDeleted, err = models.Qsos(
+				qm.WithDeleted(),
+				models.QsoWhere.ID.EQ(uploadModel.QsoID),
+				models.QsoWhere.DeletedAt.IsNotNull(),
+			).Exists(t.ctx, t.tx)
+			if err != nil {
+				return errors.New(op).Err(err).Msg("Failed to check whether the QSO was deleted")
+			}
+		}
+		if !qsoDeleted {
+			uploadModel.Status = status.Pending.String()
+			uploadModel.Attempts = 0
+			uploadModel.LastError = null.String{}
+			uploadModel.NextAttemptAt = null.Int64{}
+		}
+		uploadModel.Requeued = false
+	}
+
+	if _, err = uploadModel.Update(t.ctx, t.tx, boil.Infer()); err != nil {
+		return errors.New(op).Err(err).Msg("Failed to update QSO upload status")
+	}
+	t.publish(ChangeEvent{Entity: ChangeUpload, Action: action.Update, ID: uploadModel.ID, QsoID: uploadModel.QsoID,
+		Service: uploadModel.Service, Status: status.Status(uploadModel.Status)})
+
+	if st == status.Uploaded || st == status.Failed {
+		if err = insertUploadAttempt(t.ctx, t.tx, uploadModel, st, attempt); err != nil {
+			return errors.New(op).Err(err).Msg("Failed to record upload attempt")
+		}
+	}
+
+	if qsoDeleted {
+		if err = t.settleDeletedQsoUpload(uploadModel); err != nil {
+			return errors.New(op).Err(err)
+		}
+	}
+
+	// At this point, we don't need to update the QSO itself as that SHOULD have been
+	// done by the online-forwarder, since the online-forwarder knows what fields in the
+	// qso object to update based on the service.
+
+	return nil
+}
+
+// settleDeletedQsoUpload finishes an insert or update whose QSO was deleted while it was being sent. A copy that
+// reached the service is queued a delete; one that did not is dropped, as DeleteQso drops those still queued.
+func (t *Tx) settleDeletedQsoUpload(up *models.QsoUpload) error {
+	const op errors.Op = "sqlite.Tx.settleDeletedQsoUpload"
+
+	if up.Status == status.Uploaded.String() {
+		uploadID, queued, err := requeueQsoUpload(t.ctx, t.tx, up.QsoID, action.Delete, up.Service)
+		if err != nil {
+			return errors.New(op).Err(err)
+		}
+		if queued {
+			t.publish(uploadQueued(uploadID, up.QsoID, up.Service))
+		}
+		return nil
+	}
+
+	if _, err := up.Delete(t.ctx, t.tx); err != nil {
+		return errors.New(op).Err(err).Msgf("Failed to remove upload %d of deleted QSO", up.ID)
+	}
+	t.publish(ChangeEvent{Entity: ChangeUpload, Action: action.Delete, ID: up.ID, QsoID: up.QsoID, Service: up.Service})
+
+	return nil
+}
+
+// killUpload gives up on a reserved upload that cannot be sent as it stands, e.g. because its QSO cannot be read,
+// recording why. It stays dead until requeued.
+func (t *Tx) killUpload(up types.QsoUpload, reason error) error {
+	const op errors.Op = "sqlite.Tx.killUpload"
+
+	_, err := models.QsoUploads(models.QsoUploadWhere.ID.EQ(up.ID)).UpdateAll(t.ctx, t.tx, models.M{
+		models.QsoUploadColumns.Status:         UploadStatusDead.String(),
+		models.QsoUploadColumns.LastError:      reason.Error(),
+		models.QsoUploadColumns.NextAttemptAt:  nil,
+		models.QsoUploadColumns.LeaseOwner:     nil,
+		models.QsoUploadColumns.LeaseExpiresAt: nil,
+		models.QsoUploadColumns.Requeued:       false,
+	})
+	if err != nil {
+		return errors.New(op).Err(err).Msgf("Failed to mark upload %d dead", up.ID)
+	}
+	t.publish(ChangeEvent{Entity: ChangeUpload, Action: action.Update, ID: up.ID, QsoID: up.QsoID, Service: up.Service,
+		Status: UploadStatusDead})
+
+	return nil
+}
+
+// ReleaseUploadLease makes the service's reserved uploads pending again and returns how many were released. With no
+// IDs, every reservation the service holds is released.
+func (t *Tx) ReleaseUploadLease(ids []int64) (int64, error) {
+	const op errors.Op = "sqlite.Tx.ReleaseUploadLease"
+
+	mods := []qm.QueryMod{
+		models.QsoUploadWhere.Status.EQ(status.InProgress.String()),
+		models.QsoUploadWhere.LeaseOwner.EQ(null.StringFrom(t.service.uploadLease().owner)),
+	}
+	if len(ids) > 0 {
+		idArgs, err := uploadIDArgs(op, ids)
+		if err != nil {
+			return 0, err
+		}
+		mods = append(mods, qm.WhereIn("qso_upload.id IN ?", idArgs...))
+	}
+
+	n, err := models.QsoUploads(mods...).UpdateAll(t.ctx, t.tx, models.M{
+		models.QsoUploadColumns.Status:         status.Pending.String(),
+		models.QsoUploadColumns.LeaseOwner:     nil,
+		models.QsoUploadColumns.LeaseExpiresAt: nil,
+		models.QsoUploadColumns.Requeued:       false,
+	})
+	if err != nil {
+		return 0, errors.New(op).Err(err).Msg("Failed to release upload leases")
+	}
+	if n > 0 {
+		t.publish(ChangeEvent{Entity: ChangeUpload, Action: action.Update, Status: status.Pending})
+	}
+
+	return n, nil
+}
+
+// RequeueDeadUploads makes dead uploads pending again with a fresh set of attempts and returns how many were
+// requeued. IDs of uploads that are not dead are ignored.
+func (t *Tx) RequeueDeadUploads(ids []int64) (int64, error) {
+	const op errors.Op = "sqlite.Tx.RequeueDeadUploads"
+
+	idArgs, err := uploadIDArgs(op, ids)
+	if err != nil || len(idArgs) == 0 {
+		return 0, err
+	}
+
+	n, err := models.QsoUploads(
+		qm.WhereIn("qso_upload.id IN ?", idArgs...),
+		models.QsoUploadWhere.Status.EQ(UploadStatusDead.String()),
+	).UpdateAll(t.ctx, t.tx, models.M{
+		models.QsoUploadColumns.Status:        status.Pending.String(),
+		models.QsoUploadColumns.Attempts:      0,
+		models.QsoUploadColumns.LastError:     nil,
+		models.QsoUploadColumns.NextAttemptAt: nil,
+	})
+	if err != nil {
+		return 0, errors.New(op).Err(err).Msg("Failed to requeue dead uploads")
+	}
+	if n > 0 {
+		t.publish(ChangeEvent{Entity: ChangeUpload, Action: action.Update, Status: status.Pending})
+	}
+
+	return n, nil
+}
+
+// RetryUploadsNow makes pending and failed uploads due immediately, skipping what is left of their retry delay, and
+// returns how many were changed. Attempts are kept; dead uploads need RequeueDeadUploads.
+func (t *Tx) RetryUploadsNow(ids []int64) (int64, error) {
+	const op errors.Op = "sqlite.Tx.RetryUploadsNow"
+
+	idArgs, err := uploadIDArgs(op, ids)
+	if err != nil || len(idArgs) == 0 {
+		return 0, err
+	}
+
+	n, err := models.QsoUploads(
+		qm.WhereIn("qso_upload.id IN ?", idArgs...),
+		models.QsoUploadWhere.Status.IN([]string{status.Pending.String(), status.Failed.String()}),
+	).UpdateAll(t.ctx, t.tx, models.M{
+		models.QsoUploadColumns.Status:        status.Pending.String(),
+		models.QsoUploadColumns.NextAttemptAt: nil,
+	})
+	if err != nil {
+		return 0, errors.New(op).Err(err).Msg("Failed to retry uploads")
+	}
+	if n > 0 {
+		t.publish(ChangeEvent{Entity: ChangeUpload, Action: action.Update, Status: status.Pending})
+	}
+
+	return n, nil
+}
+
+// CancelUploads removes pending, failed and dead uploads from the queue and returns how many were removed. Uploads
+// in progress or already uploaded are left alone.
+func (t *Tx) CancelUploads(ids []int64) (int64, error) {
+	const op errors.Op = "sqlite.Tx.CancelUploads"
+
+	idArgs, err := uploadIDArgs(op, ids)
+	if err != nil || len(idArgs) == 0 {
+		return 0, err
+	}
+
+	n, err := models.QsoUploads(
+		qm.WhereIn("qso_upload.id IN ?", idArgs...),
+		models.QsoUploadWhere.Status.IN([]string{status.Pending.String(), status.Failed.String(), UploadStatusDead.String()}),
+	).DeleteAll(t.ctx, t.tx)
+	if err != nil {
+		return 0, errors.New(op).Err(err).Msg("Failed to cancel uploads")
+	}
+	if n > 0 {
+		t.publish(ChangeEvent{Entity: ChangeUpload, Action: action.Delete})
+	}
+
+	return n, nil
+}
+
+// RequeueFailedUploads makes every failed and dead upload of the service pending again with a fresh set of attempts,
+// and returns how many were requeued.
+func (t *Tx) RequeueFailedUploads(service upload.OnlineService) (int64, error) {
+	const op errors.Op = "sqlite.Tx.RequeueFailedUploads"
+
+	if service == emptyString {
+		return 0, errors.New(op).Msg("Upload service cannot be empty.")
+	}
+
+	n, err := models.QsoUploads(
+		models.QsoUploadWhere.Service.EQ(service.String()),
+		models.QsoUploadWhere.Status.IN([]string{status.Failed.String(), UploadStatusDead.String()}),
+	).UpdateAll(t.ctx, t.tx, models.M{
+		models.QsoUploadColumns.Status:        status.Pending.String(),
+		models.QsoUploadColumns.Attempts:      0,
+		models.QsoUploadColumns.LastError:     nil,
+		models.QsoUploadColumns.NextAttemptAt: nil,
+	})
+	if err != nil {
+		return 0, errors.New(op).Err(err).Msgf("Failed to requeue failed uploads for %s", service)
+	}
+	if n > 0 {
+		t.publish(ChangeEvent{Entity: ChangeUpload, Action: action.Update, Service: service.String(), Status: status.Pending})
+	}
+
+	return n, nil
+}
+
+// PurgeUploadedOlderThan removes update and delete uploads that were uploaded more than age ago, and returns how
+// many were removed. Uploaded inserts are kept: they record that the service holds the QSO, which later updates and
+// deletes rely on.
+func (t *Tx) PurgeUploadedOlderThan(age time.Duration) (int64, error) {
+	const op errors.Op = "sqlite.Tx.PurgeUploadedOlderThan"
+
+	if age < 0 {
+		return 0, errors.New(op).Msg("Age cannot be negative.")
+	}
+
+	// modified_at is written in local time by the trigger on qso_upload.
+	cutoff := time.Now().Add(-age).Format(time.DateTime)
+
+	n, err := models.QsoUploads(
+		models.QsoUploadWhere.Status.EQ(status.Uploaded.String()),
+		models.QsoUploadWhere.Action.IN([]string{action.Update.String(), action.Delete.String()}),
+		qm.Where(models.QsoUploadColumns.ModifiedAt+" < ?", cutoff),
+	).DeleteAll(t.ctx, t.tx)
+	if err != nil {
+		return 0, errors.New(op).Err(err).Msg("Failed to purge uploaded uploads")
+	}
+	if n > 0 {
+		t.publish(ChangeEvent{Entity: ChangeUpload, Action: action.Delete, Status: status.Uploaded})
+	}
+
+	return n, nil
+}
+
+// uploadIDArgs checks the upload IDs and returns them as query arguments.
+func uploadIDArgs(op errors.Op, ids []int64) ([]interface{}, error) {
+	idArgs := make([]interface{}, len(ids))
+	for i, id := range ids {
+		if id < 1 {
+			return nil, errors.New(op).Msgf("Upload ID is invalid: %d", id)
+		}
+		idArgs[i] = id
+	}
+	return idArgs, nil
+}
+
+/**********************************************************************************************************************
+ * Shared lookups, used by both Service and Tx.
+ **********************************************************************************************************************/
+
+func fetchQsoByID(ctx context.Context, exec boil.ContextExecutor, op errors.Op, id int64) (types.Qso, error) {
+	if id < 1 {
+		return types.Qso{}, errors.New(op).Msg(errMsgInvalidId)
+	}
+
+	model, err := models.FindQso(ctx, exec, id)
+	if err != nil {
+		if stderr.Is(err, sql.ErrNoRows) {
+			return types.Qso{}, errors.ErrNotFound
+		}
+		return types.Qso{}, errors.New(op).Err(err)
+	}
+
+	qso, err := adapters.QsoModelToType(model)
+	if err != nil {
+		return types.Qso{}, errors.New(op).Err(err)
+	}
+
+	return qso, nil
+}
+
+func fetchContactedStationByCallsign(ctx context.Context, exec boil.ContextExecutor, op errors.Op, callsign string) (types.ContactedStation, error) {
+	callsign = strings.TrimSpace(callsign)
+	if callsign == "" {
+		return types.ContactedStation{}, errors.New(op).Msg(errMsgEmptyCallsign)
+	}
+
+	model, err := models.ContactedStations(models.ContactedStationWhere.Call.EQ(callsign)).One(ctx, exec)
+	if err != nil {
+		if stderr.Is(err, sql.ErrNoRows) {
+			return types.ContactedStation{}, errors.ErrNotFound
+		}
+		return types.ContactedStation{}, errors.New(op).Err(err)
+	}
+
+	contactedStation, err := adapters.ContactedStationModelToType(model)
+	if err != nil {
+		return types.ContactedStation{}, errors.New(op).Err(err)
+	}
+
+	return contactedStation, nil
+}
+
+func fetchLogbookByID(ctx context.Context, exec boil.ContextExecutor, op errors.Op, id int64) (types.Logbook, error) {
+	if id < 1 {
+		return types.Logbook{}, errors.New(op).Msg(errMsgInvalidId)
+	}
+
+	model, err := models.FindLogbook(ctx, exec, id)
+	if err != nil {
+		if stderr.Is(err, sql.ErrNoRows) {
+			return types.Logbook{}, errors.ErrNotFound
+		}
+		return types.Logbook{}, errors.New(op).Err(err)
+	}
+
+	logbook, err := adapters.LogbookModelToType(model)
+	if err != nil {
+		return types.Logbook{}, errors.New(op).Err(err)
+	}
+
+	return logbook, nil
+}
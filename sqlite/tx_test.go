@@ -0,0 +1,159 @@
+package sqlite
+
+import (
+	"context"
+	stderr "errors"
+	"testing"
+	"time"
+
+	"github.com/Station-Manager/enums/upload/action"
+	"github.com/Station-Manager/errors"
+	"github.com/Station-Manager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestRollback = stderr.New("roll back")
+
+func TestWithTxCommit(t *testing.T) {
+	s := newTestService(t)
+
+	var id int64
+	err := s.WithTx(context.Background(), func(tx *Tx) error {
+		var er error
+		id, er = tx.InsertLogbook(types.Logbook{Name: "Committed", Callsign: "W1AW"})
+		return er
+	})
+	require.NoError(t, err)
+
+	lb, err := s.FetchLogbookByID(id)
+	require.NoError(t, err)
+	assert.Equal(t, "Committed", lb.Name)
+}
+
+func TestWithTxRollback(t *testing.T) {
+	s := newTestService(t)
+
+	var id int64
+	err := s.WithTx(context.Background(), func(tx *Tx) error {
+		var er error
+		if id, er = tx.InsertLogbook(types.Logbook{Name: "Rolled back", Callsign: "W1AW"}); er != nil {
+			return er
+		}
+		return errTestRollback
+	})
+	require.ErrorIs(t, err, errTestRollback)
+
+	_, err = s.FetchLogbookByID(id)
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+}
+
+func TestWithTxRollbackOnPanic(t *testing.T) {
+	s := newTestService(t)
+
+	var id int64
+	assert.Panics(t, func() {
+		_ = s.WithTx(context.Background(), func(tx *Tx) error {
+			var er error
+			if id, er = tx.InsertLogbook(types.Logbook{Name: "Panicked", Callsign: "W1AW"}); er != nil {
+				return er
+			}
+			panic("boom")
+		})
+	})
+	require.NotZero(t, id)
+
+	// The connection must have been given back, or this would time out.
+	_, err := s.FetchLogbookByID(id)
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+}
+
+func TestWithTxNestedRollback(t *testing.T) {
+	s := newTestService(t)
+	changes, err := s.Subscribe(t.Context(), ChangeFilter{Entities: []ChangeEntity{ChangeLogbook}})
+	require.NoError(t, err)
+
+	var outerID, innerID int64
+	err = s.WithTx(context.Background(), func(tx *Tx) error {
+		var er error
+		if outerID, er = tx.InsertLogbook(types.Logbook{Name: "Outer", Callsign: "W1AW"}); er != nil {
+			return er
+		}
+
+		er = tx.WithTx(func(inner *Tx) error {
+			var e error
+			if innerID, e = inner.InsertLogbook(types.Logbook{Name: "Inner", Callsign: "W1AW"}); e != nil {
+				return e
+			}
+			return errTestRollback
+		})
+		assert.ErrorIs(t, er, errTestRollback)
+
+		// A Service method given the transaction's context joins it, too.
+		er = s.WithTx(tx.Context(), func(inner *Tx) error {
+			if _, e := inner.InsertLogbook(types.Logbook{Name: "Joined", Callsign: "W1AW"}); e != nil {
+				return e
+			}
+			return errTestRollback
+		})
+		assert.ErrorIs(t, er, errTestRollback)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	_, err = s.FetchLogbookByID(outerID)
+	assert.NoError(t, err)
+	_, err = s.FetchLogbookByID(innerID)
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+
+	logbooks, err := s.FetchAllLogbooks()
+	require.NoError(t, err)
+	assert.Len(t, logbooks, 1)
+
+	// Only the outer insert is published; the rolled back savepoints took their events with them.
+	select {
+	case ev := <-changes:
+		assert.Equal(t, ChangeEvent{Entity: ChangeLogbook, Action: action.Insert, ID: outerID, LogbookID: outerID}, ev)
+	case <-time.After(time.Second):
+		t.Fatal("no event published")
+	}
+	select {
+	case ev := <-changes:
+		t.Fatalf("unexpected event: %+v", ev)
+	default:
+	}
+}
+
+func TestTxContextReads(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+
+	err := s.WithTx(context.Background(), func(tx *Tx) error {
+		id, er := tx.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1430"))
+		if er != nil {
+			return er
+		}
+
+		// Reads given the transaction's context see its uncommitted writes. With a single connection, a read on
+		// another connection would block until its context timed out.
+		qso, er := s.FetchQsoByIdWithContext(tx.Context(), id)
+		if er != nil {
+			return er
+		}
+		assert.Equal(t, "DL1ABC", qso.ContactedStation.Call)
+
+		result, er := s.SearchQsosWithContext(tx.Context(), QsoFilter{LogbookID: lb}, Page{Number: 1, Size: 10})
+		if er != nil {
+			return er
+		}
+		assert.EqualValues(t, 1, result.Total)
+
+		return errTestRollback
+	})
+	require.ErrorIs(t, err, errTestRollback)
+
+	count, err := s.FetchQsoCountByLogbookId(lb)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
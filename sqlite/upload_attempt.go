@@ -0,0 +1,80 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/enums/upload/status"
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+)
+
+// defaultUploadAttemptLimit is the number of attempts FetchUploadAttemptsByService returns when no limit is given.
+const defaultUploadAttemptLimit = 100
+
+// UploadAttempt is one attempt at sending an upload to its service. The history outlives the upload: UploadID is
+// zero once the upload has been cancelled or purged.
+type UploadAttempt struct {
+	ID       int64  `json:"id"`
+	UploadID int64  `json:"upload_id"`
+	QsoID    int64  `json:"qso_id"`
+	Service  string `json:"service"`
+	Action   string `json:"action"`
+
+	Outcome     status.Status `json:"outcome"`      // Uploaded or failed
+	AttemptedAt time.Time     `json:"attempted_at"` // Defaults to when the upload was reserved
+	Duration    time.Duration `json:"duration"`
+
+	ResponseCode    int    `json:"response_code"` // e.g. the HTTP status; zero if unknown
+	ResponseMessage string `json:"response_message"`
+	RemoteID        string `json:"remote_id"` // The service's ID for the record
+	Error           string `json:"error"`
+}
+
+// insertUploadAttempt records an attempt at sending the upload.
+func insertUploadAttempt(ctx context.Context, exec boil.ContextExecutor, up *models.QsoUpload, outcome status.Status, attempt UploadAttempt) error {
+	model := models.QsoUploadAttempt{
+		QsoUploadID:     null.Int64From(up.ID),
+		QsoID:           up.QsoID,
+		Service:         up.Service,
+		Action:          up.Action,
+		AttemptedAt:     attempt.AttemptedAt.Unix(),
+		DurationMS:      max(attempt.Duration.Milliseconds(), 0),
+		Outcome:         outcome.String(),
+		ResponseCode:    null.NewInt64(int64(attempt.ResponseCode), attempt.ResponseCode != 0),
+		ResponseMessage: null.NewString(attempt.ResponseMessage, attempt.ResponseMessage != emptyString),
+		RemoteID:        null.NewString(attempt.RemoteID, attempt.RemoteID != emptyString),
+		Error:           null.NewString(attempt.Error, attempt.Error != emptyString),
+	}
+	return model.Insert(ctx, exec, boil.Infer())
+}
+
+// fetchUploadAttempts returns the attempts selected by mods, newest first.
+func fetchUploadAttempts(ctx context.Context, exec boil.ContextExecutor, mods ...qm.QueryMod) ([]UploadAttempt, error) {
+	mods = append(mods, qm.OrderBy(models.QsoUploadAttemptColumns.AttemptedAt+" DESC, "+models.QsoUploadAttemptColumns.ID+" DESC"))
+	slice, err := models.QsoUploadAttempts(mods...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]UploadAttempt, 0, len(slice))
+	for _, m := range slice {
+		out = append(out, UploadAttempt{
+			ID:              m.ID,
+			UploadID:        m.QsoUploadID.Int64,
+			QsoID:           m.QsoID,
+			Service:         m.Service,
+			Action:          m.Action,
+			Outcome:         status.Status(m.Outcome),
+			AttemptedAt:     time.Unix(m.AttemptedAt, 0),
+			Duration:        time.Duration(m.DurationMS) * time.Millisecond,
+			ResponseCode:    int(m.ResponseCode.Int64),
+			ResponseMessage: m.ResponseMessage.String,
+			RemoteID:        m.RemoteID.String,
+			Error:           m.Error.String,
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,136 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/enums/upload"
+	"github.com/Station-Manager/enums/upload/action"
+	"github.com/Station-Manager/enums/upload/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reserveUpload queues an upload of the QSO to the service and reserves it.
+func reserveUpload(t *testing.T, s *Service, qsoID int64, service upload.OnlineService) int64 {
+	t.Helper()
+	require.NoError(t, s.InsertQsoUpload(qsoID, action.Insert, service))
+	uploads, err := s.FetchPendingUploadsForService(service, 1)
+	require.NoError(t, err)
+	require.Len(t, uploads, 1)
+	return uploads[0].ID
+}
+
+// retryUpload makes a failed upload due and reserves it again.
+func retryUpload(t *testing.T, s *Service, id int64, service upload.OnlineService) {
+	t.Helper()
+	_, err := s.RetryUploadsNow([]int64{id})
+	require.NoError(t, err)
+	uploads, err := s.FetchPendingUploadsForService(service, 1)
+	require.NoError(t, err)
+	require.Len(t, uploads, 1)
+	require.Equal(t, id, uploads[0].ID)
+}
+
+func TestUploadAttemptInReportTransaction(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	qsoID, err := s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1430"))
+	require.NoError(t, err)
+	uploadID := reserveUpload(t, s, qsoID, upload.OnlineServiceQRZ)
+
+	// A report that is rolled back takes its attempt with it.
+	err = s.WithTx(context.Background(), func(tx *Tx) error {
+		if er := tx.UpdateQsoUploadStatus(uploadID, status.Failed, emptyString, 1, "timeout"); er != nil {
+			return er
+		}
+		return errTestRollback
+	})
+	require.ErrorIs(t, err, errTestRollback)
+	attempts, err := s.FetchUploadAttemptsByQsoId(qsoID)
+	require.NoError(t, err)
+	assert.Empty(t, attempts)
+	model, err := models.FindQsoUpload(context.Background(), s.handle, uploadID)
+	require.NoError(t, err)
+	assert.Equal(t, status.InProgress.String(), model.Status)
+
+	attemptedAt := time.Date(2025, 1, 7, 14, 31, 0, 0, time.UTC)
+	require.NoError(t, s.RecordUploadAttempt(uploadID, 1, UploadAttempt{Outcome: status.Uploaded, AttemptedAt: attemptedAt,
+		Duration: 1500 * time.Millisecond, ResponseCode: 200, ResponseMessage: "OK", RemoteID: "42"}))
+	attempts, err = s.FetchUploadAttemptsByQsoId(qsoID)
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	got := attempts[0]
+	got.AttemptedAt = got.AttemptedAt.UTC()
+	assert.Equal(t, UploadAttempt{ID: got.ID, UploadID: uploadID, QsoID: qsoID, Service: upload.OnlineServiceQRZ.String(),
+		Action: action.Insert.String(), Outcome: status.Uploaded, AttemptedAt: attemptedAt, Duration: 1500 * time.Millisecond,
+		ResponseCode: 200, ResponseMessage: "OK", RemoteID: "42"}, got)
+	model, err = models.FindQsoUpload(context.Background(), s.handle, uploadID)
+	require.NoError(t, err)
+	assert.Equal(t, status.Uploaded.String(), model.Status)
+}
+
+func TestUploadAttemptOrder(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	first, err := s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1430"))
+	require.NoError(t, err)
+	second, err := s.InsertQso(testQso(lb, sess, "DL2ABC", "20250107", "1431"))
+	require.NoError(t, err)
+
+	at := func(minute int) time.Time { return time.Date(2025, 1, 7, 15, minute, 0, 0, time.UTC) }
+	failed := func(minute int) UploadAttempt {
+		return UploadAttempt{Outcome: status.Failed, AttemptedAt: at(minute), Error: "timeout"}
+	}
+
+	// The attempts are reported out of order: what counts is when they were made.
+	up1 := reserveUpload(t, s, first, upload.OnlineServiceQRZ)
+	require.NoError(t, s.RecordUploadAttempt(up1, 1, failed(3)))
+	up2 := reserveUpload(t, s, second, upload.OnlineServiceQRZ)
+	require.NoError(t, s.RecordUploadAttempt(up2, 1, failed(1)))
+	retryUpload(t, s, up1, upload.OnlineServiceQRZ)
+	require.NoError(t, s.RecordUploadAttempt(up1, 2, failed(2)))
+	up3 := reserveUpload(t, s, first, upload.OnlineServiceLoTW)
+	require.NoError(t, s.RecordUploadAttempt(up3, 1, failed(4)))
+
+	times := func(attempts []UploadAttempt) []time.Time {
+		out := make([]time.Time, len(attempts))
+		for i, a := range attempts {
+			out[i] = a.AttemptedAt.UTC()
+		}
+		return out
+	}
+
+	attempts, err := s.FetchUploadAttemptsByQsoId(first)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{at(4), at(3), at(2)}, times(attempts), "newest first, across services")
+
+	attempts, err = s.FetchUploadAttemptsByService(upload.OnlineServiceQRZ, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{at(3), at(2), at(1)}, times(attempts), "newest first, across QSOs")
+
+	attempts, err = s.FetchUploadAttemptsByService(upload.OnlineServiceQRZ, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{at(3), at(2)}, times(attempts))
+}
+
+func TestUploadAttemptOutlivesCancelledUpload(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	qsoID, err := s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1430"))
+	require.NoError(t, err)
+
+	uploadID := reserveUpload(t, s, qsoID, upload.OnlineServiceQRZ)
+	require.NoError(t, s.UpdateQsoUploadStatus(uploadID, status.Failed, emptyString, 1, "timeout"))
+	n, err := s.CancelUploads([]int64{uploadID})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+
+	attempts, err := s.FetchUploadAttemptsByQsoId(qsoID)
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	assert.Zero(t, attempts[0].UploadID)
+	assert.Equal(t, "timeout", attempts[0].Error)
+}
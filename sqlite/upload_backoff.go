@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/Station-Manager/enums/upload"
+	"github.com/Station-Manager/errors"
+)
+
+// maxUploadRetryDelay caps the delay before a retry, however many attempts have been made.
+const maxUploadRetryDelay = 7 * 24 * time.Hour
+
+// UploadBackoff decides when a failed upload is retried. The n-th retry waits Base * Factor^(n-1), give or take
+// Jitter (a fraction of the delay, chosen at random per retry). After MaxAttempts failed attempts the upload is
+// moved to UploadStatusDead; zero means it is retried forever.
+type UploadBackoff struct {
+	Base        time.Duration `json:"base"`
+	Factor      float64       `json:"factor"`
+	Jitter      float64       `json:"jitter"`
+	MaxAttempts int64         `json:"max_attempts"`
+}
+
+// defaultUploadBackoff applies to services without a backoff of their own.
+var defaultUploadBackoff = UploadBackoff{Base: uploadRetryCooldown, Factor: 2, Jitter: 0.1, MaxAttempts: 10}
+
+// SetUploadBackoff configures the retry schedule of a service's uploads. An empty service sets the default used by
+// every service without a schedule of its own.
+func (s *Service) SetUploadBackoff(service upload.OnlineService, backoff UploadBackoff) error {
+	const op errors.Op = "sqlite.Service.SetUploadBackoff"
+	if s == nil {
+		return errors.New(op).Msg(errMsgNilService)
+	}
+
+	if backoff.Base <= 0 {
+		return errors.New(op).Msg("Backoff base must be positive.")
+	}
+	if backoff.Factor < 1 {
+		return errors.New(op).Msg("Backoff factor cannot be less than 1.")
+	}
+	if backoff.Jitter < 0 || backoff.Jitter > 1 {
+		return errors.New(op).Msg("Backoff jitter must be between 0 and 1.")
+	}
+	if backoff.MaxAttempts < 0 {
+		return errors.New(op).Msg("Backoff max attempts cannot be negative.")
+	}
+
+	s.mu.Lock()
+	if s.uploadBackoffs == nil {
+		s.uploadBackoffs = map[string]UploadBackoff{}
+	}
+	s.uploadBackoffs[service.String()] = backoff
+	s.mu.Unlock()
+
+	return nil
+}
+
+// uploadBackoff returns the retry schedule of the service.
+func (s *Service) uploadBackoff(service string) UploadBackoff {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if b, ok := s.uploadBackoffs[service]; ok {
+		return b
+	}
+	if b, ok := s.uploadBackoffs[emptyString]; ok {
+		return b
+	}
+	return defaultUploadBackoff
+}
+
+// exhausted reports whether an upload that has failed 'attempts' times should be given up on.
+func (b UploadBackoff) exhausted(attempts int64) bool {
+	return b.MaxAttempts > 0 && attempts >= b.MaxAttempts
+}
+
+// delay returns how long to wait after the given number of failed attempts. r is a random number in [0, 1) that
+// places the delay within the jitter range.
+func (b UploadBackoff) delay(attempts int64, r float64) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	d := float64(b.Base) * math.Pow(b.Factor, float64(attempts-1))
+	d *= 1 + b.Jitter*(2*r-1)
+	if d > float64(maxUploadRetryDelay) || math.IsInf(d, 0) || math.IsNaN(d) {
+		return maxUploadRetryDelay
+	}
+
+	return time.Duration(d)
+}
+
+// nextAttempt returns when an upload that has failed 'attempts' times should be retried.
+func (b UploadBackoff) nextAttempt(now time.Time, attempts int64) time.Time {
+	return now.Add(b.delay(attempts, rand.Float64()))
+}
@@ -0,0 +1,30 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadBackoffDelay(t *testing.T) {
+	b := UploadBackoff{Base: time.Minute, Factor: 2}
+	assert.Equal(t, time.Minute, b.delay(0, 0.5))
+	assert.Equal(t, time.Minute, b.delay(1, 0.5))
+	assert.Equal(t, 4*time.Minute, b.delay(3, 0.5))
+	assert.Equal(t, maxUploadRetryDelay, b.delay(1000, 0.5))
+
+	b.Jitter = 0.5
+	assert.Equal(t, 30*time.Second, b.delay(1, 0))
+	assert.Equal(t, time.Minute, b.delay(1, 0.5))
+	assert.InDelta(t, float64(90*time.Second), float64(b.delay(1, 0.999999)), float64(time.Second))
+}
+
+func TestUploadBackoffExhausted(t *testing.T) {
+	b := UploadBackoff{Base: time.Minute, Factor: 2, MaxAttempts: 3}
+	assert.False(t, b.exhausted(2))
+	assert.True(t, b.exhausted(3))
+
+	b.MaxAttempts = 0
+	assert.False(t, b.exhausted(1000))
+}
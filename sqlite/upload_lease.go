@@ -0,0 +1,100 @@
+package sqlite
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	stderr "errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Station-Manager/errors"
+)
+
+// defaultUploadLeaseTTL is how long a reservation made by FetchPendingUploads lasts unless SetUploadLease says
+// otherwise. It should comfortably cover uploading one batch.
+const defaultUploadLeaseTTL = 10 * time.Minute
+
+// ErrUploadLeaseLost is matched (via errors.Is) by every UploadLeaseLostError.
+var ErrUploadLeaseLost = stderr.New("upload lease lost")
+
+// UploadLeaseLostError is returned when an attempt is reported for an upload this process no longer holds: the
+// lease ran out and another forwarder took it, or the upload was released, cancelled or settled in the meantime.
+// The report is discarded; the upload's current holder reports its own attempt.
+type UploadLeaseLostError struct {
+	UploadID int64
+	Status   string // The upload's status when the report came in
+	Owner    string // The upload's lease owner, if any
+}
+
+func (e *UploadLeaseLostError) Error() string {
+	if e.Owner != emptyString {
+		return fmt.Sprintf("upload %d is %s, leased by %s", e.UploadID, e.Status, e.Owner)
+	}
+	return fmt.Sprintf("upload %d is %s", e.UploadID, e.Status)
+}
+
+func (e *UploadLeaseLostError) Is(target error) bool {
+	return target == ErrUploadLeaseLost
+}
+
+// uploadLease identifies this service's reservations of qso_upload rows.
+type uploadLease struct {
+	owner string
+	ttl   time.Duration
+}
+
+// SetUploadLease configures the reservations made by FetchPendingUploads. owner identifies this process in
+// qso_upload.lease_owner; an empty owner keeps the current one, which defaults to host name, process ID and a
+// random suffix. A reservation not settled by UpdateQsoUploadStatus or ReleaseUploadLease within ttl is taken to be
+// abandoned and is handed out again.
+func (s *Service) SetUploadLease(owner string, ttl time.Duration) error {
+	const op errors.Op = "sqlite.Service.SetUploadLease"
+	if s == nil {
+		return errors.New(op).Msg(errMsgNilService)
+	}
+
+	if ttl <= 0 {
+		return errors.New(op).Msg("Upload lease TTL must be positive.")
+	}
+
+	s.mu.Lock()
+	if owner != emptyString {
+		s.lease.owner = owner
+	}
+	s.lease.ttl = ttl
+	s.mu.Unlock()
+
+	return nil
+}
+
+// uploadLease returns the owner and TTL of this service's reservations, choosing the default owner on first use.
+func (s *Service) uploadLease() uploadLease {
+	s.mu.RLock()
+	lease := s.lease
+	s.mu.RUnlock()
+
+	if lease.owner == emptyString {
+		s.mu.Lock()
+		if s.lease.owner == emptyString {
+			s.lease.owner = defaultUploadLeaseOwner()
+		}
+		lease = s.lease
+		s.mu.Unlock()
+	}
+	if lease.ttl <= 0 {
+		lease.ttl = defaultUploadLeaseTTL
+	}
+
+	return lease
+}
+
+func defaultUploadLeaseOwner() string {
+	host, err := os.Hostname()
+	if err != nil || host == emptyString {
+		host = "localhost"
+	}
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return fmt.Sprintf("%s:%d:%s", host, os.Getpid(), hex.EncodeToString(suffix))
+}
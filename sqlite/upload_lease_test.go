@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Station-Manager/enums/upload"
+	"github.com/Station-Manager/enums/upload/action"
+	"github.com/Station-Manager/enums/upload/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadLease(t *testing.T) {
+	s := &Service{}
+
+	lease := s.uploadLease()
+	assert.NotEmpty(t, lease.owner)
+	assert.Equal(t, defaultUploadLeaseTTL, lease.ttl)
+	assert.Equal(t, lease.owner, s.uploadLease().owner, "the default owner must not change between calls")
+
+	require.NoError(t, s.SetUploadLease(emptyString, time.Minute))
+	assert.Equal(t, uploadLease{owner: lease.owner, ttl: time.Minute}, s.uploadLease())
+
+	require.NoError(t, s.SetUploadLease("forwarder-1", 2*time.Minute))
+	assert.Equal(t, uploadLease{owner: "forwarder-1", ttl: 2 * time.Minute}, s.uploadLease())
+
+	assert.Error(t, s.SetUploadLease("forwarder-1", 0))
+}
+
+func TestUploadLeaseFencing(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	id, err := s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1430"))
+	require.NoError(t, err)
+	require.NoError(t, s.InsertQsoUpload(id, action.Insert, upload.OnlineServiceQRZ))
+
+	// Released before the report
+	uploads, err := s.FetchPendingUploadsForService(upload.OnlineServiceQRZ, 10)
+	require.NoError(t, err)
+	require.Len(t, uploads, 1)
+	uploadID := uploads[0].ID
+	_, err = s.ReleaseUploadLease(nil)
+	require.NoError(t, err)
+	err = s.RecordUploadAttempt(uploadID, 1, UploadAttempt{Outcome: status.Uploaded})
+	var lost *UploadLeaseLostError
+	require.ErrorAs(t, err, &lost)
+	assert.Equal(t, UploadLeaseLostError{UploadID: uploadID, Status: status.Pending.String()}, *lost)
+
+	// Reserved by another forwarder
+	require.NoError(t, s.SetUploadLease("forwarder-1", time.Minute))
+	_, err = s.FetchPendingUploadsForService(upload.OnlineServiceQRZ, 10)
+	require.NoError(t, err)
+	require.NoError(t, s.SetUploadLease("forwarder-2", time.Minute))
+	err = s.RecordUploadAttempt(uploadID, 1, UploadAttempt{Outcome: status.Failed, Error: "late"})
+	require.ErrorIs(t, err, ErrUploadLeaseLost)
+	require.ErrorAs(t, err, &lost)
+	assert.Equal(t, "forwarder-1", lost.Owner)
+
+	attempts, err := s.FetchUploadAttemptsByQsoId(id)
+	require.NoError(t, err)
+	assert.Empty(t, attempts, "a rejected report must not be recorded")
+
+	require.NoError(t, s.SetUploadLease("forwarder-1", time.Minute))
+	require.NoError(t, s.RecordUploadAttempt(uploadID, 1, UploadAttempt{Outcome: status.Uploaded}))
+
+	// Already settled
+	err = s.RecordUploadAttempt(uploadID, 2, UploadAttempt{Outcome: status.Failed, Error: "again"})
+	assert.ErrorIs(t, err, ErrUploadLeaseLost)
+}
+
+func TestUpdateQsoUploadStatusIsNotFenced(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	id, err := s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1430"))
+	require.NoError(t, err)
+	require.NoError(t, s.InsertQsoUpload(id, action.Insert, upload.OnlineServiceQRZ))
+	uploads, err := s.FetchPendingUploadsForService(upload.OnlineServiceQRZ, 10)
+	require.NoError(t, err)
+	require.Len(t, uploads, 1)
+	uploadID := uploads[0].ID
+
+	// Reserved by another forwarder, as after a restart with a new owner
+	require.NoError(t, s.SetUploadLease("forwarder-2", time.Minute))
+	require.NoError(t, s.UpdateQsoUploadStatus(uploadID, status.Failed, emptyString, 1, "timeout"))
+
+	// Pending, never reserved
+	_, err = s.RetryUploadsNow([]int64{uploadID})
+	require.NoError(t, err)
+	require.NoError(t, s.UpdateQsoUploadStatus(uploadID, status.Uploaded, emptyString, 2, emptyString))
+
+	attempts, err := s.FetchUploadAttemptsByQsoId(id)
+	require.NoError(t, err)
+	assert.Len(t, attempts, 2)
+}
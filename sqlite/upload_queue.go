@@ -0,0 +1,139 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"github.com/Station-Manager/types"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+)
+
+// The reservation queries spell out the statuses so that the planner can match them against the partial index
+// idx_qso_upload_pending; with bound parameters it cannot, and falls back to scanning the table.
+const (
+	// reservableServicesQuery lists the services that have uploads a reservation could pick.
+	reservableServicesQuery = `
+		SELECT DISTINCT service
+		  FROM qso_upload
+		 WHERE status IN ('pending', 'failed', 'in_progress')
+		 ORDER BY service`
+
+	// reserveUploadsQuery reserves up to LIMIT uploads of one service in the order they were queued: pending
+	// uploads, failed ones whose retry is due, and reservations whose lease has run out. Reservations made before
+	// leases existed have no expiry and count as abandoned. The new attempt sends the QSO as it is now, which settles
+	// any requeue, unless an abandoned insert or update is taken over after its QSO was deleted: that one still needs
+	// the delete queued once it is reported. Rows are ordered by id, as created_at is not stored in a single text
+	// format.
+	reserveUploadsQuery = `
+		UPDATE qso_upload
+		   SET status = 'in_progress', modified_at = ?, last_attempt_at = ?, lease_owner = ?, lease_expires_at = ?,
+		       requeued = (requeued AND action <> 'delete'
+		                   AND EXISTS (SELECT 1 FROM qso WHERE qso.id = qso_upload.qso_id AND qso.deleted_at IS NOT NULL))
+		 WHERE id IN (
+		     SELECT id
+		       FROM qso_upload
+		      WHERE service = ?
+		        AND status IN ('pending', 'failed', 'in_progress')
+		        AND CASE status
+		                WHEN 'in_progress' THEN coalesce(lease_expires_at, 0)
+		                ELSE coalesce(next_attempt_at, 0)
+		            END <= ?
+		      ORDER BY id
+		      LIMIT ?
+		   )
+		RETURNING id`
+)
+
+// reserveUploads reserves up to limit uploads of the service for this process and returns their IDs.
+func (s *Service) reserveUploads(ctx context.Context, exec boil.ContextExecutor, service string, limit int, now time.Time) ([]int64, error) {
+	lease := s.uploadLease()
+
+	var rows []struct {
+		ID int64 `boil:"id"`
+	}
+	err := queries.Raw(reserveUploadsQuery, now, now.Unix(), lease.owner, now.Add(lease.ttl).Unix(), service,
+		now.Unix(), limit).Bind(ctx, exec, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, len(rows))
+	for i, r := range rows {
+		ids[i] = r.ID
+	}
+	return ids, nil
+}
+
+// reserveUploadsRoundRobin reserves up to limit uploads across all services. The limit is shared out evenly between
+// the services that have work, and whatever a service cannot use goes to the others, so a backlog in one service
+// does not hold up the rest. first is the index of the service that gets any odd share; callers rotate it.
+func (s *Service) reserveUploadsRoundRobin(ctx context.Context, exec boil.ContextExecutor, limit int, first uint64, now time.Time) ([]int64, error) {
+	var services []struct {
+		Service string `boil:"service"`
+	}
+	if err := queries.Raw(reservableServicesQuery).Bind(ctx, exec, &services); err != nil {
+		return nil, err
+	}
+	if len(services) == 0 {
+		return nil, nil
+	}
+
+	active := make([]string, len(services))
+	for i := range services {
+		active[i] = services[(int(first%uint64(len(services)))+i)%len(services)].Service
+	}
+
+	var ids []int64
+	remaining := limit
+	for remaining > 0 && len(active) > 0 {
+		share, odd := remaining/len(active), remaining%len(active)
+		next := active[:0]
+		for i, service := range active {
+			quota := share
+			if i < odd {
+				quota++
+			}
+			if quota == 0 {
+				next = append(next, service)
+				continue
+			}
+
+			reserved, err := s.reserveUploads(ctx, exec, service, quota, now)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, reserved...)
+			remaining -= len(reserved)
+			if len(reserved) == quota {
+				next = append(next, service) // May have more
+			}
+		}
+		active = next
+	}
+
+	return ids, nil
+}
+
+// interleaveUploads orders uploads that are sorted oldest first so that services take turns, starting with the
+// service of the oldest upload.
+func interleaveUploads(uploads []types.QsoUpload) []types.QsoUpload {
+	var order []string
+	byService := map[string][]types.QsoUpload{}
+	for _, up := range uploads {
+		if _, ok := byService[up.Service]; !ok {
+			order = append(order, up.Service)
+		}
+		byService[up.Service] = append(byService[up.Service], up)
+	}
+
+	out := make([]types.QsoUpload, 0, len(uploads))
+	for round := 0; len(out) < len(uploads); round++ {
+		for _, service := range order {
+			if round < len(byService[service]) {
+				out = append(out, byService[service][round])
+			}
+		}
+	}
+	return out
+}
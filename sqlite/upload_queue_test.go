@@ -0,0 +1,225 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/enums/upload"
+	"github.com/Station-Manager/enums/upload/action"
+	"github.com/Station-Manager/enums/upload/status"
+	"github.com/Station-Manager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterleaveUploads(t *testing.T) {
+	in := []types.QsoUpload{
+		{ID: 1, Service: "qrz"}, {ID: 2, Service: "qrz"}, {ID: 3, Service: "lotw"},
+		{ID: 4, Service: "qrz"}, {ID: 5, Service: "eqsl"}, {ID: 6, Service: "lotw"},
+	}
+
+	var ids []int64
+	for _, up := range interleaveUploads(in) {
+		ids = append(ids, up.ID)
+	}
+	assert.Equal(t, []int64{1, 3, 5, 2, 6, 4}, ids)
+
+	assert.Empty(t, interleaveUploads(nil))
+}
+
+func TestReserveUploadsInQueueOrder(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+
+	insertQso := func(call string) int64 {
+		id, err := s.InsertQso(testQso(lb, sess, call, "20250107", "1430"))
+		require.NoError(t, err)
+		return id
+	}
+
+	// The uploads are queued through both insert paths: InsertQsoUpload writes created_at through the model, and
+	// forwarding rules leave it to the column default, in another format.
+	first := insertQso("DL1ABC")
+	require.NoError(t, s.InsertQsoUpload(first, action.Insert, upload.OnlineServiceQRZ))
+	_, err := s.SetForwardingRule(ForwardingRule{LogbookID: lb, Service: upload.OnlineServiceQRZ, Enabled: true})
+	require.NoError(t, err)
+	second := insertQso("DL2ABC")
+	require.NoError(t, s.DeleteForwardingRule(lb, upload.OnlineServiceQRZ))
+	third := insertQso("DL3ABC")
+	require.NoError(t, s.InsertQsoUpload(third, action.Insert, upload.OnlineServiceQRZ))
+
+	for _, want := range []int64{first, second, third} {
+		uploads, er := s.FetchPendingUploadsForService(upload.OnlineServiceQRZ, 1)
+		require.NoError(t, er)
+		require.Len(t, uploads, 1)
+		assert.Equal(t, want, uploads[0].QsoID)
+	}
+}
+
+func TestReserveUploadsKillsUnreadable(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+
+	good, err := s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1430"))
+	require.NoError(t, err)
+	bad, err := s.InsertQso(testQso(lb, sess, "DL2ABC", "20250107", "1431"))
+	require.NoError(t, err)
+	require.NoError(t, s.InsertQsoUpload(good, action.Insert, upload.OnlineServiceQRZ))
+	require.NoError(t, s.InsertQsoUpload(bad, action.Insert, upload.OnlineServiceQRZ))
+	_, err = s.handle.Exec(`UPDATE qso SET additional_data = '[]' WHERE id = ?`, bad)
+	require.NoError(t, err)
+
+	uploads, err := s.FetchPendingUploadsForService(upload.OnlineServiceQRZ, 10)
+	require.NoError(t, err)
+	require.Len(t, uploads, 1)
+	assert.Equal(t, good, uploads[0].QsoID)
+
+	dead, err := s.FetchDeadUploads(upload.OnlineServiceQRZ)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, bad, dead[0].QsoID)
+	assert.NotEmpty(t, dead[0].LastError)
+
+	_, err = s.ReleaseUploadLease(nil)
+	require.NoError(t, err)
+	uploads, err = s.FetchPendingUploadsForService(upload.OnlineServiceQRZ, 10)
+	require.NoError(t, err)
+	require.Len(t, uploads, 1, "the unreadable upload must not be reserved again")
+	assert.Equal(t, good, uploads[0].QsoID)
+}
+
+// insertUpload writes a qso_upload row directly, for states the API only reaches over time.
+func insertUpload(t *testing.T, s *Service, qsoID int64, service upload.OnlineService, act action.Action, st, columns string, values ...interface{}) int64 {
+	t.Helper()
+	query := `INSERT INTO qso_upload (qso_id, service, action, status` + columns + `) VALUES (?, ?, ?, ?` +
+		strings.Repeat(", ?", len(values)) + `)`
+	res, err := s.handle.Exec(query, append([]interface{}{qsoID, service.String(), act.String(), st}, values...)...)
+	require.NoError(t, err)
+	id, err := res.LastInsertId()
+	require.NoError(t, err)
+	return id
+}
+
+func findUpload(t *testing.T, s *Service, id int64) *models.QsoUpload {
+	t.Helper()
+	up, err := models.FindQsoUpload(context.Background(), s.handle, id)
+	require.NoError(t, err)
+	return up
+}
+
+func TestRetryUploadsNow(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	insertQso := func(call string) int64 {
+		id, err := s.InsertQso(testQso(lb, sess, call, "20250107", "1430"))
+		require.NoError(t, err)
+		return id
+	}
+
+	later := time.Now().Add(time.Hour).Unix()
+	failed := insertUpload(t, s, insertQso("DL1ABC"), upload.OnlineServiceQRZ, action.Insert, status.Failed.String(),
+		", attempts, next_attempt_at, last_error", 2, later, "timeout")
+	pending := insertUpload(t, s, insertQso("DL2ABC"), upload.OnlineServiceQRZ, action.Insert, status.Pending.String(),
+		", next_attempt_at", later)
+	dead := insertUpload(t, s, insertQso("DL3ABC"), upload.OnlineServiceQRZ, action.Insert, UploadStatusDead.String(),
+		", attempts", 10)
+
+	uploads, err := s.FetchPendingUploadsForService(upload.OnlineServiceQRZ, 10)
+	require.NoError(t, err)
+	assert.Empty(t, uploads, "nothing is due yet")
+
+	n, err := s.RetryUploadsNow([]int64{failed, pending, dead})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n, "dead uploads are left alone")
+
+	up := findUpload(t, s, failed)
+	assert.Equal(t, status.Pending.String(), up.Status)
+	assert.False(t, up.NextAttemptAt.Valid)
+	assert.Equal(t, int64(2), up.Attempts, "attempts are kept")
+	assert.Equal(t, UploadStatusDead.String(), findUpload(t, s, dead).Status)
+
+	uploads, err = s.FetchPendingUploadsForService(upload.OnlineServiceQRZ, 10)
+	require.NoError(t, err)
+	assert.Len(t, uploads, 2)
+
+	n, err = s.RetryUploadsNow(nil)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	_, err = s.RetryUploadsNow([]int64{failed, 0})
+	assert.Error(t, err)
+}
+
+func TestRequeueFailedUploads(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	qso, err := s.InsertQso(testQso(lb, sess, "DL1ABC", "20250107", "1430"))
+	require.NoError(t, err)
+
+	later := time.Now().Add(time.Hour).Unix()
+	failed := insertUpload(t, s, qso, upload.OnlineServiceQRZ, action.Insert, status.Failed.String(),
+		", attempts, next_attempt_at, last_error", 2, later, "timeout")
+	dead := insertUpload(t, s, qso, upload.OnlineServiceQRZ, action.Update, UploadStatusDead.String(),
+		", attempts, last_error", 10, "rejected")
+	uploaded := insertUpload(t, s, qso, upload.OnlineServiceQRZ, action.Delete, status.Uploaded.String(), ", attempts", 1)
+	other := insertUpload(t, s, qso, upload.OnlineServiceLoTW, action.Insert, status.Failed.String(),
+		", attempts, last_error", 1, "timeout")
+
+	n, err := s.RequeueFailedUploads(upload.OnlineServiceQRZ)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	for _, id := range []int64{failed, dead} {
+		up := findUpload(t, s, id)
+		assert.Equal(t, status.Pending.String(), up.Status)
+		assert.Zero(t, up.Attempts)
+		assert.False(t, up.LastError.Valid)
+		assert.False(t, up.NextAttemptAt.Valid)
+	}
+	assert.Equal(t, status.Uploaded.String(), findUpload(t, s, uploaded).Status)
+	assert.Equal(t, status.Failed.String(), findUpload(t, s, other).Status, "other services are left alone")
+
+	_, err = s.RequeueFailedUploads(emptyString)
+	assert.Error(t, err)
+}
+
+func TestPurgeUploadedOlderThan(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	insertQso := func(call string) int64 {
+		id, err := s.InsertQso(testQso(lb, sess, call, "20250107", "1430"))
+		require.NoError(t, err)
+		return id
+	}
+
+	// modified_at is local time, as the trigger writes it.
+	old := time.Now().Add(-48 * time.Hour).Format(time.DateTime)
+	recent := time.Now().Add(-time.Hour).Format(time.DateTime)
+
+	first, second := insertQso("DL1ABC"), insertQso("DL2ABC")
+	keptInsert := insertUpload(t, s, first, upload.OnlineServiceQRZ, action.Insert, status.Uploaded.String(), ", modified_at", old)
+	oldUpdate := insertUpload(t, s, first, upload.OnlineServiceQRZ, action.Update, status.Uploaded.String(), ", modified_at", old)
+	oldDelete := insertUpload(t, s, first, upload.OnlineServiceQRZ, action.Delete, status.Uploaded.String(), ", modified_at", old)
+	recentUpdate := insertUpload(t, s, second, upload.OnlineServiceQRZ, action.Update, status.Uploaded.String(), ", modified_at", recent)
+	pendingUpdate := insertUpload(t, s, second, upload.OnlineServiceLoTW, action.Update, status.Pending.String(), ", modified_at", old)
+
+	n, err := s.PurgeUploadedOlderThan(24 * time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	for _, id := range []int64{oldUpdate, oldDelete} {
+		exists, er := models.QsoUploadExists(context.Background(), s.handle, id)
+		require.NoError(t, er)
+		assert.False(t, exists)
+	}
+	for _, id := range []int64{keptInsert, recentUpdate, pendingUpdate} {
+		exists, er := models.QsoUploadExists(context.Background(), s.handle, id)
+		require.NoError(t, er)
+		assert.True(t, exists)
+	}
+
+	_, err = s.PurgeUploadedOlderThan(-time.Hour)
+	assert.Error(t, err)
+}
@@ -0,0 +1,179 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"github.com/Station-Manager/database/sqlite/models"
+	"github.com/Station-Manager/enums/upload/status"
+	"github.com/Station-Manager/errors"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+)
+
+// uploadStatsRecentErrors is the number of recent errors UploadQueueStats returns per service.
+const uploadStatsRecentErrors = 5
+
+// UploadServiceStats summarises the upload queue of one service.
+type UploadServiceStats struct {
+	Service string `json:"service"`
+
+	Pending    int64 `json:"pending"`
+	InProgress int64 `json:"in_progress"`
+	Uploaded   int64 `json:"uploaded"`
+	Failed     int64 `json:"failed"` // Waiting for a retry
+	Dead       int64 `json:"dead"`
+
+	// OldestPending is the age of the oldest upload still to be sent (pending, failed or in progress); zero if none.
+	OldestPending time.Duration `json:"oldest_pending"`
+	// SuccessRate is Uploaded / (Uploaded + Failed + Dead), the share of attempted uploads whose last attempt
+	// succeeded; zero if none have been attempted.
+	SuccessRate float64 `json:"success_rate"`
+
+	RecentErrors []UploadError `json:"recent_errors"` // Newest first
+}
+
+// UploadError is the last error recorded on an upload.
+type UploadError struct {
+	UploadID      int64         `json:"upload_id"`
+	QsoID         int64         `json:"qso_id"`
+	Action        string        `json:"action"`
+	Status        status.Status `json:"status"`
+	Error         string        `json:"error"`
+	LastAttemptAt time.Time     `json:"last_attempt_at"`
+}
+
+// uploadStatusCount is one row of the per service and status counts.
+type uploadStatusCount struct {
+	Service string `boil:"service"`
+	Status  string `boil:"status"`
+	Count   int64  `boil:"count"`
+}
+
+// UploadQueueStats returns, for every service with uploads, the number of uploads in each status, the age of the
+// oldest one still to be sent, the success rate and the most recent errors. Services are ordered by name.
+func (s *Service) UploadQueueStats(ctx context.Context) ([]UploadServiceStats, error) {
+	const op errors.Op = "sqlite.Service.UploadQueueStats"
+	if err := checkService(op, s); err != nil {
+		return nil, err
+	}
+
+	h, err := s.executor(ctx, op)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := s.ensureCtxTimeout(ctx)
+	defer cancel()
+
+	const countsQuery = `
+		SELECT service, status, COUNT(*) AS count
+		  FROM qso_upload
+		 GROUP BY service, status
+		 ORDER BY service, status`
+
+	var counts []uploadStatusCount
+	if err = queries.Raw(countsQuery).Bind(ctx, h, &counts); err != nil {
+		return nil, errors.New(op).Err(err).Msg("Failed to count uploads.")
+	}
+
+	const errorsQuery = `
+		SELECT id, qso_id, service, action, status, last_error, coalesce(last_attempt_at, 0) AS last_attempt_at
+		  FROM (SELECT *,
+		               row_number() OVER (PARTITION BY service ORDER BY last_attempt_at DESC, id DESC) AS n
+		          FROM qso_upload
+		         WHERE last_error IS NOT NULL AND last_error <> '')
+		 WHERE n <= ?
+		 ORDER BY service, n`
+
+	var recent []struct {
+		ID            int64  `boil:"id"`
+		QsoID         int64  `boil:"qso_id"`
+		Service       string `boil:"service"`
+		Action        string `boil:"action"`
+		Status        string `boil:"status"`
+		LastError     string `boil:"last_error"`
+		LastAttemptAt int64  `boil:"last_attempt_at"`
+	}
+	if err = queries.Raw(errorsQuery, uploadStatsRecentErrors).Bind(ctx, h, &recent); err != nil {
+		return nil, errors.New(op).Err(err).Msg("Failed to fetch recent upload errors.")
+	}
+
+	stats := summariseUploadCounts(counts)
+	byService := make(map[string]*UploadServiceStats, len(stats))
+	now := time.Now()
+	for i := range stats {
+		st := &stats[i]
+		byService[st.Service] = st
+
+		if st.Pending+st.InProgress+st.Failed == 0 {
+			continue
+		}
+		// created_at is not written in a single text format, so it cannot be compared in SQL: the oldest row is the
+		// first queued, by id, and its created_at is read through the model.
+		oldest, er := models.QsoUploads(
+			qm.Select(models.QsoUploadColumns.ID, models.QsoUploadColumns.CreatedAt),
+			models.QsoUploadWhere.Service.EQ(st.Service),
+			models.QsoUploadWhere.Status.IN([]string{status.Pending.String(), status.Failed.String(), status.InProgress.String()}),
+			qm.OrderBy(models.QsoUploadColumns.ID),
+		).One(ctx, h)
+		if er != nil {
+			return nil, errors.New(op).Err(er).Msgf("Failed to fetch the oldest %s upload.", st.Service)
+		}
+		if age := now.Sub(oldest.CreatedAt); age > 0 {
+			st.OldestPending = age.Truncate(time.Second)
+		}
+	}
+	for _, r := range recent {
+		st, ok := byService[r.Service]
+		if !ok {
+			continue
+		}
+		ue := UploadError{
+			UploadID: r.ID,
+			QsoID:    r.QsoID,
+			Action:   r.Action,
+			Status:   status.Status(r.Status),
+			Error:    r.LastError,
+		}
+		if r.LastAttemptAt > 0 {
+			ue.LastAttemptAt = time.Unix(r.LastAttemptAt, 0)
+		}
+		st.RecentErrors = append(st.RecentErrors, ue)
+	}
+
+	return stats, nil
+}
+
+// summariseUploadCounts folds the per service and status counts, ordered by service, into one entry per service
+// and works out the success rates.
+func summariseUploadCounts(counts []uploadStatusCount) []UploadServiceStats {
+	var stats []UploadServiceStats
+	for _, c := range counts {
+		if len(stats) == 0 || stats[len(stats)-1].Service != c.Service {
+			stats = append(stats, UploadServiceStats{Service: c.Service})
+		}
+		st := &stats[len(stats)-1]
+
+		switch status.Status(c.Status) {
+		case status.Pending:
+			st.Pending = c.Count
+		case status.InProgress:
+			st.InProgress = c.Count
+		case status.Uploaded:
+			st.Uploaded = c.Count
+		case status.Failed:
+			st.Failed = c.Count
+		case UploadStatusDead:
+			st.Dead = c.Count
+		}
+	}
+
+	for i := range stats {
+		if attempted := stats[i].Uploaded + stats[i].Failed + stats[i].Dead; attempted > 0 {
+			stats[i].SuccessRate = float64(stats[i].Uploaded) / float64(attempted)
+		}
+	}
+
+	return stats
+}
@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Station-Manager/enums/upload"
+	"github.com/Station-Manager/enums/upload/action"
+	"github.com/Station-Manager/enums/upload/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummariseUploadCounts(t *testing.T) {
+	stats := summariseUploadCounts([]uploadStatusCount{
+		{Service: "lotw", Status: "pending", Count: 4},
+		{Service: "qrz", Status: "dead", Count: 1},
+		{Service: "qrz", Status: "failed", Count: 1},
+		{Service: "qrz", Status: "in_progress", Count: 2},
+		{Service: "qrz", Status: "uploaded", Count: 6},
+	})
+
+	assert.Equal(t, []UploadServiceStats{
+		{Service: "lotw", Pending: 4},
+		{Service: "qrz", InProgress: 2, Uploaded: 6, Failed: 1, Dead: 1, SuccessRate: 0.75},
+	}, stats)
+
+	assert.Empty(t, summariseUploadCounts(nil))
+}
+
+func TestUploadQueueStats(t *testing.T) {
+	s := newTestService(t)
+	lb, sess := newTestLogbook(t, s)
+	insertQso := func(call string) int64 {
+		id, err := s.InsertQso(testQso(lb, sess, call, "20250107", "1430"))
+		require.NoError(t, err)
+		return id
+	}
+
+	stats, err := s.UploadQueueStats(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+
+	// The first upload queued is the oldest, but its created_at sorts after the second one's as text.
+	now := time.Now().UTC()
+	first, second, third, fourth := insertQso("DL1ABC"), insertQso("DL2ABC"), insertQso("DL3ABC"), insertQso("DL4ABC")
+	insertUpload(t, s, first, upload.OnlineServiceQRZ, action.Insert, status.Pending.String(), ", created_at",
+		now.Add(-2*time.Hour).Format(time.RFC3339))
+	failed := insertUpload(t, s, second, upload.OnlineServiceQRZ, action.Insert, status.Failed.String(),
+		", created_at, last_error, last_attempt_at", now.Add(-time.Hour).Format(time.DateTime), "timeout", now.Add(-2*time.Minute).Unix())
+	dead := insertUpload(t, s, third, upload.OnlineServiceQRZ, action.Insert, UploadStatusDead.String(),
+		", last_error, last_attempt_at", "rejected", now.Add(-time.Minute).Unix())
+	insertUpload(t, s, fourth, upload.OnlineServiceQRZ, action.Insert, status.Uploaded.String(), emptyString)
+	insertUpload(t, s, fourth, upload.OnlineServiceQRZ, action.Update, status.Uploaded.String(), emptyString)
+	insertUpload(t, s, first, upload.OnlineServiceLoTW, action.Insert, status.Uploaded.String(), emptyString)
+
+	stats, err = s.UploadQueueStats(context.Background())
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+
+	assert.Equal(t, UploadServiceStats{Service: upload.OnlineServiceLoTW.String(), Uploaded: 1, SuccessRate: 1}, stats[0])
+
+	qrz := stats[1]
+	assert.Equal(t, upload.OnlineServiceQRZ.String(), qrz.Service)
+	assert.Equal(t, []int64{1, 0, 2, 1, 1}, []int64{qrz.Pending, qrz.InProgress, qrz.Uploaded, qrz.Failed, qrz.Dead})
+	assert.InDelta(t, 0.5, qrz.SuccessRate, 1e-9)
+	assert.InDelta(t, 2*time.Hour, qrz.OldestPending, float64(time.Minute))
+	assert.Equal(t, []UploadError{
+		{UploadID: dead, QsoID: third, Action: action.Insert.String(), Status: UploadStatusDead, Error: "rejected",
+			LastAttemptAt: time.Unix(now.Add(-time.Minute).Unix(), 0)},
+		{UploadID: failed, QsoID: second, Action: action.Insert.String(), Status: status.Failed, Error: "timeout",
+			LastAttemptAt: time.Unix(now.Add(-2*time.Minute).Unix(), 0)},
+	}, qrz.RecentErrors)
+}